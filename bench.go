@@ -0,0 +1,145 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"time"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/bench"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+)
+
+// runBench measures the time and memory analysis and generation take for a
+// project and compares them with a stored baseline, exiting non-zero when
+// they regressed beyond the threshold. Without a baseline, the measurement
+// becomes one.
+func runBench(args []string) {
+	fs := flag.NewFlagSet("bench", flag.ExitOnError)
+	configPath := fs.String("config", "", "Path to configuration file")
+	projectPath := fs.String("project", ".", "Path to Go project")
+	framework := fs.String("framework", "auto", "Router framework (auto|fiber|gin|echo|chi|mux|httprouter|hertz|iris|nethttp|grpc-gateway)")
+	baselinePath := fs.String("baseline", bench.DefaultName, "Baseline file to compare against")
+	runs := fs.Int("runs", 5, "Number of runs; their medians are compared")
+	threshold := fs.Float64("threshold", 20, "Allowed slowdown or memory growth over the baseline, in percent")
+	update := fs.Bool("update", false, "Store the measurement as the new baseline instead of comparing")
+	fs.Parse(args)
+
+	config := defaultConfig()
+	config.ProjectPath = *projectPath
+	config.Framework = *framework
+	if *configPath != "" {
+		config = Config{}
+		if err := loadConfig(*configPath, &config); err != nil {
+			log.Fatalf("Failed to load config: %v", err)
+		}
+	}
+	if *runs < 1 {
+		*runs = 1
+	}
+
+	current, err := measure(config, *runs)
+	if err != nil {
+		log.Fatalf("Failed to benchmark: %v", err)
+	}
+	fmt.Printf("%s: %d runs, analyze %.1fms, generate %.1fms, allocated %.1fMiB\n", config.ProjectPath, current.Runs,
+		current.AnalyzeMillis, current.GenerateMillis, float64(current.AllocBytes)/(1<<20))
+
+	baseline, err := bench.Read(*baselinePath)
+	if *update || errors.Is(err, os.ErrNotExist) {
+		if err := current.Write(*baselinePath); err != nil {
+			log.Fatalf("Failed to store baseline: %v", err)
+		}
+		fmt.Printf("Baseline written: %s\n", *baselinePath)
+		return
+	}
+	if err != nil {
+		log.Fatalf("Failed to load baseline: %v", err)
+	}
+	if filepath.Clean(baseline.Project) != filepath.Clean(current.Project) {
+		log.Fatalf("Baseline %s was recorded for project %s, not %s; use a baseline of this project, or rerun with -update to replace it", *baselinePath, baseline.Project, current.Project)
+	}
+	if baseline.ToolVersion != current.ToolVersion {
+		fmt.Printf("Baseline recorded with %s, measured with %s\n", baseline.ToolVersion, current.ToolVersion)
+	}
+
+	regressions := bench.Compare(baseline, current, *threshold)
+	if len(regressions) == 0 {
+		fmt.Printf("No regression over %s (threshold %.0f%%)\n", *baselinePath, *threshold)
+		return
+	}
+	for _, regression := range regressions {
+		fmt.Printf("REGRESSION %s\n", regression)
+	}
+	fmt.Printf("Generation regressed beyond %.0f%% of %s; investigate, or rerun with -update to accept\n", *threshold, *baselinePath)
+	os.Exit(1)
+}
+
+// measure analyzes the project and generates its spec several times, with
+// the analyzer's output silenced, and returns the medians of the runs
+func measure(config Config, runs int) (bench.Result, error) {
+	generatorConfig, err := newGeneratorConfig(config)
+	if err != nil {
+		return bench.Result{}, fmt.Errorf("failed to load CODEOWNERS: %w", err)
+	}
+
+	stdout := os.Stdout
+	devNull, err := os.Open(os.DevNull)
+	if err != nil {
+		return bench.Result{}, err
+	}
+	defer devNull.Close()
+	os.Stdout = devNull
+	defer func() { os.Stdout = stdout }()
+
+	var analyzeTimes, generateTimes, allocs []float64
+	for i := 0; i < runs; i++ {
+		var before, after runtime.MemStats
+		runtime.GC()
+		runtime.ReadMemStats(&before)
+
+		ctx, cancel, err := runContext(config)
+		if err != nil {
+			return bench.Result{}, fmt.Errorf("invalid timeout: %w", err)
+		}
+		start := time.Now()
+		analysis, err := analyzeProject(ctx, config)
+		if err != nil {
+			cancel()
+			return bench.Result{}, fmt.Errorf("failed to analyze project: %w", err)
+		}
+		analyzed := time.Now()
+		specGenerator := generator.New(generatorConfig)
+		spec, err := specGenerator.Generate(ctx, analysis)
+		cancel()
+		if err != nil {
+			return bench.Result{}, fmt.Errorf("failed to generate spec: %w", err)
+		}
+		if err := specGenerator.MergeFragments(spec, config.Fragments); err != nil {
+			return bench.Result{}, err
+		}
+		if err := specGenerator.ApplyProfile(spec); err != nil {
+			return bench.Result{}, err
+		}
+		generated := time.Now()
+
+		runtime.ReadMemStats(&after)
+		analyzeTimes = append(analyzeTimes, float64(analyzed.Sub(start).Microseconds())/1000)
+		generateTimes = append(generateTimes, float64(generated.Sub(analyzed).Microseconds())/1000)
+		allocs = append(allocs, float64(after.TotalAlloc-before.TotalAlloc))
+	}
+
+	return bench.Result{
+		Time:           time.Now().UTC(),
+		ToolVersion:    currentVersion(),
+		Project:        config.ProjectPath,
+		Runs:           runs,
+		AnalyzeMillis:  bench.Median(analyzeTimes),
+		GenerateMillis: bench.Median(generateTimes),
+		AllocBytes:     uint64(bench.Median(allocs)),
+	}, nil
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/lockfile"
+)
+
+// writeLock records the inputs the spec was generated from in the lock file
+func writeLock(config Config, analysis *analyzer.Analysis) error {
+	lock, err := lockfile.Build(currentVersion(), config, config.ProjectPath, config.OutputPath, lockExtraInputs(config))
+	if err != nil {
+		return err
+	}
+	lock.Heuristics = map[string]string{
+		"framework":       analysis.Framework,
+		"dead_routes":     config.DeadRoutes,
+		"max_inline_enum": strconv.Itoa(config.MaxInlineEnum),
+	}
+	if len(config.AllMethods) > 0 {
+		lock.Heuristics["all_methods"] = strings.Join(config.AllMethods, ",")
+	}
+	if config.Profile != "" {
+		lock.Heuristics["profile"] = config.Profile
+	}
+	if err := lock.Write(config.LockFile); err != nil {
+		return err
+	}
+	fmt.Printf("Lock file written: %s\n", config.LockFile)
+	return nil
+}
+
+// lockExtraInputs are the inputs outside the project's source files
+func lockExtraInputs(config Config) []string {
+	extra := append([]string{}, config.Fragments...)
+	if config.BaseSpec != "" {
+		extra = append(extra, config.BaseSpec)
+	}
+	return extra
+}
+
+// runCheck reports whether the committed spec is stale relative to its lock
+// file: the inputs, config or tool changed since it was generated, or the
+// spec was edited by hand. It exits non-zero when it is.
+func runCheck(args []string) {
+	fs := flag.NewFlagSet("check", flag.ExitOnError)
+	lockPath := fs.String("lock", lockfile.DefaultName, "Lock file written with -lock when the spec was generated")
+	configPath := fs.String("config", "", "Configuration file the spec is generated with, to detect config changes")
+	fs.Parse(args)
+
+	locked, err := lockfile.Read(*lockPath)
+	if err != nil {
+		log.Fatalf("Failed to load lock file: %v", err)
+	}
+
+	var config interface{}
+	if *configPath != "" {
+		var loaded Config
+		if err := loadConfig(*configPath, &loaded); err != nil {
+			log.Fatalf("Failed to load config: %v", err)
+		}
+		config = loaded
+	}
+
+	var extra []string
+	for path := range locked.Extra {
+		extra = append(extra, path)
+	}
+	current, err := lockfile.Build(currentVersion(), config, locked.ProjectPath, locked.SpecPath, extra)
+	if err != nil {
+		log.Fatalf("Failed to hash inputs: %v", err)
+	}
+	if config == nil {
+		current.ConfigHash = ""
+	}
+
+	changes := lockfile.Compare(locked, current)
+	if len(changes) == 0 {
+		fmt.Printf("%s is up to date with %s\n", locked.SpecPath, *lockPath)
+		return
+	}
+	for _, change := range changes {
+		fmt.Printf("STALE %s\n", change)
+	}
+	fmt.Printf("%s is stale: %d change(s) since it was generated; regenerate it and commit the lock file\n", locked.SpecPath, len(changes))
+	os.Exit(1)
+}
@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/scaffold"
+)
+
+// runGenFixtures generates a Go file of example request bodies, as raw JSON
+// and typed structs, for handler unit tests
+func runGenFixtures(args []string) {
+	fs := flag.NewFlagSet("gen-fixtures", flag.ExitOnError)
+	specPath := fs.String("spec", "", "OpenAPI spec to generate fixtures from (yaml or json)")
+	outputDir := fs.String("output", "fixtures", "Directory to write "+scaffold.FixturesFile+" into")
+	pkg := fs.String("package", "", "Go package name of the fixtures (default: the output directory's name)")
+	force := fs.Bool("force", false, "Overwrite an existing "+scaffold.FixturesFile)
+	fs.Parse(args)
+
+	if *specPath == "" {
+		log.Fatalf("gen-fixtures requires -spec")
+	}
+	if *pkg == "" {
+		abs, err := filepath.Abs(*outputDir)
+		if err != nil {
+			log.Fatalf("Failed to resolve %s: %v", *outputDir, err)
+		}
+		*pkg = scaffold.PackageName(filepath.Base(abs))
+	}
+
+	spec, err := scaffold.Load(*specPath)
+	if err != nil {
+		log.Fatalf("Failed to load spec: %v", err)
+	}
+
+	files, err := scaffold.Fixtures(spec, *pkg)
+	if err != nil {
+		log.Fatalf("Failed to generate fixtures: %v", err)
+	}
+
+	if err := scaffold.Write(*outputDir, files, *force); err != nil {
+		log.Fatalf("Failed to write fixtures: %v", err)
+	}
+
+	fmt.Printf("Wrote fixtures to %s\n", filepath.Join(*outputDir, scaffold.FixturesFile))
+}
@@ -1,6 +1,12 @@
 // go.mod
 module github.com/Aman-s12345/go-openapispec-generator
 
-go 1.21
+go 1.23.0
 
 require gopkg.in/yaml.v3 v3.0.1
+
+require (
+	golang.org/x/mod v0.26.0 // indirect
+	golang.org/x/sync v0.16.0 // indirect
+	golang.org/x/tools v0.35.0
+)
@@ -0,0 +1,134 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"strings"
+)
+
+// RouteCall is a route registration recognized by a RouterAdapter
+type RouteCall struct {
+	Method     string     // HTTP method, e.g. GET
+	Path       string     // path relative to the router or group the call is made on
+	Handler    ast.Expr   // the handler argument
+	Middleware []ast.Expr // middleware arguments, if any
+}
+
+// RouterAdapter recognizes the routes and handlers of an in-house routing
+// wrapper, so projects built on one can be analyzed without changing the
+// analyzer. Everything an adapter doesn't match (groups, request bodies,
+// responses, query calls) is analyzed with the conventions of the
+// framework it was registered on top of.
+type RouterAdapter interface {
+	// MatchRouteCall reports whether a call in a RegisterRoutes function
+	// registers a route, e.g. api.Endpoint("GET", "/users/:id", GetUser)
+	MatchRouteCall(call *ast.CallExpr) (RouteCall, bool)
+
+	// MatchHandler reports whether a function is a handler and returns the
+	// name of its request context parameter
+	MatchHandler(funcDecl *ast.FuncDecl) (contextName string, ok bool)
+
+	// ExtractParams returns the parameters read by a call in a handler
+	// body, e.g. ctx.PathInt("id") or ctx.TenantHeader(). In must be path,
+	// query, header or cookie.
+	ExtractParams(call *ast.CallExpr, contextName string) []Parameter
+}
+
+type registeredAdapter struct {
+	base    string
+	adapter RouterAdapter
+}
+
+var adapters = make(map[string]registeredAdapter)
+
+// RegisterAdapter makes an adapter selectable as -framework name. base is
+// the built-in framework the wrapper is built on; its conventions apply to
+// whatever the adapter doesn't match. Adapters are registered from an init
+// function in the main package; registering a name twice is an error.
+func RegisterAdapter(name, base string, adapter RouterAdapter) error {
+	if _, exists := frameworks[name]; exists || name == FrameworkAuto {
+		return fmt.Errorf("framework %s is built in", name)
+	}
+	fw, exists := frameworks[base]
+	if !exists || fw.proto {
+		return fmt.Errorf("unsupported base framework for adapter %s: %s", name, base)
+	}
+	if _, exists := adapters[name]; exists {
+		return fmt.Errorf("adapter %s is already registered", name)
+	}
+	adapters[name] = registeredAdapter{base: base, adapter: adapter}
+	return nil
+}
+
+// parseAdapterRouteCall builds the route for a call the adapter recognizes.
+// It reports whether the call was one.
+func (a *Analyzer) parseAdapterRouteCall(callExpr *ast.CallExpr, basePath string, walk *routeWalk) bool {
+	if a.adapter == nil {
+		return false
+	}
+	routeCall, ok := a.adapter.MatchRouteCall(callExpr)
+	if !ok {
+		return false
+	}
+
+	method := strings.ToUpper(routeCall.Method)
+	handlerName := a.routeHandlerName(routeCall.Handler)
+	if !a.isHTTPMethod(method) || handlerName == "" {
+		fmt.Printf("[DEBUG] Skipping adapter route '%s %s' in %s: unsupported method or handler\n", routeCall.Method, routeCall.Path, walk.sourceFile)
+		return true
+	}
+
+	fullPath := basePath + routeCall.Path
+	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+		fullPath = a.routeFullPath(selExpr.X, basePath, routeCall.Path, walk.routeGroups)
+	}
+
+	route := a.newRoute(method, fullPath, handlerName, walk.packageName, walk.handlers, walk.analysis)
+	for _, arg := range routeCall.Middleware {
+		if name := a.middlewareName(arg); name != "" {
+			route.Middleware = append(route.Middleware, name)
+		}
+	}
+	route.SourceFile = walk.sourceFile
+	walk.analysis.Routes = append(walk.analysis.Routes, *route)
+	return true
+}
+
+// handleAdapterParams records the parameters the adapter reports for a call
+// in a handler body
+func (a *Analyzer) handleAdapterParams(callExpr *ast.CallExpr, handlerInfo *HandlerInfo) {
+	if a.adapter == nil {
+		return
+	}
+	for _, param := range a.adapter.ExtractParams(callExpr, a.contextName) {
+		if param.Type == "" {
+			param.Type = "string"
+		}
+		switch param.In {
+		case "path":
+			handlerInfo.PathParameters = append(handlerInfo.PathParameters, param.Name)
+			if param.Type != "string" {
+				if handlerInfo.PathParameterTypes == nil {
+					handlerInfo.PathParameterTypes = make(map[string]string)
+				}
+				handlerInfo.PathParameterTypes[param.Name] = param.Type
+			}
+		case "query":
+			handlerInfo.QueryParameters = append(handlerInfo.QueryParameters, QueryParameter{
+				Name:        param.Name,
+				Type:        param.Type,
+				Required:    param.Required,
+				Description: param.Description,
+				Default:     param.Default,
+				Enum:        param.Enum,
+				Source:      querySourceCall,
+			})
+		case "header":
+			handlerInfo.HeaderParameters = append(handlerInfo.HeaderParameters, param)
+		case "cookie":
+			handlerInfo.CookieParameters = append(handlerInfo.CookieParameters, param)
+		default:
+			fmt.Printf("[DEBUG] Ignoring adapter parameter '%s' of handler '%s': unsupported location '%s'\n", param.Name, handlerInfo.Name, param.In)
+		}
+	}
+}
@@ -1,63 +1,258 @@
 package analyzer
 
 import (
+	"context"
 	"fmt"
 	"go/ast"
 	"go/token"
+	"go/types"
 	"strings"
 )
 
 type Analyzer struct {
 	projectPath   string
 	sdkPackage    string
+	modulePath    string // module path from the project's go.mod, for the models' import paths
 	routesPattern string
+	frameworkName string
+	framework     framework
+	adapter       RouterAdapter // registered adapter selected with -framework, if any
+	contextName   string // name of the context parameter in the handler being analyzed
+	paramsName    string // name of the httprouter.Params parameter in the handler being analyzed
+	fiberV3       bool   // the route file being parsed imports Fiber v3
+	handlerDir    string // directory of the handler file being analyzed
+	handlerImports map[string]string // import paths of the handler file being analyzed, by name
+	presenters    map[string]string // presenter functions by import path and name, see collectPresenters
+	handlerVars   map[string]string // handler struct types of the variables in the RegisterRoutes being walked
+	methodKeys    map[string][]string // handler methods of the route package by method name
+	factories     map[string]bool     // handler factories of the route package, see factoryHandler
+	closures      map[string]*ast.FuncLit // inline closure handlers of the route file, see closureKey
+	importNames   map[string]bool         // names the route file imports handler packages under, see parseImportedHandlers
+	routeImports  map[string]string       // import paths of the route file, by name, for its inline closures
+	importedCtors map[string]string       // handler struct types returned by imported functions, by qualified name
+	handlerPkgs   map[string]*handlerPackage // handlers of imported project packages by directory
+	constants     map[string]ast.Expr     // constants of the route package and function being walked, see stringValue
+	allMethods    []string
+	debugHandler  string
+	includeStatic bool   // document Static file routes
+	modelsOnly    bool   // analyze the SDK models without the routes
+	routePrefixes map[string]string // configured prefixes of route packages
+	mountPrefixes map[string]string // prefixes the project mounts route packages at, see collectMountPrefixes
+	modelPackages []string // packages loaded like the SDK, see parseModelPackages
+	generics      map[string]genericType     // generic models by name, see registerGeneric
+	instances     map[string]genericInstance // generic models used with type arguments, by model name
+	typeArgs      map[string]string          // type arguments of the generic instance being instantiated
+	typePkgs      map[string]*packageTypes // type declarations of the packages models are loaded from, by import path
+	interfaces    map[string]bool          // interface types of the packages models are loaded from, see checkOneOf
+	skipTypes     bool                    // analyze with AST heuristics only, see loadTypes
+	exprTypes     map[typeSpan]types.Type // checked types of the project's expressions, see loadTypes
+	seenRefs      map[typeRef]bool        // types of other packages tried as models, see loadRefs
+	analysis      *Analysis               // being built, for the models loadTypedModel adds
+	stats         *Stats // of the analysis being built
+	debugFound    bool   // the -debug-handler handler was analyzed
+	strict        bool        // fail on files with syntax errors instead of skipping them
+	ctx           context.Context // of the analysis being run, see canceled
+	skipped       []FileError // files skipped for syntax errors, see skipFile
 	fileSet       *token.FileSet
 	models        map[string]Model // Store models for reference
+	namedTypes    map[string]string // underlying types of the models' named types, see Analysis.NamedTypes
+	enums         map[string][]string // values of the named types' constants, see Analysis.Enums
 }
 
-func New(projectPath, sdkPackage, routesPattern string) *Analyzer {
+type Config struct {
+	ProjectPath   string
+	SDKPackage    string
+	RoutesPattern string
+	Framework     string // auto (default), fiber, gin, echo, chi, mux, httprouter, hertz, iris, nethttp or grpc-gateway
+	AllMethods    []string // methods documented for routes registered with All (default: defaultAllMethods)
+	DebugHandler  string   // handler whose tracked state is dumped while it is analyzed
+	IncludeStatic bool     // document routes serving static files, app.Static("/assets", "./public")
+	ModelsOnly    bool     // analyze the SDK models only, for component-only specs
+	RoutePrefixes map[string]string // prefixes of route packages by directory relative to the project or package name
+	ModelPackages []string          // packages models are loaded from besides the SDK: directories relative to the project or import paths
+	SkipTypeCheck bool              // don't load the project with go/packages; rely on AST heuristics
+	Strict        bool              // fail on the first file with a syntax error instead of skipping it
+}
+
+// defaultAllMethods are the methods routes registered with All are documented under
+var defaultAllMethods = []string{"GET", "POST", "PUT", "DELETE", "PATCH"}
+
+func New(config Config) *Analyzer {
 	return &Analyzer{
-		projectPath:   projectPath,
-		sdkPackage:    sdkPackage,
-		routesPattern: routesPattern,
+		projectPath:   config.ProjectPath,
+		sdkPackage:    config.SDKPackage,
+		routesPattern: config.RoutesPattern,
+		frameworkName: config.Framework,
+		contextName:   "c",
 		fileSet:       token.NewFileSet(),
 		models:        make(map[string]Model),
+		allMethods:    config.AllMethods,
+		debugHandler:  config.DebugHandler,
+		includeStatic: config.IncludeStatic,
+		modelsOnly:    config.ModelsOnly,
+		routePrefixes: config.RoutePrefixes,
+		modelPackages: config.ModelPackages,
+		typePkgs:      make(map[string]*packageTypes),
+		interfaces:    make(map[string]bool),
+		handlerPkgs:   make(map[string]*handlerPackage),
+		generics:      make(map[string]genericType),
+		instances:     make(map[string]genericInstance),
+		skipTypes:     config.SkipTypeCheck,
+		seenRefs:      make(map[typeRef]bool),
+		strict:        config.Strict,
+		ctx:           context.Background(),
 	}
 }
 
-func (a *Analyzer) Analyze() (*Analysis, error) {
-	
+// Analyze analyzes the project's models, routes and handlers. It stops with
+// the context's error once the context is canceled or times out; the type
+// checking of loadTypes, which runs go list, is canceled with it.
+func (a *Analyzer) Analyze(ctx context.Context) (*Analysis, error) {
+	a.ctx = ctx
+	// Component-only specs need neither the framework nor the routes
+	if a.modelsOnly {
+		analysis := &Analysis{
+			Routes:     []Route{},
+			Models:     make(map[string]Model),
+			NamedTypes: make(map[string]string),
+			Enums:      make(map[string][]string),
+			Marshalers: make(map[string]string),
+		}
+		if err := a.parseSDKModels(analysis); err != nil {
+			return nil, fmt.Errorf("failed to parse SDK models: %w", err)
+		}
+		if err := a.parseModelPackages(analysis); err != nil {
+			return nil, err
+		}
+		if err := a.canceled(); err != nil {
+			return nil, err
+		}
+		a.loadReferencedModels(analysis)
+		a.checkOneOf(analysis)
+		a.instantiateGenerics(analysis)
+		a.applyEnums(analysis)
+		return a.checkSkipped(analysis)
+	}
+
+	if a.frameworkName == "" || a.frameworkName == FrameworkAuto {
+		name, err := a.detectFramework()
+		if err != nil {
+			return nil, err
+		}
+		fmt.Printf("Detected router framework: %s\n", name)
+		a.frameworkName = name
+	}
+	selected := a.frameworkName
+	if registered, ok := adapters[a.frameworkName]; ok {
+		a.adapter = registered.adapter
+		a.frameworkName = registered.base
+	}
+
+	fw, err := lookupFramework(a.frameworkName)
+	if err != nil {
+		return nil, err
+	}
+	a.framework = fw
+
+	allMethods := a.allMethods
+	if len(allMethods) == 0 {
+		allMethods = defaultAllMethods
+	}
+	a.allMethods = nil
+	for _, method := range allMethods {
+		if !containsString(defaultAllMethods, strings.ToUpper(method)) {
+			return nil, fmt.Errorf("unsupported method for All routes: %s (supported: %s)", method, strings.Join(defaultAllMethods, ", "))
+		}
+		a.allMethods = append(a.allMethods, strings.ToUpper(method))
+	}
+
 	analysis := &Analysis{
-		Routes: []Route{},
-		Models: make(map[string]Model),
+		Routes:     []Route{},
+		Models:     make(map[string]Model),
+		NamedTypes: make(map[string]string),
+		Enums:      make(map[string][]string),
+		Marshalers: make(map[string]string),
+		Framework:  selected,
+	}
+	a.stats = &analysis.Stats
+	a.analysis = analysis
+
+	// gRPC-gateway services are described by their .proto files alone
+	if a.framework.proto {
+		if err := a.parseProtoFiles(analysis); err != nil {
+			return nil, fmt.Errorf("failed to parse proto files: %w", err)
+		}
+		return analysis, nil
 	}
 
 	// Parse SDK models first
 	if err := a.parseSDKModels(analysis); err != nil {
 		return nil, fmt.Errorf("failed to parse SDK models: %w", err)
 	}
+	if err := a.parseModelPackages(analysis); err != nil {
+		return nil, err
+	}
+	if err := a.canceled(); err != nil {
+		return nil, err
+	}
+	a.loadReferencedModels(analysis)
+	a.checkOneOf(analysis)
+	a.instantiateGenerics(analysis)
+	a.applyEnums(analysis)
 
 	// Store models in analyzer for reference during route parsing
 	a.models = analysis.Models
+	a.namedTypes = analysis.NamedTypes
+	a.enums = analysis.Enums
+
+	if !a.skipTypes {
+		a.loadTypes()
+	}
+	if err := a.canceled(); err != nil {
+		return nil, err
+	}
 
 	// Parse route files
 	if err := a.parseRoutes(analysis); err != nil {
 		return nil, fmt.Errorf("failed to parse routes: %w", err)
 	}
 
-	return analysis, nil
+	analysis.Routes = a.expandPathPatterns(analysis.Routes)
+	a.applyParameterExamples(analysis)
+
+	if a.debugHandler != "" && !a.debugFound {
+		fmt.Printf("Warning: debug handler '%s' not found among the handlers next to the route files\n", a.debugHandler)
+	}
+
+	return a.checkSkipped(analysis)
+}
+
+// canceled returns an error once the context of the analysis is canceled,
+// with the cause of the cancellation, like a timeout
+func (a *Analyzer) canceled() error {
+	if a.ctx.Err() != nil {
+		return fmt.Errorf("analysis canceled: %w", context.Cause(a.ctx))
+	}
+	return nil
 }
 
 func (a *Analyzer) analyzeHandlerFunction(funcDecl *ast.FuncDecl) *HandlerInfo {
-	// Check if it's a handler function (takes *fiber.Ctx and returns error)
-	if !a.isFiberHandler(funcDecl) {
+	// Check if it's a handler function (takes the framework's context)
+	if !a.isHandlerFunc(funcDecl) {
 		return nil
 	}
+	a.contextName = a.handlerContextName(funcDecl)
+	if a.framework.routerParams {
+		a.paramsName = paramName(funcDecl, 2, "ps")
+	}
 
 	handlerInfo := &HandlerInfo{
 		Name:            funcDecl.Name.Name,
 		Package:         a.sdkPackage,
 		QueryParameters: []QueryParameter{},
+		Annotations:     a.extractAnnotations(funcDecl.Doc),
+		NotImplemented:  a.isStubHandler(funcDecl),
+		WebSocket:       a.upgradesWebSocket(funcDecl),
 	}
 
 	// Track variables that are assigned from new() or var declarations
@@ -72,6 +267,8 @@ func (a *Analyzer) analyzeHandlerFunction(funcDecl *ast.FuncDecl) *HandlerInfo {
 	serviceCallResults := make(map[string]string)
 	// Track response variables created with struct literals
 	responseVariables := make(map[string]string)
+	// Track the element types of channels, for responses built in goroutines
+	channelTypes := make(map[string]string)
 
 	// First pass: collect all variable declarations, assignments and service calls
 	ast.Inspect(funcDecl, func(n ast.Node) bool {
@@ -86,6 +283,8 @@ func (a *Analyzer) analyzeHandlerFunction(funcDecl *ast.FuncDecl) *HandlerInfo {
 								// Check if it's an anonymous struct
 								if structType, ok := valueSpec.Type.(*ast.StructType); ok {
 									anonymousStructs[name.Name] = structType
+								} else if chanType, ok := valueSpec.Type.(*ast.ChanType); ok {
+									channelTypes[name.Name] = a.extractTypeFromExpr(chanType.Value)
 								} else {
 									// Regular type
 									typeName := a.extractTypeFromExpr(valueSpec.Type)
@@ -109,14 +308,21 @@ func (a *Analyzer) analyzeHandlerFunction(funcDecl *ast.FuncDecl) *HandlerInfo {
 									}
 								}
 							}
+							// The checked type, when the package type-checked
+							a.trackCheckedType(name, variableTypes, responseVariables)
 						}
 					}
 				}
 			}
 		case *ast.AssignStmt:
+			a.trackChannels(node, variableTypes, channelTypes)
 			// Handle various assignment patterns
 			a.analyzeAssignment(node, variableTypes, queryParamAssignments, anonymousStructs, 
 				queryParserVars, serviceCallResults, responseVariables)
+			// The checked types, when the package type-checked
+			for _, lhs := range node.Lhs {
+				a.trackCheckedType(lhs, variableTypes, serviceCallResults, responseVariables, queryParserVars)
+			}
 		}
 		return true
 	})
@@ -137,11 +343,19 @@ func (a *Analyzer) analyzeHandlerFunction(funcDecl *ast.FuncDecl) *HandlerInfo {
 			if a.isQueryCall(node) {
 				a.handleQueryCall(node, funcDecl, queryParamAssignments, handlerInfo)
 			}
+			// Look for httprouter ps.ByName() and r.PathValue() calls
+			if a.isRouterParamCall(node) {
+				if basicLit, ok := node.Args[0].(*ast.BasicLit); ok {
+					handlerInfo.PathParameters = append(handlerInfo.PathParameters, strings.Trim(basicLit.Value, `"`))
+				}
+			}
 			// Look for typed query calls (c.QueryInt, c.QueryBool, etc.)
 			a.handleTypedQueryCalls(node, handlerInfo)
+			// Look for parameters read through a custom router adapter
+			a.handleAdapterParams(node, handlerInfo)
 			// Look for c.JSON() patterns
 			if a.isJSONResponseCall(node) && len(node.Args) > 0 {
-				a.handleJSONResponseCall(node, serviceCallResults, responseVariables, variableTypes, handlerInfo)
+				a.handleJSONResponseCall(node, serviceCallResults, responseVariables, variableTypes, channelTypes, handlerInfo)
 			}
 			// Look for response helper calls
 			if a.isResponseHelperCall(node) {
@@ -152,6 +366,29 @@ func (a *Analyzer) analyzeHandlerFunction(funcDecl *ast.FuncDecl) *HandlerInfo {
 		}
 		return true
 	})
+	a.extractPathParameterTypes(funcDecl, handlerInfo)
+	a.extractHeaderParameters(funcDecl, handlerInfo)
+	a.extractCookieParameters(funcDecl, handlerInfo)
+	a.extractFormFields(funcDecl, handlerInfo)
+	handlerInfo.File = a.fileResponse(funcDecl)
+	handlerInfo.Statuses = a.responseStatuses(funcDecl)
+	handlerInfo.Accepted = hasStatus(handlerInfo.Statuses, 202)
+
+	if funcDecl.Name.Name == a.debugHandler || handlerKey(funcDecl) == a.debugHandler {
+		anonymousStructTypes := make(map[string]string)
+		for name, structType := range anonymousStructs {
+			anonymousStructTypes[name] = fmt.Sprintf("struct with %d fields", len(structType.Fields.List))
+		}
+		a.dumpHandler(funcDecl, handlerInfo, handlerState{
+			{"Variable types", variableTypes},
+			{"Anonymous structs", anonymousStructTypes},
+			{"Query parameter assignments", queryParamAssignments},
+			{"Query parser variables", queryParserVars},
+			{"Service call results", serviceCallResults},
+			{"Response variables", responseVariables},
+			{"Channel element types", channelTypes},
+		})
+	}
 
 	return handlerInfo
 }
@@ -219,6 +456,12 @@ func (a *Analyzer) handleCompositeLitAssignment(varName string, compLit *ast.Com
 func (a *Analyzer) handleCallExprAssignment(varName string, callExpr *ast.CallExpr,
 	variableTypes, queryParamAssignments, queryParserVars, serviceCallResults map[string]string) {
 	
+	// Track presenter results: dto := toDTO(user)
+	if responseType := a.presenterCallType(callExpr); responseType != "" {
+		serviceCallResults[varName] = responseType
+		return
+	}
+
 	if identFunc, ok := callExpr.Fun.(*ast.Ident); ok {
 		if identFunc.Name == "new" && len(callExpr.Args) > 0 {
 			if typeName := a.extractTypeFromExpr(callExpr.Args[0]); typeName != "" {
@@ -241,12 +484,61 @@ func (a *Analyzer) handleCallExprAssignment(varName string, callExpr *ast.CallEx
 }
 
 
+// trackChannels records the element type of channels made in the handler,
+// results := make(chan sdk.User), and the type of values received from them,
+// user := <-results. The element type is the channel's checked one when the
+// package type-checked; otherwise it is read from the make call, and
+// channels and variables are matched by name, a heuristic that shadowed or
+// reassigned variables defeat.
+func (a *Analyzer) trackChannels(node *ast.AssignStmt, variableTypes, channelTypes map[string]string) {
+	if len(node.Rhs) != 1 {
+		return
+	}
+	ident, ok := node.Lhs[0].(*ast.Ident)
+	if !ok {
+		return
+	}
+	switch rhs := node.Rhs[0].(type) {
+	case *ast.CallExpr:
+		if fun, ok := rhs.Fun.(*ast.Ident); ok && fun.Name == "make" && len(rhs.Args) > 0 {
+			if chanType, ok := rhs.Args[0].(*ast.ChanType); ok {
+				channelTypes[ident.Name] = a.extractTypeFromExpr(chanType.Value)
+				if elemType := a.typedElemModel(ident); elemType != "" {
+					channelTypes[ident.Name] = elemType
+				}
+			}
+		}
+	case *ast.UnaryExpr:
+		if elemType := receivedType(rhs, channelTypes); elemType != "" {
+			variableTypes[ident.Name] = elemType
+		}
+	}
+}
+
+// receivedType returns the element type of a receive from a tracked channel
+func receivedType(expr ast.Expr, channelTypes map[string]string) string {
+	unary, ok := expr.(*ast.UnaryExpr)
+	if !ok || unary.Op != token.ARROW {
+		return ""
+	}
+	if ch, ok := unary.X.(*ast.Ident); ok {
+		return channelTypes[ch.Name]
+	}
+	return ""
+}
+
 // handleBodyParserCall handles c.BodyParser() calls
 func (a *Analyzer) handleBodyParserCall(node *ast.CallExpr, variableTypes map[string]string,
 	anonymousStructs map[string]*ast.StructType, handlerInfo *HandlerInfo) {
 	
+	// The bind target is always the last argument: c.BodyParser(&req),
+	// json.NewDecoder(r.Body).Decode(&req), render.DecodeJSON(r.Body, &req)
+	if requestType := a.typedModel(node.Args[len(node.Args)-1]); a.attempt("typed", requestType != "") {
+		handlerInfo.RequestType = requestType
+		return
+	}
 	var varName string
-	switch arg := node.Args[0].(type) {
+	switch arg := node.Args[len(node.Args)-1].(type) {
 	case *ast.UnaryExpr:
 		if ident, ok := arg.X.(*ast.Ident); ok {
 			varName = ident.Name
@@ -257,13 +549,13 @@ func (a *Analyzer) handleBodyParserCall(node *ast.CallExpr, variableTypes map[st
 	
 	if varName != "" {
 		// Check if it's an anonymous struct
-		if structType, exists := anonymousStructs[varName]; exists {
+		if structType, exists := anonymousStructs[varName]; a.attempt("anonymous_struct", exists) {
 			model := a.parseAnonymousStructWithContext(structType, handlerInfo.Name)
 			handlerInfo.RequestType = model.Name
 			if handlerInfo.AnonymousRequestModel == nil {
 				handlerInfo.AnonymousRequestModel = &model
 			}
-		} else if typeName, exists := variableTypes[varName]; exists {
+		} else if typeName, exists := variableTypes[varName]; a.attempt("variable_type", exists) {
 			handlerInfo.RequestType = a.cleanTypeName(typeName)
 		}
 	}
@@ -271,30 +563,71 @@ func (a *Analyzer) handleBodyParserCall(node *ast.CallExpr, variableTypes map[st
 
 // handleJSONResponseCall handles c.JSON() calls to detect response types
 func (a *Analyzer) handleJSONResponseCall(node *ast.CallExpr, serviceCallResults, responseVariables, 
-	variableTypes map[string]string, handlerInfo *HandlerInfo) {
+	variableTypes, channelTypes map[string]string, handlerInfo *HandlerInfo) {
 	
-	arg := node.Args[0]
+	// Gin and Echo take the status code first: c.JSON(http.StatusOK, body);
+	// chi's render.JSON(w, r, body) takes it last
+	bodyArg := a.framework.jsonBodyArg
+	if bodyArg < 0 {
+		bodyArg = len(node.Args) - 1
+	}
+	if bodyArg >= len(node.Args) {
+		return
+	}
+	arg := node.Args[bodyArg]
+
+	// The checked type of the argument, when the package type-checked:
+	// a variable, a function's result or an imported model
+	if responseType := a.typedModel(arg); a.attempt("typed", responseType != "") {
+		handlerInfo.ResponseType = responseType
+		return
+	}
+
+	// Check for presenter calls: c.JSON(toDTO(user))
+	if callExpr, ok := arg.(*ast.CallExpr); ok {
+		if responseType := a.presenterCallType(callExpr); a.attempt("presenter", responseType != "") {
+			handlerInfo.ResponseType = a.cleanTypeName(responseType)
+			return
+		}
+	}
 	
 	// Check if the argument is a variable
 	if ident, ok := arg.(*ast.Ident); ok {
 		// Check various sources for the variable type
-		if responseType, exists := serviceCallResults[ident.Name]; exists {
+		if responseType, exists := serviceCallResults[ident.Name]; a.attempt("service_call", exists) {
 			handlerInfo.ResponseType = a.cleanTypeName(responseType)
 			return
 		}
-		if responseType, exists := responseVariables[ident.Name]; exists {
+		if responseType, exists := responseVariables[ident.Name]; a.attempt("response_variable", exists) {
 			handlerInfo.ResponseType = a.cleanTypeName(responseType)
 			return
 		}
-		if responseType, exists := variableTypes[ident.Name]; exists && a.isResponseType(responseType) {
+		if responseType, exists := variableTypes[ident.Name]; a.attempt("variable_name", exists && a.isResponseType(responseType)) {
 			handlerInfo.ResponseType = a.cleanTypeName(responseType)
 			return
 		}
+		// Variables declared with a model type, e.g. filled in by goroutines,
+		// or a named type or alias of one, like sdk.Items
+		if responseType, exists := variableTypes[ident.Name]; exists {
+			_, isInstance := a.instances[a.cleanTypeName(responseType)]
+			_, isNamed := a.namedTypes[a.cleanTypeName(responseType)]
+			if _, isModel := a.models[a.cleanTypeName(responseType)]; a.attempt("variable_type", isModel || isInstance || isNamed) {
+				handlerInfo.ResponseType = a.cleanTypeName(responseType)
+				return
+			}
+		}
+	}
+
+	// Check for values received from a channel: c.JSON(<-results)
+	if responseType := receivedType(arg, channelTypes); a.attempt("channel", responseType != "") {
+		handlerInfo.ResponseType = a.cleanTypeName(responseType)
+		return
 	}
 	
-	// Check for fiber.Map
+	// Check for fiber.Map (iris.Map)
 	if selExpr, ok := arg.(*ast.SelectorExpr); ok {
-		if ident, ok := selExpr.X.(*ast.Ident); ok && ident.Name == "fiber" && selExpr.Sel.Name == "Map" {
+		ident, ok := selExpr.X.(*ast.Ident)
+		if a.attempt("map", ok && (ident.Name == "fiber" || ident.Name == "iris") && selExpr.Sel.Name == "Map") {
 			handlerInfo.ResponseType = "StandardResponse"
 			return
 		}
@@ -302,11 +635,44 @@ func (a *Analyzer) handleJSONResponseCall(node *ast.CallExpr, serviceCallResults
 	
 	// Original logic for inline response types
 	responseType := a.extractResponseType(arg)
-	if responseType != "" {
+	if a.attempt("inline", responseType != "") {
 		handlerInfo.ResponseType = a.cleanTypeName(responseType)
 	}
 }
 
+// attempt counts a heuristic tried on a type in the analysis stats, and the
+// type as resolved by it on a hit, which it returns
+func (a *Analyzer) attempt(heuristic string, hit bool) bool {
+	if a.stats == nil {
+		return hit
+	}
+	if a.stats.Attempts == nil {
+		a.stats.Attempts = make(map[string]int)
+		a.stats.Resolved = make(map[string]int)
+	}
+	a.stats.Attempts[heuristic]++
+	if hit {
+		a.stats.Resolved[heuristic]++
+	}
+	return hit
+}
+
+// matched counts a request or response type that matched a model in the
+// analysis stats
+func (a *Analyzer) matched() {
+	if a.stats != nil {
+		a.stats.Matched++
+	}
+}
+
+// unresolved records a request or response type that matched no model in
+// the analysis stats
+func (a *Analyzer) unresolved(typeName string) {
+	if a.stats != nil {
+		a.stats.Unresolved = append(a.stats.Unresolved, typeName)
+	}
+}
+
 // isResponseType checks if a type name is likely a response type
 func (a *Analyzer) isResponseType(typeName string) bool {
 	cleanType := a.cleanTypeName(typeName)
@@ -0,0 +1,72 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"path/filepath"
+	"strings"
+	"unicode"
+)
+
+// closureKey records a function literal passed as a route's handler, like
+//
+//	v1.Get("/health", func(c *fiber.Ctx) error { ... })
+//
+// and returns the key it is recorded under. The closure is analyzed once the
+// route's method and path are known, see closureHandler.
+func (a *Analyzer) closureKey(funcLit *ast.FuncLit) string {
+	key := fmt.Sprintf("func@%d", funcLit.Pos())
+	a.closures[key] = funcLit
+	return key
+}
+
+// closureHandler analyzes a closure recorded by closureKey as a handler named
+// after its route, GET /api/users/:id becoming GetApiUsersById, and adds it
+// to handlers. It returns the handler's name.
+func (a *Analyzer) closureHandler(key, method, fullPath string, handlers map[string]HandlerInfo) string {
+	funcLit := a.closures[key]
+	name := closureName(method, fullPath)
+	if _, exists := handlers[name]; exists {
+		return name
+	}
+
+	a.handlerDir = filepath.Dir(a.fileSet.Position(funcLit.Pos()).Filename)
+	a.handlerImports = a.routeImports
+	handler := &ast.FuncDecl{
+		Name: ast.NewIdent(name),
+		Type: funcLit.Type,
+		Body: funcLit.Body,
+	}
+	if handlerInfo := a.analyzeHandlerFunction(handler); handlerInfo != nil {
+		handlers[name] = *handlerInfo
+		fmt.Printf("[DEBUG] Inline handler '%s': Request=%s, Response=%s, QueryParams=%d\n",
+			name, handlerInfo.RequestType, handlerInfo.ResponseType, len(handlerInfo.QueryParameters))
+	}
+	return name
+}
+
+// closureName builds a handler name from a route's method and path
+func closureName(method, fullPath string) string {
+	var name strings.Builder
+	name.WriteString(exportedName(strings.ToLower(method)))
+	for _, segment := range strings.Split(fullPath, "/") {
+		isParam := strings.HasPrefix(segment, ":") || strings.HasPrefix(segment, "{")
+		for _, word := range strings.FieldsFunc(segment, func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		}) {
+			if isParam {
+				name.WriteString("By")
+				isParam = false
+			}
+			name.WriteString(exportedName(word))
+		}
+	}
+	return name.String()
+}
+
+func exportedName(word string) string {
+	if word == "" {
+		return ""
+	}
+	return strings.ToUpper(word[:1]) + word[1:]
+}
@@ -0,0 +1,128 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// recordConstants records the values of a const declaration's constants,
+// so route paths built from them can be folded by stringValue
+func recordConstants(decl *ast.GenDecl, constants map[string]ast.Expr) {
+	if decl.Tok != token.CONST {
+		return
+	}
+	for _, spec := range decl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		for i, name := range valueSpec.Names {
+			if i < len(valueSpec.Values) {
+				constants[name.Name] = valueSpec.Values[i]
+			}
+		}
+	}
+}
+
+// stringValue returns the value of a statically known string expression:
+// a string literal, a constant of the route package or function, a
+// concatenation of those, or fmt.Sprintf with a constant format and
+// arguments
+//
+//	const usersPath = "/users"
+//	app.Get(usersPath+"/:id", GetUser)
+//	app.Get(fmt.Sprintf("%s/%s", apiPrefix, "health"), Health)
+func (a *Analyzer) stringValue(expr ast.Expr) (string, bool) {
+	value, ok := a.constValue(expr, 0)
+	if !ok {
+		return "", false
+	}
+	s, ok := value.(string)
+	return s, ok
+}
+
+// maxConstDepth bounds how many constants deep constValue follows a value
+const maxConstDepth = 16
+
+// constValue folds a constant expression to a string or an int64
+func (a *Analyzer) constValue(expr ast.Expr, depth int) (interface{}, bool) {
+	if depth > maxConstDepth {
+		return nil, false
+	}
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.STRING:
+			s, err := strconv.Unquote(e.Value)
+			return s, err == nil
+		case token.INT:
+			n, err := strconv.ParseInt(e.Value, 0, 64)
+			return n, err == nil
+		}
+	case *ast.ParenExpr:
+		return a.constValue(e.X, depth)
+	case *ast.Ident:
+		if value, exists := a.constants[e.Name]; exists {
+			return a.constValue(value, depth+1)
+		}
+	case *ast.BinaryExpr:
+		if e.Op != token.ADD {
+			return nil, false
+		}
+		x, ok := a.constValue(e.X, depth)
+		if !ok {
+			return nil, false
+		}
+		y, ok := a.constValue(e.Y, depth)
+		if !ok {
+			return nil, false
+		}
+		switch x := x.(type) {
+		case string:
+			if y, ok := y.(string); ok {
+				return x + y, true
+			}
+		case int64:
+			if y, ok := y.(int64); ok {
+				return x + y, true
+			}
+		}
+	case *ast.CallExpr:
+		return a.sprintfValue(e, depth)
+	}
+	return nil, false
+}
+
+// sprintfValue folds fmt.Sprintf calls whose format and arguments are constant
+func (a *Analyzer) sprintfValue(callExpr *ast.CallExpr, depth int) (interface{}, bool) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "Sprintf" || len(callExpr.Args) == 0 {
+		return nil, false
+	}
+	if pkg, ok := selExpr.X.(*ast.Ident); !ok || pkg.Name != "fmt" {
+		return nil, false
+	}
+
+	format, ok := a.stringValue(callExpr.Args[0])
+	if !ok {
+		return nil, false
+	}
+	args := make([]interface{}, 0, len(callExpr.Args)-1)
+	for _, arg := range callExpr.Args[1:] {
+		value, ok := a.constValue(arg, depth)
+		if !ok {
+			return nil, false
+		}
+		args = append(args, value)
+	}
+
+	// Mismatched verbs and arguments leave a %!verb in the result
+	result := fmt.Sprintf(format, args...)
+	if strings.Contains(result, "%!") {
+		return nil, false
+	}
+	return result, true
+}
@@ -0,0 +1,44 @@
+package analyzer
+
+import "go/ast"
+
+// cookieName returns the cookie a call reads, with its default value:
+// c.Cookies("session_id", "guest") with Fiber, c.Cookie with Gin, Echo and
+// Hertz, ctx.GetCookie with Iris and r.Cookie on the request with net/http
+// routers
+func (a *Analyzer) cookieName(callExpr *ast.CallExpr) (string, interface{}, bool) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || len(callExpr.Args) == 0 {
+		return "", nil, false
+	}
+	ident, ok := selExpr.X.(*ast.Ident)
+	if !ok || ident.Name != a.contextName || !containsString(a.framework.cookieMethods, selExpr.Sel.Name) {
+		return "", nil, false
+	}
+	name, ok := a.stringValue(callExpr.Args[0])
+	if !ok {
+		return "", nil, false
+	}
+	// Fiber's Cookies takes the default as an optional second argument
+	var defaultValue interface{}
+	if len(callExpr.Args) > 1 {
+		if value, ok := a.stringValue(callExpr.Args[1]); ok && value != "" {
+			defaultValue = value
+		}
+	}
+	return name, defaultValue, true
+}
+
+// extractCookieParameters records the cookies a handler reads, required
+// like headers when the handler returns without them, see readParameters
+func (a *Analyzer) extractCookieParameters(funcDecl *ast.FuncDecl, handlerInfo *HandlerInfo) {
+	for _, param := range readParameters(funcDecl, "cookie", a.cookieName) {
+		exists := false
+		for _, existing := range handlerInfo.CookieParameters {
+			exists = exists || existing.Name == param.Name
+		}
+		if !exists {
+			handlerInfo.CookieParameters = append(handlerInfo.CookieParameters, param)
+		}
+	}
+}
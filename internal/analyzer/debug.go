@@ -0,0 +1,93 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+// handlerState is what the analyzer tracked while analyzing a handler, by
+// kind of tracking, for the -debug-handler dump
+type handlerState []struct {
+	name   string
+	values map[string]string
+}
+
+// dumpHandler prints the state tracked for the handler selected with
+// -debug-handler and what was inferred from it
+func (a *Analyzer) dumpHandler(funcDecl *ast.FuncDecl, handlerInfo *HandlerInfo, state handlerState) {
+	a.debugFound = true
+
+	position := a.fileSet.Position(funcDecl.Pos())
+	fmt.Printf("=== Handler %s (%s:%d) ===\n", funcDecl.Name.Name, a.relativePath(position.Filename), position.Line)
+	fmt.Printf("Framework: %s, context: %s\n", a.framework.name, a.contextName)
+	if a.paramsName != "" {
+		fmt.Printf("Router params: %s\n", a.paramsName)
+	}
+
+	for _, tracked := range state {
+		fmt.Printf("%s:\n", tracked.name)
+		if len(tracked.values) == 0 {
+			fmt.Println("  (none)")
+			continue
+		}
+		names := make([]string, 0, len(tracked.values))
+		for name := range tracked.values {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			fmt.Printf("  %s: %s\n", name, tracked.values[name])
+		}
+	}
+
+	fmt.Println("Inferred:")
+	fmt.Printf("  request type: %s\n", debugValue(handlerInfo.RequestType))
+	if handlerInfo.AnonymousRequestModel != nil {
+		fmt.Printf("  anonymous request model: %s (%d fields)\n", handlerInfo.AnonymousRequestModel.Name, len(handlerInfo.AnonymousRequestModel.Fields))
+	}
+	fmt.Printf("  response type: %s\n", debugValue(handlerInfo.ResponseType))
+	for _, param := range handlerInfo.QueryParameters {
+		fmt.Printf("  query parameter: %s %s (required: %t)\n", param.Name, param.Type, param.Required)
+	}
+	for _, name := range handlerInfo.PathParameters {
+		paramType := "string"
+		if typed, ok := handlerInfo.PathParameterTypes[name]; ok {
+			paramType = typed
+		}
+		fmt.Printf("  path parameter: %s %s\n", name, paramType)
+	}
+	for _, param := range handlerInfo.HeaderParameters {
+		fmt.Printf("  header parameter: %s %s\n", param.Name, param.Type)
+	}
+	for _, param := range handlerInfo.CookieParameters {
+		fmt.Printf("  cookie parameter: %s %s\n", param.Name, param.Type)
+	}
+	if handlerInfo.File != nil {
+		fmt.Printf("  file response: %s (attachment: %t)\n", handlerInfo.File.MediaType, handlerInfo.File.Attachment)
+	}
+	if len(handlerInfo.Statuses) > 0 {
+		fmt.Printf("  response statuses: %v\n", handlerInfo.Statuses)
+	}
+	for _, field := range handlerInfo.FormFields {
+		fmt.Printf("  form field: %s %s (required: %t)\n", field.Name, field.Type, field.Required)
+	}
+	if len(handlerInfo.Annotations) > 0 {
+		keys := make([]string, 0, len(handlerInfo.Annotations))
+		for key := range handlerInfo.Annotations {
+			keys = append(keys, "@"+key)
+		}
+		sort.Strings(keys)
+		fmt.Printf("  annotations: %s\n", strings.Join(keys, ", "))
+	}
+	fmt.Printf("  not implemented: %t\n", handlerInfo.NotImplemented)
+	fmt.Println("===")
+}
+
+func debugValue(value string) string {
+	if value == "" {
+		return "(none)"
+	}
+	return value
+}
@@ -0,0 +1,131 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FrameworkAuto picks the framework from the imports of the route files
+const FrameworkAuto = "auto"
+
+// frameworkImports maps import path prefixes to the framework they belong to
+var frameworkImports = []struct {
+	prefix    string
+	framework string
+}{
+	{"github.com/gofiber/fiber", FrameworkFiber},
+	{"github.com/gin-gonic/gin", FrameworkGin},
+	{"github.com/labstack/echo", FrameworkEcho},
+	{"github.com/go-chi/chi", FrameworkChi},
+	{"github.com/gorilla/mux", FrameworkMux},
+	{"github.com/julienschmidt/httprouter", FrameworkHTTPRouter},
+	{"github.com/cloudwego/hertz", FrameworkHertz},
+	{"github.com/kataras/iris", FrameworkIris},
+}
+
+// detectFramework inspects the imports of the route files and of the
+// handler files next to them. A project whose routes import several
+// frameworks is an error, since each is analyzed differently. Without any
+// router import, routes importing only net/http use the Go 1.22 ServeMux,
+// projects with google.api.http bindings are grpc-gateway and all others
+// fall back to Fiber.
+func (a *Analyzer) detectFramework() (string, error) {
+	routeFiles, err := filepath.Glob(filepath.Join(a.projectPath, a.routesPattern))
+	if err != nil {
+		return "", err
+	}
+
+	dirs := make(map[string]bool)
+	for _, routeFile := range routeFiles {
+		dirs[filepath.Dir(routeFile)] = true
+	}
+
+	// Files importing each detected framework
+	found := make(map[string][]string)
+	netHTTP := false
+	for dir := range dirs {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		if err != nil {
+			return "", err
+		}
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+			src, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ImportsOnly)
+			if err != nil {
+				continue
+			}
+			for _, imp := range src.Imports {
+				path := strings.Trim(imp.Path.Value, `"`)
+				if name := importedFramework(path); name != "" {
+					found[name] = append(found[name], a.relativePath(file))
+				}
+				netHTTP = netHTTP || path == "net/http"
+			}
+		}
+	}
+
+	switch len(found) {
+	case 0:
+		if netHTTP {
+			return FrameworkNetHTTP, nil
+		}
+		if a.hasHTTPRuleProtos() {
+			return FrameworkGRPCGateway, nil
+		}
+		fmt.Printf("[DEBUG] No router framework imports found in %s; assuming %s\n", a.routesPattern, FrameworkFiber)
+		return FrameworkFiber, nil
+	case 1:
+		for name := range found {
+			return name, nil
+		}
+	}
+
+	var detected []string
+	for name, files := range found {
+		sort.Strings(files)
+		detected = append(detected, fmt.Sprintf("%s (%s)", name, strings.Join(files, ", ")))
+	}
+	sort.Strings(detected)
+	return "", fmt.Errorf("routes import several router frameworks: %s; select one with -framework", strings.Join(detected, "; "))
+}
+
+func importedFramework(path string) string {
+	for _, imp := range frameworkImports {
+		if path == imp.prefix || strings.HasPrefix(path, imp.prefix+"/") {
+			return imp.framework
+		}
+	}
+	return ""
+}
+
+// hasHTTPRuleProtos reports whether any of the project's .proto files
+// declares google.api.http bindings
+func (a *Analyzer) hasHTTPRuleProtos() bool {
+	found := false
+	filepath.Walk(a.projectPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if path != a.projectPath && (protoSkippedDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, ".proto") {
+			if src, err := os.ReadFile(path); err == nil && strings.Contains(string(src), "google.api.http") {
+				found = true
+				return filepath.SkipAll
+			}
+		}
+		return nil
+	})
+	return found
+}
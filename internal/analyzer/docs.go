@@ -0,0 +1,70 @@
+package analyzer
+
+import (
+	"go/ast"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// innerSpaces matches the runs of whitespace normalized in descriptions
+var innerSpaces = regexp.MustCompile(`[ \t]{2,}`)
+
+// docText returns a doc comment as a description, see sanitizeDescription
+func docText(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	return sanitizeDescription(doc.Text())
+}
+
+// sanitizeDescription cleans comment text for use as a description: it
+// strips the comment markers left in the text, like the stars of
+// /** ... */ blocks and doubled slashes, drops control characters and
+// invalid UTF-8, which YAML can't carry as they are, trims trailing
+// whitespace, collapses runs of spaces and blank lines, and trims the
+// result. Indented lines, code blocks in Go doc comments, keep their
+// spacing.
+func sanitizeDescription(text string) string {
+	text = strings.ToValidUTF8(text, "�")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	lines := strings.Split(strings.ReplaceAll(text, "\r", "\n"), "\n")
+
+	starred := false
+	for _, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		if !strings.HasPrefix(trimmed, "*") {
+			starred = false
+			break
+		}
+		starred = true
+	}
+
+	var cleaned []string
+	for _, line := range lines {
+		if starred {
+			line = strings.TrimPrefix(strings.TrimPrefix(strings.TrimLeft(line, " \t"), "*"), " ")
+		}
+		if trimmed := strings.TrimLeft(line, " \t"); strings.HasPrefix(trimmed, "//") {
+			line = strings.TrimPrefix(strings.TrimLeft(trimmed, "/"), " ")
+		}
+		line = strings.Map(func(r rune) rune {
+			if unicode.IsControl(r) && r != '\t' {
+				return -1
+			}
+			return r
+		}, line)
+		line = strings.TrimRight(line, " \t")
+		if line != "" && line[0] != ' ' && line[0] != '\t' {
+			line = innerSpaces.ReplaceAllString(line, " ")
+		}
+		if line == "" && (len(cleaned) == 0 || cleaned[len(cleaned)-1] == "") {
+			continue
+		}
+		cleaned = append(cleaned, line)
+	}
+	return strings.TrimSpace(strings.Join(cleaned, "\n"))
+}
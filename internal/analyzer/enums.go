@@ -0,0 +1,127 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strconv"
+	"strings"
+)
+
+// recordEnumConstants records the values of the typed constants of a const
+// declaration by type name, so a field of a named type like Status is
+// documented with the values of its constants:
+//
+//	const (
+//		StatusActive   Status = "active"
+//		StatusInactive Status = "inactive"
+//	)
+//
+// Constants without a type or value repeat the previous ones, as in Go, so
+// iota blocks are evaluated too. Constants whose value isn't a literal, iota
+// or arithmetic of those are skipped.
+func recordEnumConstants(decl *ast.GenDecl, enums map[string][]string) {
+	if decl.Tok != token.CONST {
+		return
+	}
+	var typeName string
+	var values []ast.Expr
+	for iota, spec := range decl.Specs {
+		valueSpec, ok := spec.(*ast.ValueSpec)
+		if !ok {
+			continue
+		}
+		if valueSpec.Type != nil || len(valueSpec.Values) > 0 {
+			typeName, values = "", valueSpec.Values
+			if ident, ok := valueSpec.Type.(*ast.Ident); ok {
+				typeName = ident.Name
+			}
+		}
+		if typeName == "" {
+			continue
+		}
+		for i, name := range valueSpec.Names {
+			if name.Name == "_" || i >= len(values) {
+				continue
+			}
+			if value, ok := enumConstValue(values[i], iota); ok && !containsString(enums[typeName], value) {
+				enums[typeName] = append(enums[typeName], value)
+			}
+		}
+	}
+}
+
+// enumConstValue evaluates a constant's value: a string or integer literal,
+// iota, a conversion like Status("active"), or integer arithmetic of those
+// like 1 << iota. Integers are returned in decimal.
+func enumConstValue(expr ast.Expr, iota int) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.STRING:
+			value, err := strconv.Unquote(e.Value)
+			return value, err == nil
+		case token.INT:
+			value, err := strconv.ParseInt(e.Value, 0, 64)
+			return strconv.FormatInt(value, 10), err == nil
+		}
+	case *ast.Ident:
+		if e.Name == "iota" {
+			return strconv.Itoa(iota), true
+		}
+	case *ast.ParenExpr:
+		return enumConstValue(e.X, iota)
+	case *ast.CallExpr:
+		if len(e.Args) == 1 {
+			return enumConstValue(e.Args[0], iota)
+		}
+	case *ast.UnaryExpr:
+		if value, ok := enumConstInt(e.X, iota); ok && e.Op == token.SUB {
+			return strconv.FormatInt(-value, 10), true
+		}
+	case *ast.BinaryExpr:
+		x, xOK := enumConstInt(e.X, iota)
+		y, yOK := enumConstInt(e.Y, iota)
+		if !xOK || !yOK {
+			break
+		}
+		switch e.Op {
+		case token.ADD:
+			return strconv.FormatInt(x+y, 10), true
+		case token.SUB:
+			return strconv.FormatInt(x-y, 10), true
+		case token.MUL:
+			return strconv.FormatInt(x*y, 10), true
+		case token.SHL:
+			return strconv.FormatInt(x<<y, 10), true
+		}
+	}
+	return "", false
+}
+
+func enumConstInt(expr ast.Expr, iota int) (int64, bool) {
+	value, ok := enumConstValue(expr, iota)
+	if !ok {
+		return 0, false
+	}
+	number, err := strconv.ParseInt(value, 10, 64)
+	return number, err == nil
+}
+
+// applyEnums sets the enum of the model fields, and of the elements of
+// slice fields, whose type is a named type with constants. Enums already
+// set, like those of proto enums, are kept.
+func (a *Analyzer) applyEnums(analysis *Analysis) {
+	for name, model := range analysis.Models {
+		for i, field := range model.Fields {
+			typeName := strings.TrimLeft(field.Type, "*[]")
+			if _, named := analysis.NamedTypes[typeName]; !named || len(field.Enum) > 0 {
+				continue
+			}
+			if values := analysis.Enums[typeName]; len(values) > 0 {
+				model.Fields[i].Enum = values
+				model.Fields[i].EnumName = typeName
+			}
+		}
+		analysis.Models[name] = model
+	}
+}
@@ -0,0 +1,142 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// examplePrefixes mark constants holding realistic sample values, like
+// DefaultTenantID in a config package or TestUserID in a test
+var examplePrefixes = []string{"Default", "Example", "Sample", "Test", "Fake", "Mock", "Demo"}
+
+// exampleSkippedDirs never hold the project's own constants
+var exampleSkippedDirs = map[string]bool{
+	"vendor":       true,
+	"node_modules": true,
+	"testdata":     true,
+}
+
+// exampleKey normalizes a parameter or constant name so tenant_id, tenantId
+// and TenantID compare equal
+func exampleKey(name string) string {
+	name = strings.ToLower(name)
+	name = strings.ReplaceAll(name, "_", "")
+	return strings.ReplaceAll(name, "-", "")
+}
+
+// collectExampleConstants scans every Go file of the project, tests
+// included, for string and number constants named with an example prefix.
+// The first constant found for a name wins.
+func (a *Analyzer) collectExampleConstants() map[string]string {
+	examples := make(map[string]string)
+
+	filepath.Walk(a.projectPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if path != a.projectPath && (exampleSkippedDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		src, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return nil
+		}
+		for _, decl := range src.Decls {
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok || genDecl.Tok != token.CONST {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				valueSpec := spec.(*ast.ValueSpec)
+				for i, name := range valueSpec.Names {
+					if i >= len(valueSpec.Values) {
+						break
+					}
+					key, ok := exampleConstantKey(name.Name)
+					if !ok {
+						continue
+					}
+					value, ok := exampleValue(valueSpec.Values[i])
+					if !ok {
+						continue
+					}
+					if _, exists := examples[key]; !exists {
+						examples[key] = value
+					}
+				}
+			}
+		}
+		return nil
+	})
+
+	return examples
+}
+
+// exampleConstantKey strips the example prefix from a constant name,
+// returning the key of the parameter it describes
+func exampleConstantKey(name string) (string, bool) {
+	for _, prefix := range examplePrefixes {
+		rest := strings.TrimPrefix(name, prefix)
+		// The prefix must be a word of its own: TestUserID, not Testimonial
+		if rest != name && rest != "" && (rest[0] >= 'A' && rest[0] <= 'Z' || rest[0] == '_') {
+			return exampleKey(rest), true
+		}
+	}
+	return "", false
+}
+
+// exampleValue returns the value of a string or number literal
+func exampleValue(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok {
+		return "", false
+	}
+	switch lit.Kind {
+	case token.STRING:
+		value, err := strconv.Unquote(lit.Value)
+		return value, err == nil && value != ""
+	case token.INT, token.FLOAT:
+		return lit.Value, true
+	}
+	return "", false
+}
+
+// applyParameterExamples sets path and query parameter examples from the
+// project's example constants
+func (a *Analyzer) applyParameterExamples(analysis *Analysis) {
+	examples := a.collectExampleConstants()
+	if len(examples) == 0 {
+		return
+	}
+
+	applied := 0
+	for i := range analysis.Routes {
+		params := analysis.Routes[i].Parameters
+		for j := range params {
+			if params[j].Example != "" {
+				continue
+			}
+			if example, ok := examples[exampleKey(params[j].Name)]; ok {
+				params[j].Example = example
+				applied++
+			}
+		}
+	}
+
+	if applied > 0 {
+		fmt.Printf("[DEBUG] Applied %d parameter examples from %d example constants\n", applied, len(examples))
+	}
+}
@@ -1,8 +1,10 @@
 package analyzer
 
 import (
+	"fmt"
 	"go/ast"
 	"go/token"
+	"reflect"
 	"regexp"
 	"strings"
 )
@@ -48,16 +50,30 @@ func (a *Analyzer) extractResponseType(expr ast.Expr) string {
 
 func (a *Analyzer) extractPathParameters(path string) []Parameter {
 	var params []Parameter
-	re := regexp.MustCompile(`:([^/]+)`)
+	// Fiber/Gin/Echo use :id, chi uses {id} or {id:regex}, httprouter
+	// catch-all parameters are *name. Fiber's optional :id? parameters are
+	// not required until expandPathPatterns splits their route.
+	re := regexp.MustCompile(`:([^/]+)|\{([^}/:]+)(?::[^}]*)?\}|\*([a-zA-Z_][a-zA-Z0-9_]*)`)
 	matches := re.FindAllStringSubmatch(path, -1)
 
 	for _, match := range matches {
-		if len(match) > 1 {
+		name := match[1]
+		required := !strings.HasSuffix(name, "?")
+		name = strings.TrimSuffix(name, "?")
+		if name == "" {
+			name = match[2]
+		}
+		wildcard := false
+		if name == "" {
+			name, wildcard = match[3], true
+		}
+		if name != "" {
 			params = append(params, Parameter{
-				Name:     match[1],
+				Name:     name,
 				In:       "path",
-				Required: true,
+				Required: required,
 				Type:     "string",
+				Wildcard: wildcard,
 			})
 		}
 	}
@@ -74,7 +90,17 @@ func (a *Analyzer) extractJSONTag(tag string) string {
 	return ""
 }
 
+// extractStructTag returns the value of a key of a raw struct tag, like
+// the rules of validate:"required,email"
+func (a *Analyzer) extractStructTag(tag, key string) string {
+	return reflect.StructTag(strings.Trim(tag, "`")).Get(key)
+}
+
 func (a *Analyzer) extractTypeFromExpr(expr ast.Expr) string {
+	// Generic types with type arguments: sdk.Page[sdk.User]
+	if name, ok := a.indexedTypeName(expr, a.extractTypeFromExpr); ok {
+		return name
+	}
 	switch e := expr.(type) {
 	case *ast.SelectorExpr:
 		// Handle package.Type expressions
@@ -109,15 +135,27 @@ func (a *Analyzer) extractTypeFromExpr(expr ast.Expr) string {
 }
 
 func (a *Analyzer) extractQueryParametersFromType(typeName string) []QueryParameter {
+	return a.queryParametersFromType(typeName, make(map[string]bool))
+}
+
+// queryParametersFromType maps the fields of a query struct to parameters,
+// including the fields of inlined structs. seen guards against embedding
+// cycles.
+func (a *Analyzer) queryParametersFromType(typeName string, seen map[string]bool) []QueryParameter {
 	var params []QueryParameter
 	
 	// Clean the type name
 	cleanType := a.cleanTypeName(typeName)
 	
 	// Look for the type in our models
-	if model, exists := a.models[cleanType]; exists {
+	if model, exists := a.models[cleanType]; exists && !seen[cleanType] {
+		seen[cleanType] = true
 		// Convert model fields to query parameters
 		for _, field := range model.Fields {
+			if field.Inline {
+				params = append(params, a.queryParametersFromType(field.Type, seen)...)
+				continue
+			}
 			paramName := field.Name
 			if field.JSONTag != "" && field.JSONTag != "-" {
 				// Use JSON tag name if available
@@ -133,10 +171,18 @@ func (a *Analyzer) extractQueryParametersFromType(typeName string) []QueryParame
 			param := QueryParameter{
 				Name:        paramName,
 				Type:        a.mapFieldTypeToParamType(field.Type),
-				Required:    false, // Query parameters are typically optional
+				Required:    hasValidateRule(field.Validate, "required"), // optional unless validated
 				Description: field.Description,
+				GoType:      strings.TrimPrefix(field.Type, "*"),
 			}
 			
+			// Named types with constants, like Status, are enums
+			typeName := strings.TrimLeft(field.Type, "*")
+			if _, named := a.namedTypes[typeName]; named && len(a.enums[typeName]) > 0 {
+				param.Enum = a.enums[typeName]
+				param.EnumName = typeName
+			}
+
 			// Add default values for common parameters
 			switch paramName {
 			case "skip", "offset":
@@ -149,7 +195,7 @@ func (a *Analyzer) extractQueryParametersFromType(typeName string) []QueryParame
 			
 			params = append(params, param)
 		}
-	} else {
+	} else if !exists {
 		// If model not found, try common patterns
 		switch cleanType {
 		case "ConversationFilter", "ConversationFilterRequest":
@@ -181,6 +227,13 @@ func (a *Analyzer) mapFieldTypeToParamType(fieldType string) string {
 		return "array"
 	}
 	
+	// Named types, like type UserID int64, take their underlying type
+	if underlying, named := a.namedTypes[a.cleanTypeName(cleanType)]; named {
+		if _, isModel := a.models[a.cleanTypeName(cleanType)]; !isModel {
+			return a.mapFieldTypeToParamType(underlying)
+		}
+	}
+
 	switch cleanType {
 	case "int", "int32", "int64", "uint", "uint32", "uint64":
 		return "integer"
@@ -313,4 +366,80 @@ func toSnakeCase(str string) string {
 		result.WriteRune(r)
 	}
 	return strings.ToLower(result.String())
-}
\ No newline at end of file
+}
+
+// extractOneOfAnnotation reads an //openapi:oneOf TypeA,TypeB annotation from
+// a field's doc or trailing comment and returns the listed type names
+func (a *Analyzer) extractOneOfAnnotation(field *ast.Field) []string {
+	var types []string
+	for _, group := range []*ast.CommentGroup{field.Doc, field.Comment} {
+		if group == nil {
+			continue
+		}
+		for _, comment := range group.List {
+			text := strings.TrimSpace(strings.TrimPrefix(comment.Text, "//"))
+			if !strings.HasPrefix(text, "openapi:oneOf") {
+				continue
+			}
+			for _, typeName := range strings.Split(strings.TrimPrefix(text, "openapi:oneOf"), ",") {
+				if typeName = a.cleanTypeName(strings.TrimSpace(typeName)); typeName != "" {
+					types = append(types, typeName)
+				}
+			}
+		}
+	}
+	return types
+}
+
+// withoutOneOf drops the openapi:oneOf annotation lines from a field's
+// description
+func withoutOneOf(description string) string {
+	var lines []string
+	for _, line := range strings.Split(description, "\n") {
+		if !strings.HasPrefix(strings.TrimSpace(line), "openapi:oneOf") {
+			lines = append(lines, line)
+		}
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// checkOneOf drops the oneOf annotations of fields that aren't
+// interface-typed: any, interface{} or an interface type of the packages
+// models are loaded from. A field of a concrete type has one schema.
+func (a *Analyzer) checkOneOf(analysis *Analysis) {
+	for name, model := range analysis.Models {
+		for i, field := range model.Fields {
+			if len(field.OneOf) == 0 {
+				continue
+			}
+			typeName := a.cleanTypeName(field.OriginalType)
+			if typeName == "any" || typeName == "interface{}" || a.interfaces[typeName] {
+				continue
+			}
+			fmt.Printf("Warning: ignoring the openapi:oneOf annotation of %s.%s: %s isn't an interface type\n", name, field.Name, field.OriginalType)
+			model.Fields[i].OneOf = nil
+		}
+	}
+}
+
+// extractAnnotations collects "// @key value" lines from a doc comment
+func (a *Analyzer) extractAnnotations(doc *ast.CommentGroup) map[string]string {
+	if doc == nil {
+		return make(map[string]string)
+	}
+	return parseAnnotations(doc.Text())
+}
+
+// parseAnnotations collects "@key value" lines from comment text
+func parseAnnotations(text string) map[string]string {
+	annotations := make(map[string]string)
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimSpace(line)
+		if !strings.HasPrefix(line, "@") {
+			continue
+		}
+		key, value, _ := strings.Cut(strings.TrimPrefix(line, "@"), " ")
+		annotations[strings.ToLower(key)] = strings.TrimSpace(value)
+	}
+	return annotations
+}
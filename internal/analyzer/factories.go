@@ -0,0 +1,60 @@
+package analyzer
+
+import "go/ast"
+
+// factoryHandler returns the handler built by a handler factory, like
+//
+//	func MakeListHandler(svc UserService) fiber.Handler {
+//		return func(c *fiber.Ctx) error { ... }
+//	}
+//
+// as a function declaration named after the factory, so it can be analyzed
+// like any other handler. The closure may be returned directly or through
+// a variable. It returns nil if funcDecl doesn't return a closure.
+func factoryHandler(funcDecl *ast.FuncDecl) *ast.FuncDecl {
+	if funcDecl.Body == nil || funcDecl.Type.Results == nil || len(funcDecl.Type.Results.List) != 1 {
+		return nil
+	}
+
+	closures := make(map[string]*ast.FuncLit)
+	var returned *ast.FuncLit
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.FuncLit:
+			// Returns inside closures belong to the closure
+			return false
+		case *ast.AssignStmt:
+			for i, lhs := range node.Lhs {
+				ident, ok := lhs.(*ast.Ident)
+				if !ok || i >= len(node.Rhs) {
+					continue
+				}
+				if funcLit, ok := node.Rhs[i].(*ast.FuncLit); ok {
+					closures[ident.Name] = funcLit
+				}
+			}
+		case *ast.ReturnStmt:
+			if len(node.Results) != 1 || returned != nil {
+				return true
+			}
+			switch result := node.Results[0].(type) {
+			case *ast.FuncLit:
+				returned = result
+			case *ast.Ident:
+				returned = closures[result.Name]
+			}
+		}
+		return true
+	})
+	if returned == nil {
+		return nil
+	}
+
+	return &ast.FuncDecl{
+		Doc:  funcDecl.Doc,
+		Recv: funcDecl.Recv,
+		Name: funcDecl.Name,
+		Type: returned.Type,
+		Body: returned.Body,
+	}
+}
@@ -0,0 +1,143 @@
+package analyzer
+
+import (
+	"go/ast"
+	"path"
+	"strings"
+)
+
+// defaultFileType is the media type of files whose type can't be inferred
+const defaultFileType = "application/octet-stream"
+
+// FileResponse describes the file a handler responds with
+type FileResponse struct {
+	MediaType  string // inferred from the file name, or given with c.Data and the like
+	Attachment bool   // sent for download, with a Content-Disposition header
+}
+
+// fileResponse returns the file a handler responds with:
+//
+//   - c.SendFile and c.Download with Fiber, c.File and c.FileAttachment with
+//     Gin and Hertz, c.File, c.Attachment and c.Inline with Echo, and
+//     ctx.ServeFile and ctx.SendFile with Iris, taking the file path first
+//   - http.ServeFile(w, r, name) with net/http routers
+//   - c.Data(code, contentType, data) with Gin and Hertz and c.Blob with Echo
+//
+// The media type comes from the extension of the file or download name when
+// it's a constant, or the last argument of filepath.Join.
+func (a *Analyzer) fileResponse(funcDecl *ast.FuncDecl) *FileResponse {
+	var file *FileResponse
+	ast.Inspect(funcDecl, func(n ast.Node) bool {
+		callExpr, ok := n.(*ast.CallExpr)
+		if !ok || file != nil {
+			return file == nil
+		}
+		selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		ident, ok := selExpr.X.(*ast.Ident)
+		if !ok {
+			return true
+		}
+
+		switch {
+		case ident.Name == "http" && selExpr.Sel.Name == "ServeFile" && len(callExpr.Args) == 3:
+			file = &FileResponse{MediaType: a.fileMediaType(callExpr.Args[2])}
+		case ident.Name != a.contextName:
+		case len(callExpr.Args) > 0 && a.framework.fileMethods[selExpr.Sel.Name] != "":
+			file = &FileResponse{MediaType: a.fileMediaType(callExpr.Args[0])}
+			if a.framework.fileMethods[selExpr.Sel.Name] == fileAttachment {
+				file.Attachment = true
+				// The download name, like c.Download(path, "report.pdf")
+				if len(callExpr.Args) > 1 {
+					if mediaType := a.fileMediaType(callExpr.Args[1]); mediaType != defaultFileType {
+						file.MediaType = mediaType
+					}
+				}
+			}
+		case containsString(a.framework.dataMethods, selExpr.Sel.Name) && len(callExpr.Args) == 3:
+			file = &FileResponse{MediaType: defaultFileType}
+			if contentType, ok := a.stringValue(callExpr.Args[1]); ok && contentType != "" {
+				file.MediaType = contentType
+			}
+		}
+		return file == nil
+	})
+	return file
+}
+
+// Ways fileMethods send files
+const (
+	fileInline     = "inline"
+	fileAttachment = "attachment"
+)
+
+// fileMediaType infers a media type from the extension of a file name
+func (a *Analyzer) fileMediaType(expr ast.Expr) string {
+	// filepath.Join(dir, "report.pdf")
+	if call, ok := expr.(*ast.CallExpr); ok && len(call.Args) > 0 {
+		if selExpr, ok := call.Fun.(*ast.SelectorExpr); ok && selExpr.Sel.Name == "Join" {
+			expr = call.Args[len(call.Args)-1]
+		}
+	}
+	name, ok := a.stringValue(expr)
+	if !ok {
+		return defaultFileType
+	}
+	if mediaType, known := fileMediaTypes[strings.ToLower(path.Ext(name))]; known {
+		return mediaType
+	}
+	return defaultFileType
+}
+
+// fileMediaTypes are the media types of file extensions. The table is fixed
+// rather than read from the host's mime.types, so a project documents the
+// same on every machine and its lock file checks out.
+var fileMediaTypes = map[string]string{
+	".avif":  "image/avif",
+	".bmp":   "image/bmp",
+	".gif":   "image/gif",
+	".ico":   "image/x-icon",
+	".jpeg":  "image/jpeg",
+	".jpg":   "image/jpeg",
+	".png":   "image/png",
+	".svg":   "image/svg+xml",
+	".tif":   "image/tiff",
+	".tiff":  "image/tiff",
+	".webp":  "image/webp",
+	".mp3":   "audio/mpeg",
+	".ogg":   "audio/ogg",
+	".wav":   "audio/wav",
+	".mp4":   "video/mp4",
+	".webm":  "video/webm",
+	".css":   "text/css",
+	".csv":   "text/csv",
+	".htm":   "text/html",
+	".html":  "text/html",
+	".ics":   "text/calendar",
+	".js":    "text/javascript",
+	".md":    "text/markdown",
+	".mjs":   "text/javascript",
+	".txt":   "text/plain",
+	".xml":   "text/xml",
+	".json":  "application/json",
+	".yaml":  "application/yaml",
+	".yml":   "application/yaml",
+	".pdf":   "application/pdf",
+	".rtf":   "application/rtf",
+	".wasm":  "application/wasm",
+	".zip":   "application/zip",
+	".gz":    "application/gzip",
+	".tar":   "application/x-tar",
+	".7z":    "application/x-7z-compressed",
+	".epub":  "application/epub+zip",
+	".doc":   "application/msword",
+	".xls":   "application/vnd.ms-excel",
+	".ppt":   "application/vnd.ms-powerpoint",
+	".docx":  "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+	".xlsx":  "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	".pptx":  "application/vnd.openxmlformats-officedocument.presentationml.presentation",
+	".woff":  "font/woff",
+	".woff2": "font/woff2",
+}
@@ -0,0 +1,138 @@
+package analyzer
+
+import "go/ast"
+
+// formFieldName returns the form field a call reads, with its default value:
+// c.FormValue("name") with Fiber, Echo and Iris, c.PostForm and
+// c.DefaultPostForm("name", "guest") with Gin and Hertz, and r.FormValue or
+// r.PostFormValue on the request with net/http routers
+func (a *Analyzer) formFieldName(callExpr *ast.CallExpr) (string, interface{}, bool) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || len(callExpr.Args) == 0 {
+		return "", nil, false
+	}
+	ident, ok := selExpr.X.(*ast.Ident)
+	if !ok || ident.Name != a.contextName || !containsString(a.framework.formMethods, selExpr.Sel.Name) {
+		return "", nil, false
+	}
+	name, ok := a.stringValue(callExpr.Args[0])
+	if !ok {
+		return "", nil, false
+	}
+	var defaultValue interface{}
+	if len(callExpr.Args) > 1 {
+		if value, ok := a.stringValue(callExpr.Args[1]); ok && value != "" {
+			defaultValue = value
+		}
+	}
+	return name, defaultValue, true
+}
+
+// extractFormFields records the form fields and files a handler reads,
+// required like headers when the handler returns without them, see
+// readParameters. Files, and a parsed multipart form, make the form
+// multipart.
+func (a *Analyzer) extractFormFields(funcDecl *ast.FuncDecl, handlerInfo *HandlerInfo) {
+	files := readParameters(funcDecl, "form", a.fileFieldName)
+	for i := range files {
+		files[i].Type = "file"
+	}
+	for _, field := range append(readParameters(funcDecl, "form", a.formFieldName), files...) {
+		if !hasFormField(handlerInfo.FormFields, field.Name) {
+			handlerInfo.FormFields = append(handlerInfo.FormFields, field)
+		}
+	}
+	multipart := a.extractMultipartFields(funcDecl, handlerInfo)
+	handlerInfo.Multipart = multipart || len(files) > 0
+}
+
+// fileFieldName returns the file field a call reads: c.FormFile("file")
+// with every framework but chi, mux and httprouter, whose handlers call
+// r.FormFile on the request
+func (a *Analyzer) fileFieldName(callExpr *ast.CallExpr) (string, interface{}, bool) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || len(callExpr.Args) != 1 || selExpr.Sel.Name != "FormFile" {
+		return "", nil, false
+	}
+	ident, ok := selExpr.X.(*ast.Ident)
+	if !ok || ident.Name != a.contextName {
+		return "", nil, false
+	}
+	name, ok := a.stringValue(callExpr.Args[0])
+	return name, nil, ok
+}
+
+// isMultipartFormCall checks if the call parses the multipart form:
+// c.MultipartForm() or r.ParseMultipartForm(maxMemory)
+func (a *Analyzer) isMultipartFormCall(callExpr *ast.CallExpr) bool {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || (selExpr.Sel.Name != "MultipartForm" && selExpr.Sel.Name != "ParseMultipartForm") {
+		return false
+	}
+	ident, ok := selExpr.X.(*ast.Ident)
+	return ok && ident.Name == a.contextName
+}
+
+// extractMultipartFields records the files and fields a handler reads from
+// the parsed multipart form, form.File["documents"] and form.Value["title"],
+// where form is assigned c.MultipartForm() or is r.MultipartForm. It reports
+// whether the handler parses the multipart form.
+func (a *Analyzer) extractMultipartFields(funcDecl *ast.FuncDecl, handlerInfo *HandlerInfo) bool {
+	multipart := false
+	forms := make(map[string]bool) // variables assigned the multipart form
+
+	isForm := func(expr ast.Expr) bool {
+		switch e := expr.(type) {
+		case *ast.Ident:
+			return forms[e.Name]
+		case *ast.SelectorExpr:
+			ident, ok := e.X.(*ast.Ident)
+			return ok && ident.Name == a.contextName && e.Sel.Name == "MultipartForm"
+		}
+		return false
+	}
+
+	ast.Inspect(funcDecl, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if call, ok := node.Rhs[0].(*ast.CallExpr); ok && len(node.Rhs) == 1 && a.isMultipartFormCall(call) {
+				if ident, ok := node.Lhs[0].(*ast.Ident); ok {
+					forms[ident.Name] = true
+				}
+			}
+		case *ast.CallExpr:
+			multipart = multipart || a.isMultipartFormCall(node)
+		case *ast.IndexExpr:
+			selExpr, ok := node.X.(*ast.SelectorExpr)
+			if !ok || !isForm(selExpr.X) {
+				break
+			}
+			name, ok := a.stringValue(node.Index)
+			if !ok {
+				break
+			}
+			field := Parameter{Name: name, In: "form", Type: "string"}
+			switch selExpr.Sel.Name {
+			case "File":
+				field.Type = "files"
+			case "Value":
+			default:
+				return true
+			}
+			if !hasFormField(handlerInfo.FormFields, name) {
+				handlerInfo.FormFields = append(handlerInfo.FormFields, field)
+			}
+		}
+		return true
+	})
+	return multipart
+}
+
+func hasFormField(fields []Parameter, name string) bool {
+	for _, field := range fields {
+		if field.Name == name {
+			return true
+		}
+	}
+	return false
+}
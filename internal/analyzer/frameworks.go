@@ -0,0 +1,230 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Supported router frameworks
+const (
+	FrameworkFiber       = "fiber"
+	FrameworkGin         = "gin"
+	FrameworkEcho        = "echo"
+	FrameworkChi         = "chi"
+	FrameworkMux         = "mux"
+	FrameworkHTTPRouter  = "httprouter"
+	FrameworkGRPCGateway = "grpc-gateway"
+	FrameworkHertz       = "hertz"
+	FrameworkIris        = "iris"
+	FrameworkNetHTTP     = "nethttp"
+)
+
+// fiberV3Import is the import path that marks a route file as Fiber v3
+const fiberV3Import = "github.com/gofiber/fiber/v3"
+
+// framework describes how a router framework exposes its handler context,
+// binding helpers and route registration
+type framework struct {
+	name           string
+	contextType    string // selector name of the handler context type, e.g. fiber.Ctx
+	contextPointer bool   // handlers take *Ctx rather than Ctx
+	contextValue   bool   // handlers may also take Ctx by value (Fiber v3's fiber.Ctx interface)
+	contextArg     int    // position of the context among the handler's parameters
+	netHTTP        bool   // handlers are func(w http.ResponseWriter, r *http.Request)
+	routerParams   bool   // net/http handlers take a third httprouter.Params argument
+	bodyParsers    []string
+	queryParsers   []string
+	queryMethods   []string          // c.Query("name"[, default])
+	typedQuery     map[string]string // typed query getters beyond QueryInt/QueryBool/QueryFloat, by parameter type
+	bindBody       []string          // c.Bind().Body(&req) (Fiber v3)
+	bindQuery      []string          // c.Bind().Query(&q) (Fiber v3)
+	genericQuery   string            // fiber.Query[int](c, "page") (Fiber v3)
+	pathMethods    []string          // c.Params("id"), besides chi.URLParam, mux.Vars and ps.ByName, see pathParamName
+	typedPath      map[string]string // typed path getters like c.ParamsInt("id"), by parameter type
+	genericPath    string            // fiber.Params[int](c, "id") (Fiber v3)
+	headerMethods  []string          // c.Get("X-Tenant-ID"), besides r.Header.Get, see headerName
+	cookieMethods  []string          // c.Cookies("session_id"[, default]), see cookieName
+	formMethods    []string          // c.FormValue("name"), see formFieldName
+	fileMethods    map[string]string // c.SendFile(path), inline or attachment, see fileResponse
+	dataMethods    []string          // c.Data(code, contentType, data)
+	jsonMethods    []string
+	jsonBodyArg    int      // position of the body in JSON calls, -1 for the last argument
+	handlerFirst   bool     // route handler precedes middleware: GET(path, h, m...)
+	methodsChain   bool     // routes declared as HandleFunc(path, h).Methods("GET")
+	methodCalls    []string // routes declared as Handle("GET", path, h)
+	allCalls       []string // routes declared for every method as All(path, h)
+	patternRoutes  bool     // routes declared as HandleFunc("GET /items/{id}", h) (Go 1.22 ServeMux)
+	proto          bool     // routes come from google.api.http bindings in .proto files
+}
+
+var frameworks = map[string]framework{
+	FrameworkFiber: {
+		name:           FrameworkFiber,
+		contextType:    "Ctx",
+		contextPointer: true,
+		contextValue:   true,
+		bodyParsers:    []string{"BodyParser"},
+		queryParsers:   []string{"QueryParser"},
+		queryMethods:   []string{"Query"},
+		bindBody:       []string{"Body", "JSON", "XML", "Form", "MultipartForm"},
+		bindQuery:      []string{"Query"},
+		genericQuery:   "Query",
+		pathMethods:    []string{"Params"},
+		typedPath:      map[string]string{"ParamsInt": "integer"},
+		genericPath:    "Params",
+		headerMethods:  []string{"Get"},
+		cookieMethods:  []string{"Cookies"},
+		formMethods:    []string{"FormValue"},
+		fileMethods:    map[string]string{"SendFile": fileInline, "Download": fileAttachment},
+		jsonMethods:    []string{"JSON"},
+		methodCalls:    []string{"Add"},
+		allCalls:       []string{"All"},
+	},
+	FrameworkGin: {
+		name:           FrameworkGin,
+		contextType:    "Context",
+		contextPointer: true,
+		bodyParsers:    []string{"ShouldBindJSON", "BindJSON", "ShouldBind", "Bind"},
+		queryParsers:   []string{"ShouldBindQuery", "BindQuery"},
+		queryMethods:   []string{"Query", "DefaultQuery"},
+		pathMethods:    []string{"Param"},
+		headerMethods:  []string{"GetHeader"},
+		cookieMethods:  []string{"Cookie"},
+		formMethods:    []string{"PostForm", "DefaultPostForm"},
+		fileMethods:    map[string]string{"File": fileInline, "FileAttachment": fileAttachment},
+		dataMethods:    []string{"Data"},
+		jsonMethods:    []string{"JSON", "IndentedJSON", "PureJSON"},
+		jsonBodyArg:    1,
+	},
+	FrameworkEcho: {
+		name:          FrameworkEcho,
+		contextType:   "Context",
+		bodyParsers:   []string{"Bind"},
+		queryMethods:  []string{"QueryParam"},
+		pathMethods:   []string{"Param"},
+		cookieMethods: []string{"Cookie"},
+		formMethods:   []string{"FormValue"},
+		fileMethods:   map[string]string{"File": fileInline, "Inline": fileInline, "Attachment": fileAttachment},
+		dataMethods:   []string{"Blob"},
+		jsonMethods:   []string{"JSON", "JSONPretty"},
+		jsonBodyArg:   1,
+		handlerFirst:  true,
+	},
+	FrameworkChi: {
+		name:          FrameworkChi,
+		contextType:   "Request",
+		netHTTP:       true,
+		cookieMethods: []string{"Cookie"},
+		formMethods:   []string{"FormValue", "PostFormValue"},
+		// json.NewDecoder(r.Body).Decode(&req), render.DecodeJSON(r.Body, &req), render.Bind(r, &req)
+		bodyParsers: []string{"Decode", "DecodeJSON", "Bind"},
+		// json.NewEncoder(w).Encode(resp), render.JSON(w, r, resp)
+		jsonMethods: []string{"Encode", "JSON", "Respond"},
+		jsonBodyArg: -1,
+	},
+	FrameworkMux: {
+		name:          FrameworkMux,
+		contextType:   "Request",
+		netHTTP:       true,
+		bodyParsers:   []string{"Decode"},
+		cookieMethods: []string{"Cookie"},
+		formMethods:   []string{"FormValue", "PostFormValue"},
+		jsonMethods:   []string{"Encode"},
+		jsonBodyArg:   -1,
+		methodsChain:  true,
+	},
+	FrameworkHTTPRouter: {
+		name:          FrameworkHTTPRouter,
+		contextType:   "Request",
+		netHTTP:       true,
+		routerParams:  true,
+		bodyParsers:   []string{"Decode"},
+		cookieMethods: []string{"Cookie"},
+		formMethods:   []string{"FormValue", "PostFormValue"},
+		jsonMethods:   []string{"Encode"},
+		jsonBodyArg:   -1,
+		methodCalls:   []string{"Handle", "HandlerFunc", "Handler"},
+	},
+	FrameworkHertz: {
+		name:           FrameworkHertz,
+		contextType:    "RequestContext",
+		contextPointer: true,
+		// func(ctx context.Context, c *app.RequestContext)
+		contextArg:    1,
+		bodyParsers:   []string{"BindAndValidate", "Bind", "BindJSON"},
+		queryParsers:  []string{"BindQuery"},
+		queryMethods:  []string{"Query", "DefaultQuery", "GetQuery"},
+		pathMethods:   []string{"Param"},
+		headerMethods: []string{"GetHeader"},
+		cookieMethods: []string{"Cookie"},
+		formMethods:   []string{"PostForm", "DefaultPostForm"},
+		fileMethods:   map[string]string{"File": fileInline, "FileAttachment": fileAttachment},
+		dataMethods:   []string{"Data"},
+		jsonMethods:   []string{"JSON", "PureJSON", "IndentedJSON"},
+		jsonBodyArg:   1,
+		methodCalls:   []string{"Handle"},
+	},
+	FrameworkIris: {
+		name:          FrameworkIris,
+		contextType:   "Context", // iris.Context is an alias of *context.Context
+		bodyParsers:   []string{"ReadJSON", "ReadBody", "ReadForm"},
+		queryParsers:  []string{"ReadQuery"},
+		queryMethods:  []string{"URLParam", "URLParamDefault", "URLParamTrim"},
+		headerMethods: []string{"GetHeader"},
+		cookieMethods: []string{"GetCookie"},
+		formMethods:   []string{"FormValue", "PostValue"},
+		fileMethods:   map[string]string{"ServeFile": fileInline, "SendFile": fileAttachment},
+		typedQuery: map[string]string{
+			"URLParamInt":            "integer",
+			"URLParamIntDefault":     "integer",
+			"URLParamInt64":          "integer",
+			"URLParamInt64Default":   "integer",
+			"URLParamBool":           "boolean",
+			"URLParamFloat64":        "number",
+			"URLParamFloat64Default": "number",
+		},
+		jsonMethods: []string{"JSON"},
+	},
+	FrameworkNetHTTP: {
+		name:          FrameworkNetHTTP,
+		contextType:   "Request",
+		netHTTP:       true,
+		bodyParsers:   []string{"Decode"},
+		cookieMethods: []string{"Cookie"},
+		formMethods:   []string{"FormValue", "PostFormValue"},
+		jsonMethods:   []string{"Encode"},
+		jsonBodyArg:   -1,
+		patternRoutes: true,
+	},
+	FrameworkGRPCGateway: {
+		name:  FrameworkGRPCGateway,
+		proto: true,
+	},
+}
+
+func lookupFramework(name string) (framework, error) {
+	fw, exists := frameworks[name]
+	if !exists {
+		supported := "auto, fiber, gin, echo, chi, mux, httprouter, hertz, iris, nethttp, grpc-gateway"
+		if len(adapters) > 0 {
+			var names []string
+			for adapterName := range adapters {
+				names = append(names, adapterName)
+			}
+			sort.Strings(names)
+			supported += "; adapters: " + strings.Join(names, ", ")
+		}
+		return framework{}, fmt.Errorf("unsupported framework: %s (supported: %s)", name, supported)
+	}
+	return fw, nil
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,163 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// genericType is a generic struct declaration, like Page[T any], which has
+// no schema of its own: each use with type arguments is instantiated as a
+// model, see instantiateGenerics
+type genericType struct {
+	spec      *ast.TypeSpec
+	doc       *ast.CommentGroup
+	goPackage string
+}
+
+// genericInstance is a use of a generic type with type arguments, like
+// Page[User], whose model is named PageUser
+type genericInstance struct {
+	generic string
+	args    []string
+}
+
+// registerGeneric records a generic struct declaration and reports whether
+// spec was one
+func (a *Analyzer) registerGeneric(spec *ast.TypeSpec, doc *ast.CommentGroup, goPackage string) bool {
+	if spec.TypeParams == nil || len(spec.TypeParams.List) == 0 {
+		return false
+	}
+	if _, isStruct := spec.Type.(*ast.StructType); !isStruct {
+		return false
+	}
+	if _, exists := a.generics[spec.Name.Name]; !exists {
+		a.generics[spec.Name.Name] = genericType{spec: spec, doc: doc, goPackage: goPackage}
+		fmt.Printf("[DEBUG] Found generic model %s\n", spec.Name.Name)
+	}
+	return true
+}
+
+// indexedTypeName returns the model name of a generic type with type
+// arguments, Page[User] or Pair[K, V], typing the arguments with
+// typeString. It reports false for other expressions. The generic may be
+// declared in a file not parsed yet; instances of generics that turn out not
+// to be models are dropped by instantiateGenerics.
+func (a *Analyzer) indexedTypeName(expr ast.Expr, typeString func(ast.Expr) string) (string, bool) {
+	var base ast.Expr
+	var indices []ast.Expr
+	switch e := expr.(type) {
+	case *ast.IndexExpr:
+		base, indices = e.X, []ast.Expr{e.Index}
+	case *ast.IndexListExpr:
+		base, indices = e.X, e.Indices
+	default:
+		return "", false
+	}
+	name := a.cleanTypeName(typeString(base))
+	args := make([]string, len(indices))
+	for i, index := range indices {
+		args[i] = typeString(index)
+	}
+	return a.genericInstanceName(name, args), true
+}
+
+// genericInstanceName names the model of a generic type instantiated with
+// type arguments after the generic and the arguments, PageUser for
+// Page[User] and ResponseUserList for Response[[]User], and records the
+// instance for instantiateGenerics
+func (a *Analyzer) genericInstanceName(generic string, args []string) string {
+	name := generic
+	for _, arg := range args {
+		name += typeArgName(arg)
+	}
+	if _, exists := a.instances[name]; !exists {
+		a.instances[name] = genericInstance{generic: generic, args: args}
+	}
+	return name
+}
+
+// typeArgName is the part a type argument adds to an instance's name
+func typeArgName(arg string) string {
+	arg = strings.TrimPrefix(arg, "*")
+	switch {
+	case strings.HasPrefix(arg, "[]"):
+		return typeArgName(arg[2:]) + "List"
+	case strings.HasPrefix(arg, "map["):
+		if end := strings.Index(arg, "]"); end != -1 {
+			return "MapOf" + typeArgName(arg[end+1:])
+		}
+	case arg == "interface{}" || arg == "any":
+		return "Any"
+	}
+	if idx := strings.LastIndex(arg, "."); idx != -1 {
+		arg = arg[idx+1:]
+	}
+	var name strings.Builder
+	for i, r := range arg {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		if i == 0 {
+			r = unicode.ToUpper(r)
+		}
+		name.WriteRune(r)
+	}
+	return name.String()
+}
+
+// instantiateGenerics adds a model for each generic instance used so far
+// that has none yet, with the type parameters in its fields replaced by the
+// instance's type arguments. Fields may use further instances, which are
+// added in turn.
+func (a *Analyzer) instantiateGenerics(analysis *Analysis) {
+	for {
+		var pending []string
+		for name := range a.instances {
+			if _, exists := analysis.Models[name]; !exists {
+				pending = append(pending, name)
+			}
+		}
+		if len(pending) == 0 {
+			return
+		}
+		sort.Strings(pending)
+
+		for _, name := range pending {
+			instance := a.instances[name]
+			generic, exists := a.generics[instance.generic]
+			if !exists {
+				fmt.Printf("[DEBUG] %s is not a generic model; %s is not documented\n", instance.generic, name)
+				delete(a.instances, name)
+				continue
+			}
+			var params []string
+			for _, field := range generic.spec.TypeParams.List {
+				for _, param := range field.Names {
+					params = append(params, param.Name)
+				}
+			}
+			if len(params) != len(instance.args) {
+				fmt.Printf("Warning: %s takes %d type arguments but %s has %d; missing ones are documented as any value\n", instance.generic, len(params), name, len(instance.args))
+			}
+
+			a.typeArgs = make(map[string]string)
+			for i, param := range params {
+				arg := "interface{}"
+				if i < len(instance.args) {
+					arg = instance.args[i]
+				}
+				a.typeArgs[param] = arg
+			}
+			model := a.parseStruct(name, generic.spec.Type.(*ast.StructType), generic.doc)
+			a.typeArgs = nil
+
+			model.GoPackage = generic.goPackage
+			model.GoName = instance.generic + "[" + strings.Join(instance.args, ", ") + "]"
+			analysis.Models[name] = model
+			fmt.Printf("[DEBUG] Instantiated %s as %s\n", model.GoName, name)
+		}
+	}
+}
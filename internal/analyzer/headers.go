@@ -0,0 +1,222 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// ignoredHeaders are the headers OpenAPI doesn't allow as header
+// parameters; content negotiation and security schemes document them
+var ignoredHeaders = map[string]bool{"accept": true, "content-type": true, "authorization": true}
+
+// headerName returns the header a call reads: c.Get("X-Tenant-ID") with
+// Fiber, c.GetHeader with Gin, Hertz and Iris, and Header.Get on the
+// request, like r.Header.Get with net/http routers or c.Request().Header.Get
+// with Echo
+func (a *Analyzer) headerName(callExpr *ast.CallExpr) (string, bool) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || len(callExpr.Args) != 1 {
+		return "", false
+	}
+	if !a.isContextHeaderCall(selExpr) && !a.isRequestHeaderCall(selExpr) {
+		return "", false
+	}
+	return a.stringValue(callExpr.Args[0])
+}
+
+func (a *Analyzer) isContextHeaderCall(selExpr *ast.SelectorExpr) bool {
+	ident, ok := selExpr.X.(*ast.Ident)
+	return ok && ident.Name == a.contextName && containsString(a.framework.headerMethods, selExpr.Sel.Name)
+}
+
+func (a *Analyzer) isRequestHeaderCall(selExpr *ast.SelectorExpr) bool {
+	header, ok := selExpr.X.(*ast.SelectorExpr)
+	if selExpr.Sel.Name != "Get" || !ok || header.Sel.Name != "Header" {
+		return false
+	}
+	request := header.X
+	if call, ok := request.(*ast.CallExpr); ok && len(call.Args) == 0 {
+		request = call.Fun
+	}
+	if sel, ok := request.(*ast.SelectorExpr); ok && sel.Sel.Name == "Request" {
+		request = sel.X
+	}
+	ident, ok := request.(*ast.Ident)
+	return ok && ident.Name == a.contextName
+}
+
+// extractHeaderParameters records the request headers a handler reads. A
+// header is required when the handler returns after checking it's empty:
+//
+//	tenantID := c.Get("X-Tenant-ID")
+//	if tenantID == "" {
+//		return fiber.NewError(fiber.StatusBadRequest, "missing tenant")
+//	}
+func (a *Analyzer) extractHeaderParameters(funcDecl *ast.FuncDecl, handlerInfo *HandlerInfo) {
+	headerName := func(call *ast.CallExpr) (string, interface{}, bool) {
+		name, ok := a.headerName(call)
+		return name, nil, ok && !ignoredHeaders[strings.ToLower(name)]
+	}
+	for _, param := range readParameters(funcDecl, "header", headerName) {
+		exists := false
+		for _, existing := range handlerInfo.HeaderParameters {
+			exists = exists || strings.EqualFold(existing.Name, param.Name)
+		}
+		if !exists {
+			handlerInfo.HeaderParameters = append(handlerInfo.HeaderParameters, param)
+		}
+	}
+}
+
+// readParameters returns the parameters a handler reads with the calls
+// nameOf recognizes, in the order of their first read, with their default
+// values. A parameter is required when the handler returns after checking
+// it's empty, or after checking the error of reading it, the last value of
+// the read:
+//
+//	cookie, err := r.Cookie("session_id")
+//	if err != nil {
+//		return
+//	}
+func readParameters(funcDecl *ast.FuncDecl, in string, nameOf func(*ast.CallExpr) (string, interface{}, bool)) []Parameter {
+	var params []Parameter
+	required := make(map[string]bool)
+	variables := make(map[string]string)    // variables assigned a parameter, to the parameter
+	errVariables := make(map[string]string) // error variables of the reads, to the parameter
+
+	record := func(assign *ast.AssignStmt) {
+		if len(assign.Lhs) > 3 || len(assign.Rhs) != 1 {
+			return
+		}
+		call, isCall := assign.Rhs[0].(*ast.CallExpr)
+		if !isCall {
+			return
+		}
+		name, _, ok := nameOf(call)
+		if !ok {
+			return
+		}
+		if ident, isIdent := assign.Lhs[0].(*ast.Ident); isIdent {
+			variables[ident.Name] = name
+		}
+		if len(assign.Lhs) > 1 {
+			if ident, isIdent := assign.Lhs[len(assign.Lhs)-1].(*ast.Ident); isIdent && ident.Name != "_" {
+				errVariables[ident.Name] = name
+			}
+		}
+	}
+
+	ast.Inspect(funcDecl, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			record(node)
+		case *ast.CallExpr:
+			name, defaultValue, ok := nameOf(node)
+			if !ok {
+				break
+			}
+			exists := false
+			for i, param := range params {
+				if strings.EqualFold(param.Name, name) {
+					exists = true
+					if param.Default == nil {
+						params[i].Default = defaultValue
+					}
+				}
+			}
+			if !exists {
+				params = append(params, Parameter{Name: name, In: in, Type: "string", Default: defaultValue})
+			}
+		case *ast.IfStmt:
+			// if key := c.Get("X-API-Key"); key == "" {
+			if init, ok := node.Init.(*ast.AssignStmt); ok {
+				record(init)
+			}
+			if !returns(node.Body) {
+				break
+			}
+			for _, checked := range emptyChecks(node.Cond) {
+				if ident, ok := checked.(*ast.Ident); ok && variables[ident.Name] != "" {
+					required[strings.ToLower(variables[ident.Name])] = true
+				} else if call, ok := checked.(*ast.CallExpr); ok {
+					if name, _, ok := nameOf(call); ok {
+						required[strings.ToLower(name)] = true
+					}
+				}
+			}
+			if name := errVariables[errorCheck(node.Cond)]; name != "" {
+				required[strings.ToLower(name)] = true
+			}
+		}
+		return true
+	})
+
+	for i, param := range params {
+		// A default makes the parameter optional whatever the checks
+		params[i].Required = required[strings.ToLower(param.Name)] && param.Default == nil
+	}
+	return params
+}
+
+// emptyChecks returns the expressions a condition checks for emptiness,
+// like x in x == "" || len(x) == 0
+func emptyChecks(cond ast.Expr) []ast.Expr {
+	binary, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok {
+		return nil
+	}
+	switch binary.Op {
+	case token.LOR:
+		return append(emptyChecks(binary.X), emptyChecks(binary.Y)...)
+	case token.EQL:
+		for _, operands := range [][2]ast.Expr{{binary.X, binary.Y}, {binary.Y, binary.X}} {
+			if lit, ok := operands[1].(*ast.BasicLit); ok && lit.Kind == token.STRING && (lit.Value == `""` || lit.Value == "``") {
+				return []ast.Expr{operands[0]}
+			}
+			if lit, ok := operands[1].(*ast.BasicLit); ok && lit.Value == "0" {
+				if call, ok := operands[0].(*ast.CallExpr); ok && len(call.Args) == 1 {
+					if fn, ok := call.Fun.(*ast.Ident); ok && fn.Name == "len" {
+						return []ast.Expr{call.Args[0]}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// errorCheck returns the variable a condition like err != nil checks
+func errorCheck(cond ast.Expr) string {
+	binary, ok := ast.Unparen(cond).(*ast.BinaryExpr)
+	if !ok || binary.Op != token.NEQ {
+		return ""
+	}
+	for _, operands := range [][2]ast.Expr{{binary.X, binary.Y}, {binary.Y, binary.X}} {
+		if ident, ok := operands[1].(*ast.Ident); ok && ident.Name == "nil" {
+			if checked, ok := operands[0].(*ast.Ident); ok {
+				return checked.Name
+			}
+		}
+	}
+	return ""
+}
+
+// returns reports whether a block ends the handler
+func returns(block *ast.BlockStmt) bool {
+	for _, stmt := range block.List {
+		if _, ok := stmt.(*ast.ReturnStmt); ok {
+			return true
+		}
+	}
+	return false
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
@@ -33,6 +33,9 @@ func (a *Analyzer) handleQueryParserCall(node *ast.CallExpr, variableTypes, quer
 		if varType != "" {
 			// Extract query parameters from the struct type
 			queryParams := a.extractQueryParametersFromType(varType)
+			for i := range queryParams {
+				queryParams[i].Source = querySourceStruct
+			}
 			handlerInfo.QueryParameters = append(handlerInfo.QueryParameters, queryParams...)
 		}
 	}
@@ -51,6 +54,7 @@ func (a *Analyzer) handleQueryCall(node *ast.CallExpr, funcDecl *ast.FuncDecl,
 		if queryParam.Name == "sort_order" && len(queryParam.Enum) == 0 {
 			queryParam.Enum = []string{"asc", "desc"}
 		}
+		queryParam.Source = querySourceCall
 		handlerInfo.QueryParameters = append(handlerInfo.QueryParameters, *queryParam)
 	}
 }
@@ -58,39 +62,118 @@ func (a *Analyzer) handleTypedQueryCalls(node *ast.CallExpr, handlerInfo *Handle
 	if a.isQueryIntCall(node) {
 		if queryParam := a.extractQueryParameter(node); queryParam != nil {
 			queryParam.Type = "integer"
+			queryParam.Source = querySourceGetter
 			handlerInfo.QueryParameters = append(handlerInfo.QueryParameters, *queryParam)
 		}
 	}
 	if a.isQueryBoolCall(node) {
 		if queryParam := a.extractQueryParameter(node); queryParam != nil {
 			queryParam.Type = "boolean"
+			queryParam.Source = querySourceGetter
 			handlerInfo.QueryParameters = append(handlerInfo.QueryParameters, *queryParam)
 		}
 	}
 	if a.isQueryFloatCall(node) {
 		if queryParam := a.extractQueryParameter(node); queryParam != nil {
 			queryParam.Type = "number"
+			queryParam.Source = querySourceGetter
+			handlerInfo.QueryParameters = append(handlerInfo.QueryParameters, *queryParam)
+		}
+	}
+	if paramType, ok := a.typedQueryType(node); ok {
+		if queryParam := a.extractQueryParameter(node); queryParam != nil {
+			queryParam.Type = paramType
+			queryParam.Source = querySourceGetter
+			handlerInfo.QueryParameters = append(handlerInfo.QueryParameters, *queryParam)
+		}
+	}
+	if typeArg, ok := a.genericQueryType(node); ok {
+		// fiber.Query[int](c, "page", 1): the context comes first
+		args := &ast.CallExpr{Fun: node.Fun, Args: node.Args[1:]}
+		if queryParam := a.extractQueryParameter(args); queryParam != nil {
+			queryParam.Type = a.mapFieldTypeToParamType(a.getTypeStringWithArrays(typeArg))
+			queryParam.Source = querySourceGetter
 			handlerInfo.QueryParameters = append(handlerInfo.QueryParameters, *queryParam)
 		}
 	}
 }
 
-// isQueryCall checks if the call is c.Query()
+// typedQueryType checks if the call is one of the framework's typed query
+// getters, like Iris's ctx.URLParamIntDefault("page", 1), and returns the
+// parameter type
+func (a *Analyzer) typedQueryType(callExpr *ast.CallExpr) (string, bool) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	ident, ok := selExpr.X.(*ast.Ident)
+	if !ok || ident.Name != a.contextName {
+		return "", false
+	}
+	paramType, ok := a.framework.typedQuery[selExpr.Sel.Name]
+	return paramType, ok
+}
+
+// genericQueryType checks if the call is Fiber v3's generic fiber.Query[T](c, "name")
+// and returns its type argument
+func (a *Analyzer) genericQueryType(callExpr *ast.CallExpr) (ast.Expr, bool) {
+	if a.framework.genericQuery == "" {
+		return nil, false
+	}
+	indexExpr, ok := callExpr.Fun.(*ast.IndexExpr)
+	if !ok || len(callExpr.Args) < 2 {
+		return nil, false
+	}
+	selExpr, ok := indexExpr.X.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != a.framework.genericQuery {
+		return nil, false
+	}
+	if ident, ok := callExpr.Args[0].(*ast.Ident); !ok || ident.Name != a.contextName {
+		return nil, false
+	}
+	return indexExpr.Index, true
+}
+
+// isQueryCall checks if the call is c.Query() (or the framework's equivalent)
 func (a *Analyzer) isQueryCall(callExpr *ast.CallExpr) bool {
+	if a.framework.netHTTP {
+		return a.isURLQueryCall(callExpr)
+	}
 	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
 		if ident, ok := selExpr.X.(*ast.Ident); ok {
-			return ident.Name == "c" && selExpr.Sel.Name == "Query"
+			return ident.Name == a.contextName && containsString(a.framework.queryMethods, selExpr.Sel.Name)
 		}
 	}
 	return false
 }
 
+// isURLQueryCall checks if the call is r.URL.Query().Get()
+func (a *Analyzer) isURLQueryCall(callExpr *ast.CallExpr) bool {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "Get" {
+		return false
+	}
+	queryCall, ok := selExpr.X.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	querySel, ok := queryCall.Fun.(*ast.SelectorExpr)
+	if !ok || querySel.Sel.Name != "Query" {
+		return false
+	}
+	urlSel, ok := querySel.X.(*ast.SelectorExpr)
+	if !ok || urlSel.Sel.Name != "URL" {
+		return false
+	}
+	ident, ok := urlSel.X.(*ast.Ident)
+	return ok && ident.Name == a.contextName
+}
 
 // isQueryIntCall checks if the call is c.QueryInt()
 func (a *Analyzer) isQueryIntCall(callExpr *ast.CallExpr) bool {
 	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
 		if ident, ok := selExpr.X.(*ast.Ident); ok {
-			return ident.Name == "c" && selExpr.Sel.Name == "QueryInt"
+			return ident.Name == a.contextName && selExpr.Sel.Name == "QueryInt"
 		}
 	}
 	return false
@@ -100,7 +183,7 @@ func (a *Analyzer) isQueryIntCall(callExpr *ast.CallExpr) bool {
 func (a *Analyzer) isQueryBoolCall(callExpr *ast.CallExpr) bool {
 	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
 		if ident, ok := selExpr.X.(*ast.Ident); ok {
-			return ident.Name == "c" && selExpr.Sel.Name == "QueryBool"
+			return ident.Name == a.contextName && selExpr.Sel.Name == "QueryBool"
 		}
 	}
 	return false
@@ -110,7 +193,7 @@ func (a *Analyzer) isQueryBoolCall(callExpr *ast.CallExpr) bool {
 func (a *Analyzer) isQueryFloatCall(callExpr *ast.CallExpr) bool {
 	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
 		if ident, ok := selExpr.X.(*ast.Ident); ok {
-			return ident.Name == "c" && selExpr.Sel.Name == "QueryFloat"
+			return ident.Name == a.contextName && selExpr.Sel.Name == "QueryFloat"
 		}
 	}
 	return false
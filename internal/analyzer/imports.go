@@ -0,0 +1,133 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/parser"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// handlerPackage holds the handlers of a project package route files import
+type handlerPackage struct {
+	handlers     map[string]HandlerInfo
+	factories    map[string]bool
+	constructors map[string]string // handler struct types returned by the package's functions, see recordHandlerVar
+}
+
+// parseImportedHandlers adds the handlers of the project packages a route
+// file imports, so handlers registered from a sibling package resolve to
+// their definitions: handlers.CreateTenant, or h.CreateTenant when the
+// package is imported as h. Functions are keyed by the name the package is
+// imported under, methods by their type as for local handlers, so handler
+// variables like h := handlers.NewTenantHandler(db) resolve too.
+func (a *Analyzer) parseImportedHandlers(src *ast.File, handlerDir string, handlers map[string]HandlerInfo) {
+	a.importNames = make(map[string]bool)
+	a.importedCtors = make(map[string]string)
+	if a.modulePath == "" {
+		return
+	}
+	for name, path := range fileImports(src) {
+		if name == "_" || !strings.HasPrefix(path, a.modulePath+"/") || a.isSDKImport(path) {
+			continue
+		}
+		dir := a.packageDir(path)
+		if dir == "" || filepath.Clean(dir) == filepath.Clean(handlerDir) {
+			continue
+		}
+		pkg := a.handlerPackage(dir)
+		if len(pkg.handlers) == 0 {
+			continue
+		}
+		// Dot imports use the package's handlers unqualified
+		qualifier := name + "."
+		if name == "." {
+			qualifier = ""
+		} else {
+			a.importNames[name] = true
+		}
+		for funcName, typeName := range pkg.constructors {
+			a.importedCtors[qualifier+funcName] = typeName
+		}
+		for key, info := range pkg.handlers {
+			method := strings.Contains(key, ".")
+			if !method {
+				key = qualifier + key
+			}
+			if _, exists := handlers[key]; exists {
+				continue
+			}
+			handlers[key] = info
+			if pkg.factories[strings.TrimPrefix(key, qualifier)] {
+				a.factories[key] = true
+			}
+			if method {
+				methodName := key[strings.LastIndex(key, ".")+1:]
+				a.methodKeys[methodName] = append(a.methodKeys[methodName], key)
+				sort.Strings(a.methodKeys[methodName])
+			}
+		}
+	}
+}
+
+// handlerPackage parses the handlers of the package in a directory, without
+// its subdirectories, caching them
+func (a *Analyzer) handlerPackage(dir string) *handlerPackage {
+	if pkg, cached := a.handlerPkgs[dir]; cached {
+		return pkg
+	}
+	pkg := &handlerPackage{
+		handlers:     make(map[string]HandlerInfo),
+		constructors: make(map[string]string),
+	}
+	// The route package's factories are kept apart from the imported ones
+	routeFactories := a.factories
+	a.factories = make(map[string]bool)
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		// Files that fail to parse are recorded by skipFile; a strict run
+		// fails once the analysis is done
+		if err := a.parseHandlerFile(file, pkg.handlers); err != nil {
+			continue
+		}
+		a.recordConstructors(file, pkg.constructors)
+	}
+	pkg.factories = a.factories
+	a.factories = routeFactories
+	// Only constructors of handler types matter
+	for funcName, typeName := range pkg.constructors {
+		handlerType := false
+		for key := range pkg.handlers {
+			if strings.HasPrefix(key, typeName+".") {
+				handlerType = true
+				break
+			}
+		}
+		if !handlerType {
+			delete(pkg.constructors, funcName)
+		}
+	}
+	a.handlerPkgs[dir] = pkg
+	return pkg
+}
+
+// recordConstructors records the exported functions of a file returning a
+// struct type: func NewTenantHandler(db *sql.DB) *TenantHandler
+func (a *Analyzer) recordConstructors(file string, constructors map[string]string) {
+	src, err := parser.ParseFile(a.fileSet, file, nil, 0)
+	if err != nil {
+		return
+	}
+	for _, decl := range src.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Recv != nil || !funcDecl.Name.IsExported() || funcDecl.Type.Results == nil || len(funcDecl.Type.Results.List) == 0 {
+			continue
+		}
+		if typeName := a.cleanTypeName(a.extractTypeFromExpr(funcDecl.Type.Results.List[0].Type)); typeName != "" {
+			constructors[funcDecl.Name.Name] = typeName
+		}
+	}
+}
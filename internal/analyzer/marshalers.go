@@ -0,0 +1,171 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"strings"
+)
+
+// recordMarshaler records a MarshalJSON method by the name of its receiver
+// type, with the JSON type its body produces, see marshalJSONType
+func recordMarshaler(funcDecl *ast.FuncDecl, marshalers map[string]string) {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) != 1 || funcDecl.Name.Name != "MarshalJSON" {
+		return
+	}
+	if funcDecl.Type.Params.NumFields() != 0 || funcDecl.Type.Results.NumFields() != 2 {
+		return
+	}
+	recv := funcDecl.Recv.List[0].Type
+	if star, ok := recv.(*ast.StarExpr); ok {
+		recv = star.X
+	}
+	ident, ok := recv.(*ast.Ident)
+	if !ok {
+		return
+	}
+	marshalers[ident.Name] = marshalJSONType(funcDecl)
+}
+
+// loadMarshaler copies the MarshalJSON method of a type loaded from a model
+// package into the analysis
+func (a *Analyzer) loadMarshaler(analysis *Analysis, path, name string) {
+	pkg := a.typePkgs[path]
+	if pkg == nil {
+		return
+	}
+	if jsonType, exists := pkg.marshalers[name]; exists {
+		analysis.Marshalers[name] = jsonType
+	}
+}
+
+// marshalJSONType infers the JSON type a MarshalJSON method produces from
+// what it returns: json.Marshal of a string, number or boolean expression,
+// or a quoted string converted to bytes. It returns "" when the returns
+// disagree or can't be classified.
+//
+//	func (m Money) MarshalJSON() ([]byte, error) {
+//		return json.Marshal(m.String())
+//	}
+func marshalJSONType(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Body == nil {
+		return ""
+	}
+	jsonType := ""
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		if _, isFunc := n.(*ast.FuncLit); isFunc {
+			return false
+		}
+		ret, ok := n.(*ast.ReturnStmt)
+		if !ok || len(ret.Results) == 0 {
+			return true
+		}
+		if ident, ok := ret.Results[0].(*ast.Ident); ok && ident.Name == "nil" {
+			return true
+		}
+		returned := returnedJSONType(ret.Results[0])
+		if jsonType == "" {
+			jsonType = returned
+		}
+		if returned == "" || returned != jsonType {
+			jsonType = "-"
+		}
+		return true
+	})
+	if jsonType == "-" {
+		return ""
+	}
+	return jsonType
+}
+
+// returnedJSONType classifies the bytes a MarshalJSON method returns
+func returnedJSONType(expr ast.Expr) string {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || len(call.Args) == 0 {
+		return ""
+	}
+	switch fun := call.Fun.(type) {
+	case *ast.SelectorExpr:
+		// json.Marshal(m.String())
+		if pkg, ok := fun.X.(*ast.Ident); ok && pkg.Name == "json" && fun.Sel.Name == "Marshal" {
+			return valueJSONType(call.Args[0])
+		}
+		// strconv.AppendQuote(nil, m.String())
+		if pkg, ok := fun.X.(*ast.Ident); ok && pkg.Name == "strconv" && fun.Sel.Name == "AppendQuote" {
+			return "string"
+		}
+	case *ast.ArrayType:
+		// []byte(strconv.Quote(s)), []byte(`"` + s + `"`)
+		if isQuotedString(call.Args[0]) {
+			return "string"
+		}
+	}
+	return ""
+}
+
+// valueJSONType classifies a value passed to json.Marshal
+func valueJSONType(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		switch e.Kind {
+		case token.STRING:
+			return "string"
+		case token.INT:
+			return "integer"
+		case token.FLOAT:
+			return "number"
+		}
+	case *ast.BinaryExpr:
+		if e.Op == token.ADD && valueJSONType(e.X) == "string" {
+			return "string"
+		}
+	case *ast.CallExpr:
+		switch fun := e.Fun.(type) {
+		case *ast.Ident:
+			// Conversions
+			switch fun.Name {
+			case "string":
+				return "string"
+			case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+				return "integer"
+			case "float32", "float64":
+				return "number"
+			case "bool":
+				return "boolean"
+			}
+		case *ast.SelectorExpr:
+			if pkg, ok := fun.X.(*ast.Ident); ok && (pkg.Name == "fmt" || pkg.Name == "strconv") {
+				if strings.HasPrefix(fun.Sel.Name, "Sprint") || strings.HasPrefix(fun.Sel.Name, "Format") || fun.Sel.Name == "Itoa" || fun.Sel.Name == "Quote" {
+					return "string"
+				}
+				return ""
+			}
+			// m.String(), t.Format(layout), id.Hex()
+			switch fun.Sel.Name {
+			case "String", "Format", "Hex":
+				return "string"
+			}
+		}
+	}
+	return ""
+}
+
+// isQuotedString reports whether a string expression is quoted for JSON:
+// strconv.Quote(s), or a concatenation starting with a double quote
+func isQuotedString(expr ast.Expr) bool {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		if sel, ok := e.Fun.(*ast.SelectorExpr); ok {
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "strconv" && sel.Sel.Name == "Quote" {
+				return true
+			}
+			if pkg, ok := sel.X.(*ast.Ident); ok && pkg.Name == "fmt" && sel.Sel.Name == "Sprintf" && len(e.Args) > 0 {
+				return isQuotedString(e.Args[0])
+			}
+		}
+	case *ast.BinaryExpr:
+		return e.Op == token.ADD && isQuotedString(e.X)
+	case *ast.BasicLit:
+		return e.Kind == token.STRING && (strings.HasPrefix(e.Value, `"\"`) || strings.HasPrefix(e.Value, "`\""))
+	}
+	return false
+}
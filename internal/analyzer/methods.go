@@ -0,0 +1,112 @@
+package analyzer
+
+import (
+	"go/ast"
+	"sort"
+	"strings"
+)
+
+// handlerKey names a handler in the handlers map: functions by their name,
+// methods by their receiver type and name, e.g. UserHandler.List
+func handlerKey(funcDecl *ast.FuncDecl) string {
+	if receiver := receiverTypeName(funcDecl); receiver != "" {
+		return receiver + "." + funcDecl.Name.Name
+	}
+	return funcDecl.Name.Name
+}
+
+// receiverTypeName returns the receiver type of a method, without pointer
+func receiverTypeName(funcDecl *ast.FuncDecl) string {
+	if funcDecl.Recv == nil || len(funcDecl.Recv.List) == 0 {
+		return ""
+	}
+	recvType := funcDecl.Recv.List[0].Type
+	if star, ok := recvType.(*ast.StarExpr); ok {
+		recvType = star.X
+	}
+	if ident, ok := recvType.(*ast.Ident); ok {
+		return ident.Name
+	}
+	return ""
+}
+
+// indexHandlerMethods maps method names to the handler methods of that name
+func indexHandlerMethods(handlers map[string]HandlerInfo) map[string][]string {
+	methods := make(map[string][]string)
+	for key := range handlers {
+		if idx := strings.LastIndex(key, "."); idx != -1 {
+			methods[key[idx+1:]] = append(methods[key[idx+1:]], key)
+		}
+	}
+	for _, keys := range methods {
+		sort.Strings(keys)
+	}
+	return methods
+}
+
+// recordHandlerParams records the handler struct parameters of a function
+// registering routes: func RegisterRoutes(app *fiber.App, h *UserHandler)
+func (a *Analyzer) recordHandlerParams(funcDecl *ast.FuncDecl) {
+	for _, param := range funcDecl.Type.Params.List {
+		typeName := a.cleanTypeName(a.extractTypeFromExpr(param.Type))
+		for _, name := range param.Names {
+			a.handlerVars[name.Name] = typeName
+		}
+	}
+}
+
+// recordHandlerVar records variables holding a handler struct:
+// h := &UserHandler{}, h := NewUserHandler(svc), h := handlers.NewUserHandler(svc)
+// or h := new(UserHandler)
+func (a *Analyzer) recordHandlerVar(name string, value ast.Expr, funcs map[string]*ast.FuncDecl) {
+	if unary, ok := value.(*ast.UnaryExpr); ok {
+		value = unary.X
+	}
+	switch v := value.(type) {
+	case *ast.CompositeLit:
+		a.handlerVars[name] = a.cleanTypeName(a.extractTypeFromExpr(v.Type))
+	case *ast.CallExpr:
+		if fun, ok := v.Fun.(*ast.Ident); ok {
+			if fun.Name == "new" && len(v.Args) == 1 {
+				a.handlerVars[name] = a.cleanTypeName(a.extractTypeFromExpr(v.Args[0]))
+				return
+			}
+			// Constructors of the route package
+			if funcDecl, exists := funcs[fun.Name]; exists && funcDecl.Type.Results != nil && len(funcDecl.Type.Results.List) > 0 {
+				a.handlerVars[name] = a.cleanTypeName(a.extractTypeFromExpr(funcDecl.Type.Results.List[0].Type))
+				return
+			}
+		}
+		// Constructors of imported handler packages
+		if fun, ok := v.Fun.(*ast.SelectorExpr); ok {
+			if pkg, ok := fun.X.(*ast.Ident); ok {
+				if typeName, exists := a.importedCtors[pkg.Name+"."+fun.Sel.Name]; exists {
+					a.handlerVars[name] = typeName
+					return
+				}
+			}
+		}
+		if typeName := a.presenterCallType(v); typeName != "" {
+			a.handlerVars[name] = a.cleanTypeName(typeName)
+		}
+	}
+}
+
+// methodHandlerName resolves a method value used as a handler, h.List, to
+// its handler key. The receiver's type comes from the variable holding it;
+// failing that, a method name used by a single handler type is enough.
+func (a *Analyzer) methodHandlerName(selExpr *ast.SelectorExpr) string {
+	if ident, ok := selExpr.X.(*ast.Ident); ok {
+		if typeName, exists := a.handlerVars[ident.Name]; exists {
+			return typeName + "." + selExpr.Sel.Name
+		}
+		// Functions of an imported handler package: handlers.CreateTenant
+		if a.importNames[ident.Name] {
+			return ident.Name + "." + selExpr.Sel.Name
+		}
+	}
+	if keys := a.methodKeys[selExpr.Sel.Name]; len(keys) == 1 {
+		return keys[0]
+	}
+	return ""
+}
@@ -0,0 +1,407 @@
+package analyzer
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// typeRef names a type of another package: dto.User in a handler is
+// {path: "example.com/app/pkg/dto", name: "User"}
+type typeRef struct {
+	path string
+	name string
+}
+
+// packageTypes holds the type declarations of a package models are loaded
+// from, with the imports of the file declaring each
+type packageTypes struct {
+	dir        string
+	specs      map[string]*ast.TypeSpec
+	docs       map[string]*ast.CommentGroup
+	imports    map[string]map[string]string // by type name: import name -> path
+	enums      map[string][]string          // values of the typed constants, by type name
+	marshalers map[string]string            // types with a MarshalJSON method, see recordMarshaler
+}
+
+// parseModelPackages loads every struct of the configured model packages,
+// given as directories relative to the project or as import paths, and of
+// the packages below them, like the SDK package
+func (a *Analyzer) parseModelPackages(analysis *Analysis) error {
+	for _, pkg := range a.modelPackages {
+		root := filepath.Join(a.projectPath, filepath.FromSlash(pkg))
+		rootPath := a.goPackage(root)
+		if info, err := os.Stat(root); err != nil || !info.IsDir() {
+			root, rootPath = a.packageDir(pkg), pkg
+		}
+		if root == "" {
+			return fmt.Errorf("model package %s not found in the project, its vendor directory or the module cache", pkg)
+		}
+		err := filepath.Walk(root, func(dir string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if !info.IsDir() {
+				return nil
+			}
+			if err := a.canceled(); err != nil {
+				return err
+			}
+			if dir != root && (exampleSkippedDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			rel, _ := filepath.Rel(root, dir)
+			path := rootPath
+			if rel != "." {
+				path += "/" + filepath.ToSlash(rel)
+			}
+			types := a.packageTypes(path, dir)
+			if types == nil {
+				return nil
+			}
+			for name, spec := range types.specs {
+				a.loadModel(analysis, path, name, spec, types.docs[name])
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("failed to parse model package %s: %w", pkg, err)
+		}
+	}
+	return nil
+}
+
+// loadModel adds a struct type of a package to the models, or a named
+// type to the named types. The first model of a name wins; SDK models are
+// loaded before all others.
+func (a *Analyzer) loadModel(analysis *Analysis, path, name string, spec *ast.TypeSpec, doc *ast.CommentGroup) bool {
+	if a.registerGeneric(spec, doc, path) {
+		return true
+	}
+	switch t := spec.Type.(type) {
+	case *ast.StructType:
+		if existing, exists := analysis.Models[name]; exists {
+			if existing.GoPackage != path {
+				fmt.Printf("Warning: %s.%s has the name of model %s from %s; it is not loaded\n", path, name, name, existing.GoPackage)
+			}
+			return false
+		}
+		model := a.parseStruct(name, t, doc)
+		model.GoPackage = path
+		analysis.Models[name] = model
+		a.loadMarshaler(analysis, path, name)
+		fmt.Printf("[DEBUG] Loaded model %s from %s\n", name, path)
+		return true
+	default:
+		if _, exists := analysis.NamedTypes[name]; !exists {
+			if underlying := a.namedUnderlying(spec); underlying != "" {
+				analysis.NamedTypes[name] = underlying
+				a.loadMarshaler(analysis, path, name)
+				if pkg := a.typePkgs[path]; pkg != nil && len(pkg.enums[name]) > 0 {
+					analysis.Enums[name] = pkg.enums[name]
+				}
+			}
+		}
+	}
+	return false
+}
+
+// loadReferencedModels loads the structs of other packages that handlers
+// and models use but no model package declares, like dto.User built in a
+// handler or an Address field of type common.Address, and the structs those
+// reference in turn. Only payload positions count: composite literals,
+// var declarations and new() in handlers, and struct fields of models.
+func (a *Analyzer) loadReferencedModels(analysis *Analysis) {
+	var queue []typeRef
+
+	// Fields of the models loaded so far
+	for _, model := range analysis.Models {
+		if model.GoPackage == "" {
+			continue
+		}
+		if pkg := a.packageTypes(model.GoPackage, ""); pkg != nil {
+			if spec, exists := pkg.specs[model.Name]; exists {
+				queue = append(queue, a.fieldRefs(spec, model.GoPackage, pkg.imports[model.Name])...)
+			}
+		}
+	}
+
+	// Payloads of the handlers next to the route files
+	var routeFiles []string
+	if !a.modelsOnly {
+		routeFiles, _ = filepath.Glob(filepath.Join(a.projectPath, a.routesPattern))
+	}
+	dirs := make(map[string]bool)
+	for _, routeFile := range routeFiles {
+		dirs[filepath.Dir(routeFile)] = true
+	}
+	for dir := range dirs {
+		files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+		for _, file := range files {
+			if strings.HasSuffix(file, "_test.go") {
+				continue
+			}
+			src, err := parser.ParseFile(token.NewFileSet(), file, nil, 0)
+			if err != nil {
+				continue
+			}
+			queue = append(queue, handlerRefs(src)...)
+		}
+	}
+
+	if loaded := a.loadRefs(analysis, queue); loaded > 0 {
+		fmt.Printf("Loaded %d models referenced from outside the model packages\n", loaded)
+	}
+}
+
+// loadRefs loads the referenced structs not loaded yet as models, and the
+// structs their fields reference in turn. It returns the number loaded.
+func (a *Analyzer) loadRefs(analysis *Analysis, queue []typeRef) int {
+	loaded := 0
+	for len(queue) > 0 {
+		ref := queue[0]
+		queue = queue[1:]
+		if a.seenRefs[ref] || a.isSDKImport(ref.path) || isWellKnownImport(ref.path) {
+			continue
+		}
+		a.seenRefs[ref] = true
+
+		pkg := a.packageTypes(ref.path, "")
+		if pkg == nil {
+			continue
+		}
+		spec, exists := pkg.specs[ref.name]
+		if !exists {
+			continue
+		}
+		if a.loadModel(analysis, ref.path, ref.name, spec, pkg.docs[ref.name]) {
+			loaded++
+			queue = append(queue, a.fieldRefs(spec, ref.path, pkg.imports[ref.name])...)
+		}
+	}
+	return loaded
+}
+
+// isSDKImport reports whether an import path is the SDK package, whose
+// models are all loaded already
+func (a *Analyzer) isSDKImport(path string) bool {
+	return a.modulePath != "" && path == a.goPackage(filepath.Join(a.projectPath, "sdk"))
+}
+
+// handlerRefs returns the types of other packages a handler file builds
+// payloads from: dto.User{...}, var req dto.CreateUser and new(dto.Filter)
+func handlerRefs(src *ast.File) []typeRef {
+	imports := fileImports(src)
+	var refs []typeRef
+	add := func(expr ast.Expr) {
+		refs = append(refs, selectorRefs(expr, imports)...)
+	}
+	for _, decl := range src.Decls {
+		funcDecl, ok := decl.(*ast.FuncDecl)
+		if !ok || funcDecl.Body == nil {
+			continue
+		}
+		ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.CompositeLit:
+				add(node.Type)
+			case *ast.ValueSpec:
+				add(node.Type)
+			case *ast.CallExpr:
+				if fun, ok := node.Fun.(*ast.Ident); ok && fun.Name == "new" && len(node.Args) == 1 {
+					add(node.Args[0])
+				}
+			}
+			return true
+		})
+	}
+	return refs
+}
+
+// fieldRefs returns the types a struct's fields use: types of its own
+// package by name, and types of imported packages
+func (a *Analyzer) fieldRefs(spec *ast.TypeSpec, path string, imports map[string]string) []typeRef {
+	structType, ok := spec.Type.(*ast.StructType)
+	if !ok {
+		return nil
+	}
+	var refs []typeRef
+	for _, field := range structType.Fields.List {
+		refs = append(refs, selectorRefs(field.Type, imports)...)
+		ast.Inspect(field.Type, func(n ast.Node) bool {
+			switch node := n.(type) {
+			case *ast.SelectorExpr:
+				return false
+			case *ast.Ident:
+				if node.IsExported() {
+					refs = append(refs, typeRef{path: path, name: node.Name})
+				}
+			}
+			return true
+		})
+	}
+	return refs
+}
+
+// selectorRefs returns the pkg.Type references in a type expression whose
+// package is imported and not the standard library
+func selectorRefs(expr ast.Expr, imports map[string]string) []typeRef {
+	if expr == nil {
+		return nil
+	}
+	var refs []typeRef
+	ast.Inspect(expr, func(n ast.Node) bool {
+		selExpr, ok := n.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if pkg, ok := selExpr.X.(*ast.Ident); ok && selExpr.Sel.IsExported() {
+			if path, imported := imports[pkg.Name]; imported && !isStdImport(path) {
+				refs = append(refs, typeRef{path: path, name: selExpr.Sel.Name})
+			}
+		}
+		return false
+	})
+	return refs
+}
+
+// isStdImport reports whether an import path is of the standard library,
+// whose first element has no dot
+func isStdImport(path string) bool {
+	return !strings.Contains(strings.Split(path, "/")[0], ".")
+}
+
+// isWellKnownImport reports whether an import path is a package whose
+// types the generator documents itself rather than as models: pgx's pgtype,
+// whose nullable wrappers are documented as the values they wrap, the uuid
+// packages, whose UUID is a string, and the decimal packages
+func isWellKnownImport(path string) bool {
+	switch path {
+	case "github.com/jackc/pgtype", "github.com/google/uuid", "github.com/gofrs/uuid", "github.com/satori/go.uuid",
+		"github.com/shopspring/decimal", "github.com/cockroachdb/apd":
+		return true
+	}
+	return strings.HasPrefix(path, "github.com/jackc/pgx/") && strings.HasSuffix(path, "/pgtype") ||
+		strings.HasPrefix(path, "github.com/gofrs/uuid/") || strings.HasPrefix(path, "github.com/cockroachdb/apd/")
+}
+
+// fileImports maps the names a file imports packages under to their paths
+func fileImports(src *ast.File) map[string]string {
+	imports := make(map[string]string)
+	for _, imp := range src.Imports {
+		path, err := strconv.Unquote(imp.Path.Value)
+		if err != nil {
+			continue
+		}
+		name := filepath.Base(path)
+		// gopkg.in/yaml.v3 and example.com/api/v2 are imported as yaml and api
+		if strings.HasPrefix(name, "v") && len(name) > 1 && strings.Trim(name[1:], "0123456789") == "" {
+			name = filepath.Base(filepath.Dir(path))
+		}
+		name = strings.SplitN(name, ".", 2)[0]
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		imports[name] = path
+	}
+	return imports
+}
+
+// packageTypes parses the type declarations of the package with an import
+// path, caching them. dir is the package's directory if already known.
+func (a *Analyzer) packageTypes(path, dir string) *packageTypes {
+	if pkg, cached := a.typePkgs[path]; cached {
+		return pkg
+	}
+	a.typePkgs[path] = nil
+	if dir == "" {
+		dir = a.packageDir(path)
+	}
+	if dir == "" {
+		return nil
+	}
+
+	pkg := &packageTypes{
+		dir:        dir,
+		specs:      make(map[string]*ast.TypeSpec),
+		docs:       make(map[string]*ast.CommentGroup),
+		imports:    make(map[string]map[string]string),
+		enums:      make(map[string][]string),
+		marshalers: make(map[string]string),
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		src, err := parser.ParseFile(a.fileSet, file, nil, parser.ParseComments)
+		if err != nil {
+			a.skipFile(file, err)
+			continue
+		}
+		imports := fileImports(src)
+		for _, decl := range src.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok {
+				recordMarshaler(funcDecl, pkg.marshalers)
+				continue
+			}
+			genDecl, ok := decl.(*ast.GenDecl)
+			if !ok {
+				continue
+			}
+			recordEnumConstants(genDecl, pkg.enums)
+			if genDecl.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range genDecl.Specs {
+				typeSpec, ok := spec.(*ast.TypeSpec)
+				if !ok || !typeSpec.Name.IsExported() {
+					continue
+				}
+				pkg.specs[typeSpec.Name.Name] = typeSpec
+				pkg.docs[typeSpec.Name.Name] = genDecl.Doc
+				if typeSpec.Doc != nil {
+					pkg.docs[typeSpec.Name.Name] = typeSpec.Doc
+				}
+				pkg.imports[typeSpec.Name.Name] = imports
+				if _, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+					a.interfaces[typeSpec.Name.Name] = true
+				}
+			}
+		}
+	}
+	a.typePkgs[path] = pkg
+	return pkg
+}
+
+// packageDir finds the directory of a package by import path: in the
+// project, its vendor directory, or the module cache. It never downloads.
+func (a *Analyzer) packageDir(path string) string {
+	if a.modulePath != "" && (path == a.modulePath || strings.HasPrefix(path, a.modulePath+"/")) {
+		return filepath.Join(a.projectPath, filepath.FromSlash(strings.TrimPrefix(strings.TrimPrefix(path, a.modulePath), "/")))
+	}
+	vendored := filepath.Join(a.projectPath, "vendor", filepath.FromSlash(path))
+	if info, err := os.Stat(vendored); err == nil && info.IsDir() {
+		return vendored
+	}
+	if a.modulePath == "" {
+		return ""
+	}
+
+	cmd := exec.Command("go", "list", "-find", "-f", "{{.Dir}}", path)
+	cmd.Dir = a.projectPath
+	cmd.Env = append(os.Environ(), "GOPROXY=off", "GOFLAGS=-mod=readonly")
+	out, err := cmd.Output()
+	if err != nil {
+		fmt.Printf("[DEBUG] Package %s not found in the module cache\n", path)
+		return ""
+	}
+	return string(bytes.TrimSpace(out))
+}
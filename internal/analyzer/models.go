@@ -1,8 +1,26 @@
 package analyzer
 
 type Analysis struct {
-	Routes []Route
-	Models map[string]Model
+	Routes     []Route
+	Models     map[string]Model
+	NamedTypes map[string]string   // non-struct model types to their underlying type, e.g. Email -> string, Tags -> []string
+	Enums      map[string][]string // values of the constants of named types, e.g. Status -> [active inactive]
+	Marshalers map[string]string   // types with a MarshalJSON method, to the JSON type it produces if inferred, e.g. Money -> string
+	Framework  string              // router framework the routes were analyzed as, after auto-detection
+	Stats      Stats               // how the handlers' types were resolved
+
+	SkippedFiles []FileError // files skipped for syntax errors, see skipFile
+}
+
+// Stats counts how the analyzer resolved request and response types, for
+// the usage statistics report
+type Stats struct {
+	Attempts        map[string]int // heuristics tried on a type, by heuristic; see attempt
+	Resolved        map[string]int // types inferred, by the heuristic that inferred them
+	Matched         int            // request and response types that matched a model
+	Unresolved      []string       // request and response types that matched no model
+	TypeChecked     int            // packages type-checked, see loadTypes
+	TypeCheckFailed int            // packages that failed to type-check
 }
 
 type Route struct {
@@ -14,17 +32,33 @@ type Route struct {
 	Response    *Model
 	Parameters  []Parameter
 	Tags        []string
+	SourceFile  string // route file path relative to the project root
+	Annotations map[string]string
+
+	FormFields     []Parameter   // form fields the handler reads, documented as a form body without RequestBody
+	Multipart      bool          // the form is multipart/form-data, with files
+	File           *FileResponse // the file the handler responds with, documented without Response
+	ResponseArray  bool          // the handler responds with a slice of Response
+	NotImplemented bool
+	Name           string // route name given with .Name("listUsers"), if any
+	StaticRoot     string // directory served by a Static route
+	WebSocket      bool   // the route upgrades to a WebSocket connection
+	Accepted       bool   // the handler answers 202 Accepted, starting an asynchronous job
+	Statuses       []int  // response statuses the handler sets, like 201 or 404
 }
 
 type Parameter struct {
 	Name        string
-	In          string // "path", "query", "header"
+	In          string // "path", "query", "header", "cookie"
 	Required    bool
 	Type        string
 	Example     string
 	Description string
 	Default     interface{}
 	Enum        []string
+	EnumName    string // named type the enum values belong to, if any
+	GoType      string // named Go type the parameter is declared with, e.g. Email
+	Wildcard    bool   // catch-all path parameter: the rest of the path, slashes included
 }
 
 type QueryParameter struct {
@@ -34,11 +68,16 @@ type QueryParameter struct {
 	Description string
 	Default     interface{}
 	Enum        []string
+	EnumName    string
+	GoType      string
+	Source      string // how the handler reads it: querySourceStruct, querySourceGetter or querySourceCall
 }
 
 type Model struct {
 	Name        string
 	Package     string
+	GoPackage   string // import path of the package declaring the model, if known
+	GoName      string // Go type of the model when it isn't Name, like Page[User] for the generic instance PageUser
 	Fields      []Field
 	Description string
 }
@@ -51,6 +90,12 @@ type Field struct {
 	Required    bool
 	Description string
 	Example     interface{}
+	OneOf       []string // schema names from an //openapi:oneOf annotation
+
+	Enum     []string // allowed values
+	EnumName string   // named type the enum values belong to, if any
+	Inline   bool     // embedded without a JSON name, or tagged ",inline": the type's fields are promoted
+	Validate string   // rules of the validate tag, e.g. "required,min=1,max=50"
 }
 
 type HandlerInfo struct {
@@ -60,6 +105,19 @@ type HandlerInfo struct {
 	Package         string
 	QueryParameters []QueryParameter
 	AnonymousRequestModel *Model 
+	Annotations     map[string]string // "// @key value" lines from the doc comment
+
+	NotImplemented     bool              // stub body: empty or only returns ErrNotImplemented
+	PathParameters     []string          // path parameters read in the handler, e.g. ps.ByName("id")
+	PathParameterTypes map[string]string // types of path parameters read with typed getters
+	HeaderParameters   []Parameter       // header parameters read in the handler
+	CookieParameters   []Parameter       // cookie parameters read in the handler
+	FormFields         []Parameter       // form fields read in the handler, see extractFormFields
+	Multipart          bool              // the handler reads files or a multipart form
+	File               *FileResponse     // the file the handler responds with, see fileResponse
+	WebSocket          bool              // the handler upgrades the connection to a WebSocket
+	Accepted           bool              // the handler answers 202 Accepted, see respondsAccepted
+	Statuses           []int             // response statuses the handler sets, see responseStatuses
 }
 
 type RouteGroup struct {
@@ -6,11 +6,13 @@ import (
 	"go/token"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 )
 
 func (a *Analyzer) parseSDKModels(analysis *Analysis) error {
 	sdkPath := filepath.Join(a.projectPath, "sdk")
+	a.modulePath = readModulePath(a.projectPath)
 
 	return filepath.Walk(sdkPath, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -20,6 +22,9 @@ func (a *Analyzer) parseSDKModels(analysis *Analysis) error {
 		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
 			return nil
 		}
+		if err := a.canceled(); err != nil {
+			return err
+		}
 
 		return a.parseSDKFile(path, analysis)
 	})
@@ -28,21 +33,33 @@ func (a *Analyzer) parseSDKModels(analysis *Analysis) error {
 func (a *Analyzer) parseSDKFile(filePath string, analysis *Analysis) error {
 	src, err := parser.ParseFile(a.fileSet, filePath, nil, parser.ParseComments)
 	if err != nil {
-		return err
+		return a.skipFile(filePath, err)
 	}
 
 	ast.Inspect(src, func(n ast.Node) bool {
 		switch node := n.(type) {
+		case *ast.FuncDecl:
+			recordMarshaler(node, analysis.Marshalers)
 		case *ast.GenDecl:
+			recordEnumConstants(node, analysis.Enums)
 			if node.Tok == token.TYPE {
 				for _, spec := range node.Specs {
 					if typeSpec, ok := spec.(*ast.TypeSpec); ok {
+						if a.registerGeneric(typeSpec, node.Doc, a.goPackage(filepath.Dir(filePath))) {
+							continue
+						}
 						if structType, ok := typeSpec.Type.(*ast.StructType); ok {
 							model := a.parseStruct(typeSpec.Name.Name, structType, node.Doc)
+							model.GoPackage = a.goPackage(filepath.Dir(filePath))
 							// Clean the model name before storing
 							cleanName := a.cleanTypeName(model.Name)
 							model.Name = cleanName
 							analysis.Models[cleanName] = model
+						} else if _, ok := typeSpec.Type.(*ast.InterfaceType); ok {
+							a.interfaces[typeSpec.Name.Name] = true
+						} else if underlying := a.namedUnderlying(typeSpec); underlying != "" {
+							// type Email string, type Email = string, type Tags []string
+							analysis.NamedTypes[typeSpec.Name.Name] = underlying
 						}
 					}
 				}
@@ -54,6 +71,93 @@ func (a *Analyzer) parseSDKFile(filePath string, analysis *Analysis) error {
 	return nil
 }
 
+// namedUnderlying returns the underlying type of a named type or alias
+// declaration that isn't a struct, like string for type UserID string or
+// []string for type Tags = []string, or "" for types without a schema of
+// their own, like interfaces and funcs
+func (a *Analyzer) namedUnderlying(spec *ast.TypeSpec) string {
+	switch spec.Type.(type) {
+	case *ast.Ident, *ast.SelectorExpr, *ast.StarExpr, *ast.ArrayType, *ast.MapType:
+		return a.getTypeStringWithArrays(spec.Type)
+	}
+	return ""
+}
+
+// resolveNamedType follows named types and aliases that aren't models to
+// their underlying type, like []Tenant for type Tenants = []Tenant, so
+// c.JSON(sdk.Tenants{}) responds with an array of Tenant. Other types are
+// returned as is.
+func (a *Analyzer) resolveNamedType(typeName string, analysis *Analysis) string {
+	for depth := 0; depth < maxConstDepth; depth++ {
+		prefix := ""
+		name := strings.TrimPrefix(typeName, "*")
+		if strings.HasPrefix(name, "[]") {
+			prefix, name = "[]", strings.TrimPrefix(name, "[]")
+		}
+		name = a.cleanTypeName(name)
+		if _, isModel := analysis.Models[name]; isModel {
+			return typeName
+		}
+		underlying, named := analysis.NamedTypes[name]
+		if !named || prefix != "" && strings.HasPrefix(underlying, "[]") {
+			return typeName
+		}
+		typeName = prefix + underlying
+	}
+	return typeName
+}
+
+// readModulePath reads the module path from dir/go.mod, or returns "" if
+// there is none
+func readModulePath(dir string) string {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	match := regexp.MustCompile(`(?m)^module\s+(\S+)`).FindSubmatch(data)
+	if match == nil {
+		return ""
+	}
+	return string(match[1])
+}
+
+// goPackage returns the import path of the project package in dir
+func (a *Analyzer) goPackage(dir string) string {
+	if a.modulePath == "" {
+		return ""
+	}
+	rel, err := filepath.Rel(a.projectPath, dir)
+	if err != nil || rel == "." {
+		return a.modulePath
+	}
+	return a.modulePath + "/" + filepath.ToSlash(rel)
+}
+
+// isInlineTag reports whether a JSON tag carries the inline option
+func isInlineTag(jsonTag string) bool {
+	for _, option := range strings.Split(jsonTag, ",")[1:] {
+		if option == "inline" {
+			return true
+		}
+	}
+	return false
+}
+
+// hasValidateRule reports whether the rules of a validate tag include rule
+// for the field itself, rather than for its elements after dive
+func hasValidateRule(rules, rule string) bool {
+	for _, r := range strings.Split(rules, ",") {
+		name := strings.SplitN(r, "=", 2)[0]
+		if name == "dive" {
+			return false
+		}
+		if name == rule {
+			return true
+		}
+	}
+	return false
+}
+
 // Update the parseStruct function in internal/analyzer/parser.go
 func (a *Analyzer) parseStruct(name string, structType *ast.StructType, doc *ast.CommentGroup) Model {
 	model := Model{
@@ -62,26 +166,27 @@ func (a *Analyzer) parseStruct(name string, structType *ast.StructType, doc *ast
 		Fields:  []Field{},
 	}
 
-	if doc != nil {
-		model.Description = strings.TrimSpace(doc.Text())
-	}
+	model.Description = docText(doc)
 
 	for _, field := range structType.Fields.List {
 		if len(field.Names) == 0 {
 			// Embedded field
 			fieldType := a.getTypeStringWithArrays(field.Type)
 			modelField := Field{
-				Name:         fieldType,
+				Name:         a.cleanTypeName(fieldType),
 				Type:         fieldType,
 				OriginalType: fieldType, // Preserve original
+				Inline:       true,
 			}
 
-			// Parse JSON tag for embedded fields
+			// Parse JSON tag for embedded fields; a JSON name makes the
+			// embedded struct a property of its own, as with encoding/json
 			if field.Tag != nil {
 				tag := field.Tag.Value
 				if jsonTag := a.extractJSONTag(tag); jsonTag != "" {
 					modelField.JSONTag = jsonTag
 					modelField.Required = !strings.Contains(jsonTag, "omitempty")
+					modelField.Inline = strings.Split(jsonTag, ",")[0] == "" || isInlineTag(jsonTag)
 				}
 			}
 
@@ -120,9 +225,31 @@ func (a *Analyzer) parseStruct(name string, structType *ast.StructType, doc *ast
 					modelField.Required = true
 				}
 
+				// Validation rules decide whether a field is required:
+				// validate:"required" (or Gin's binding:"required") makes it
+				// required even with omitempty, and an omitempty rule makes
+				// it optional. The other rules become schema constraints.
+				if field.Tag != nil {
+					modelField.Validate = a.extractStructTag(field.Tag.Value, "validate")
+					if modelField.Validate == "" {
+						modelField.Validate = a.extractStructTag(field.Tag.Value, "binding")
+					}
+					if hasValidateRule(modelField.Validate, "required") {
+						modelField.Required = true
+					} else if hasValidateRule(modelField.Validate, "omitempty") {
+						modelField.Required = false
+					}
+				}
+
 				// Parse field comments
-				if field.Doc != nil {
-					modelField.Description = strings.TrimSpace(field.Doc.Text())
+				modelField.Description = withoutOneOf(docText(field.Doc))
+
+				// json:",inline" promotes the fields of a named struct field
+				modelField.Inline = isInlineTag(modelField.JSONTag)
+
+				// Parse //openapi:oneOf TypeA,TypeB annotations
+				if oneOf := a.extractOneOfAnnotation(field); len(oneOf) > 0 {
+					modelField.OneOf = oneOf
 				}
 
 				model.Fields = append(model.Fields, modelField)
@@ -135,8 +262,15 @@ func (a *Analyzer) parseStruct(name string, structType *ast.StructType, doc *ast
 
 // getTypeStringWithArrays is an improved version that better handles array types
 func (a *Analyzer) getTypeStringWithArrays(expr ast.Expr) string {
+	if name, ok := a.indexedTypeName(expr, a.getTypeStringWithArrays); ok {
+		return name
+	}
 	switch t := expr.(type) {
 	case *ast.Ident:
+		// Type parameters of the generic being instantiated
+		if arg, ok := a.typeArgs[t.Name]; ok {
+			return arg
+		}
 		return t.Name
 	case *ast.SelectorExpr:
 		pkg := a.getTypeStringWithArrays(t.X)
@@ -176,6 +310,7 @@ func (a *Analyzer) getTypeStringWithArrays(expr ast.Expr) string {
 
 func (a *Analyzer) parseHandlers(handlerDir string) (map[string]HandlerInfo, error) {
 	handlers := make(map[string]HandlerInfo)
+	a.factories = make(map[string]bool)
 
 	err := filepath.Walk(handlerDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
@@ -187,6 +322,9 @@ func (a *Analyzer) parseHandlers(handlerDir string) (map[string]HandlerInfo, err
 			strings.HasSuffix(path, "router.go") {
 			return nil
 		}
+		if err := a.canceled(); err != nil {
+			return err
+		}
 
 		return a.parseHandlerFile(path, handlers)
 	})
@@ -195,20 +333,30 @@ func (a *Analyzer) parseHandlers(handlerDir string) (map[string]HandlerInfo, err
 }
 
 func (a *Analyzer) parseHandlerFile(filePath string, handlers map[string]HandlerInfo) error {
-	src, err := parser.ParseFile(a.fileSet, filePath, nil, 0)
+	src, err := parser.ParseFile(a.fileSet, filePath, nil, parser.ParseComments)
 	if err != nil {
-		return err
+		return a.skipFile(filePath, err)
 	}
+	a.handlerDir = filepath.Dir(filePath)
+	a.handlerImports = fileImports(src)
 
 	ast.Inspect(src, func(n ast.Node) bool {
 		if funcDecl, ok := n.(*ast.FuncDecl); ok {
 			handlerInfo := a.analyzeHandlerFunction(funcDecl)
+			// Handler factories are analyzed through the closure they return
+			if handlerInfo == nil {
+				if handler := factoryHandler(funcDecl); handler != nil {
+					if handlerInfo = a.analyzeHandlerFunction(handler); handlerInfo != nil {
+						a.factories[handlerKey(funcDecl)] = true
+					}
+				}
+			}
 			if handlerInfo != nil {
-				handlers[funcDecl.Name.Name] = *handlerInfo
+				handlers[handlerKey(funcDecl)] = *handlerInfo
 				// Debug output
 				if handlerInfo.RequestType != "" || handlerInfo.ResponseType != "" || len(handlerInfo.QueryParameters) > 0 {
 					fmt.Printf("[DEBUG] Handler '%s': Request=%s, Response=%s, QueryParams=%d\n", 
-						funcDecl.Name.Name, handlerInfo.RequestType, handlerInfo.ResponseType, len(handlerInfo.QueryParameters))
+						handlerKey(funcDecl), handlerInfo.RequestType, handlerInfo.ResponseType, len(handlerInfo.QueryParameters))
 				}
 			}
 		}
@@ -0,0 +1,130 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// expandPathPatterns rewrites path segments OpenAPI can't express. A route
+// with optional parameters, /users/:id?, becomes one route per optional
+// parameter in use: /users and /users/:id. Unnamed wildcards, /files/* and
+// /files/+, become catch-all parameters named path, path2, ...
+func (a *Analyzer) expandPathPatterns(routes []Route) []Route {
+	expanded := make([]Route, 0, len(routes))
+	for _, route := range routes {
+		variants := expandRoute(route)
+		if len(variants) > 1 {
+			fmt.Printf("[DEBUG] Split optional parameters of %s %s into %d routes\n", route.Method, route.Path, len(variants))
+		}
+		expanded = append(expanded, variants...)
+	}
+	return expanded
+}
+
+func expandRoute(route Route) []Route {
+	segments := strings.Split(route.Path, "/")
+	params := append([]Parameter(nil), route.Parameters...)
+
+	wildcards := 0
+	var optional []int // segments holding optional parameters
+	for i, segment := range segments {
+		switch {
+		case segment == "*" || segment == "+":
+			wildcards++
+			name := "path"
+			for n := 2; hasPathParameter(params, name); n++ {
+				name = fmt.Sprintf("path%d", n)
+			}
+			description := "Rest of the path, may be empty and may contain slashes"
+			if segment == "+" {
+				description = "Rest of the path, not empty and may contain slashes"
+			}
+			segments[i] = ":" + name
+			params = append(params, Parameter{
+				Name:        name,
+				In:          "path",
+				Required:    true,
+				Type:        "string",
+				Description: description,
+				Wildcard:    true,
+			})
+		case strings.HasPrefix(segment, ":") && strings.HasSuffix(segment, "?"):
+			optional = append(optional, i)
+		}
+	}
+	if len(optional) == 0 {
+		route.Path = strings.Join(segments, "/")
+		route.Parameters = params
+		if wildcards > 0 {
+			sortPathParams(segments, route.Parameters)
+		}
+		return []Route{route}
+	}
+
+	// Optional parameters are used left to right: variant k keeps the
+	// first k of them
+	variants := make([]Route, 0, len(optional)+1)
+	for k := 0; k <= len(optional); k++ {
+		variant := route
+		dropped := make(map[string]bool)
+		var kept []string
+		for _, i := range optional[k:] {
+			dropped[optionalParamName(segments[i])] = true
+		}
+		for _, segment := range segments {
+			if strings.HasPrefix(segment, ":") && strings.HasSuffix(segment, "?") {
+				if dropped[optionalParamName(segment)] {
+					continue
+				}
+				segment = strings.TrimSuffix(segment, "?")
+			}
+			kept = append(kept, segment)
+		}
+		variant.Path = strings.Join(kept, "/")
+		if variant.Path == "" {
+			variant.Path = "/"
+		}
+
+		variant.Parameters = nil
+		for _, param := range params {
+			if param.In == "path" && dropped[param.Name] {
+				continue
+			}
+			if param.In == "path" {
+				param.Required = true
+			}
+			variant.Parameters = append(variant.Parameters, param)
+		}
+		sortPathParams(kept, variant.Parameters)
+		// Route names belong to the route with every parameter
+		if k < len(optional) {
+			variant.Name = ""
+		}
+		variants = append(variants, variant)
+	}
+	return variants
+}
+
+// optionalParamName returns the parameter name of an optional segment, :id?
+func optionalParamName(segment string) string {
+	return strings.TrimSuffix(strings.TrimPrefix(segment, ":"), "?")
+}
+
+// sortPathParams orders path parameters as they appear in the path, ahead
+// of the other parameters
+func sortPathParams(segments []string, params []Parameter) {
+	position := make(map[string]int)
+	for i, segment := range segments {
+		position[strings.TrimSuffix(strings.TrimLeft(segment, ":*"), "?")] = i
+	}
+	paramPosition := func(param Parameter) int {
+		if i, ok := position[param.Name]; ok && param.In == "path" {
+			return i
+		}
+		return len(segments)
+	}
+	sort.SliceStable(params, func(i, j int) bool {
+		return paramPosition(params[i]) < paramPosition(params[j])
+	})
+}
@@ -0,0 +1,144 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+)
+
+// conversionTypes are the parameter types of the strconv functions handlers
+// convert path parameters with
+var conversionTypes = map[string]string{
+	"Atoi":       "integer",
+	"ParseInt":   "integer",
+	"ParseUint":  "integer",
+	"ParseFloat": "number",
+	"ParseBool":  "boolean",
+}
+
+// pathParamName returns the path parameter an expression reads: c.Params("id")
+// with Fiber, c.Param with Gin, Echo and Hertz, chi.URLParam(r, "id"),
+// mux.Vars(r)["id"], ps.ByName("id") with httprouter and r.PathValue("id")
+func (a *Analyzer) pathParamName(expr ast.Expr) (string, bool) {
+	switch e := ast.Unparen(expr).(type) {
+	case *ast.CallExpr:
+		selExpr, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok || len(e.Args) == 0 {
+			return "", false
+		}
+		ident, ok := selExpr.X.(*ast.Ident)
+		if !ok {
+			return "", false
+		}
+		switch {
+		case ident.Name == a.contextName && containsString(a.framework.pathMethods, selExpr.Sel.Name):
+			return a.stringValue(e.Args[0])
+		case ident.Name == "chi" && selExpr.Sel.Name == "URLParam" && len(e.Args) == 2:
+			return a.stringValue(e.Args[1])
+		case a.isRouterParamCall(e):
+			return a.stringValue(e.Args[0])
+		}
+	case *ast.IndexExpr:
+		// mux.Vars(r)["id"]
+		if call, ok := e.X.(*ast.CallExpr); ok {
+			if selExpr, ok := call.Fun.(*ast.SelectorExpr); ok && selExpr.Sel.Name == "Vars" {
+				return a.stringValue(e.Index)
+			}
+		}
+	}
+	return "", false
+}
+
+// typedPathParam returns the path parameter a typed getter reads, with its
+// type: c.ParamsInt("id") with Fiber v2 or fiber.Params[int](c, "id") with v3
+func (a *Analyzer) typedPathParam(callExpr *ast.CallExpr) (string, string, bool) {
+	switch fun := callExpr.Fun.(type) {
+	case *ast.SelectorExpr:
+		ident, ok := fun.X.(*ast.Ident)
+		paramType, typed := a.framework.typedPath[fun.Sel.Name]
+		if !ok || ident.Name != a.contextName || !typed || len(callExpr.Args) == 0 {
+			return "", "", false
+		}
+		name, ok := a.stringValue(callExpr.Args[0])
+		return name, paramType, ok
+	case *ast.IndexExpr:
+		selExpr, ok := fun.X.(*ast.SelectorExpr)
+		if a.framework.genericPath == "" || !ok || selExpr.Sel.Name != a.framework.genericPath || len(callExpr.Args) < 2 {
+			return "", "", false
+		}
+		if ident, ok := callExpr.Args[0].(*ast.Ident); !ok || ident.Name != a.contextName {
+			return "", "", false
+		}
+		name, ok := a.stringValue(callExpr.Args[1])
+		return name, a.mapFieldTypeToParamType(a.getTypeStringWithArrays(fun.Index)), ok
+	}
+	return "", "", false
+}
+
+// extractPathParameterTypes infers the types of the path parameters a
+// handler reads with typed getters or converts with strconv, directly or
+// through the variable it assigned the parameter to:
+//
+//	id, err := strconv.Atoi(c.Params("id"))
+func (a *Analyzer) extractPathParameterTypes(funcDecl *ast.FuncDecl, handlerInfo *HandlerInfo) {
+	variables := make(map[string]string) // variables assigned a path parameter, to the parameter
+	setType := func(name, paramType string) {
+		if handlerInfo.PathParameterTypes == nil {
+			handlerInfo.PathParameterTypes = make(map[string]string)
+		}
+		if _, exists := handlerInfo.PathParameterTypes[name]; !exists {
+			handlerInfo.PathParameterTypes[name] = paramType
+		}
+		if !containsString(handlerInfo.PathParameters, name) {
+			handlerInfo.PathParameters = append(handlerInfo.PathParameters, name)
+		}
+	}
+
+	ast.Inspect(funcDecl, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) == 1 && len(node.Rhs) == 1 {
+				if ident, ok := node.Lhs[0].(*ast.Ident); ok {
+					if name, ok := a.pathParamName(node.Rhs[0]); ok {
+						variables[ident.Name] = name
+					}
+				}
+			}
+		case *ast.CallExpr:
+			if name, paramType, ok := a.typedPathParam(node); ok {
+				setType(name, paramType)
+				break
+			}
+			paramType, ok := strconvConversion(node)
+			if !ok || len(node.Args) == 0 {
+				break
+			}
+			name, ok := a.pathParamName(node.Args[0])
+			if ident, isIdent := node.Args[0].(*ast.Ident); !ok && isIdent {
+				name, ok = variables[ident.Name], variables[ident.Name] != ""
+			}
+			if ok {
+				setType(name, paramType)
+			}
+		}
+		return true
+	})
+}
+
+// strconvConversion returns the parameter type a strconv call converts to;
+// 64-bit ParseInt and ParseUint give int64
+func strconvConversion(callExpr *ast.CallExpr) (string, bool) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return "", false
+	}
+	if pkg, ok := selExpr.X.(*ast.Ident); !ok || pkg.Name != "strconv" {
+		return "", false
+	}
+	paramType, ok := conversionTypes[selExpr.Sel.Name]
+	if paramType == "integer" && len(callExpr.Args) == 3 {
+		if bits, isLit := callExpr.Args[2].(*ast.BasicLit); isLit && bits.Kind == token.INT && bits.Value == "64" {
+			paramType = "int64"
+		}
+	}
+	return paramType, ok
+}
@@ -0,0 +1,173 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// routeBasePath returns the prefix of the routes registered by the route
+// package in dir: the configured prefix for the package, the prefix of the
+// router the project passes to its RegisterRoutes, or /<package> as a guess
+func (a *Analyzer) routeBasePath(dir, packageName string) string {
+	relDir := filepath.ToSlash(a.relativePath(dir))
+	for _, key := range []string{relDir, packageName} {
+		if prefix, exists := a.routePrefixes[key]; exists {
+			prefix = strings.TrimSuffix(prefix, "/")
+			fmt.Printf("[DEBUG] Routes of %s are mounted at '%s' (configured)\n", relDir, prefix)
+			return prefix
+		}
+	}
+
+	if a.mountPrefixes == nil {
+		a.mountPrefixes = a.collectMountPrefixes()
+	}
+	for importPath, prefix := range a.mountPrefixes {
+		if importPath == relDir || strings.HasSuffix(importPath, "/"+relDir) {
+			fmt.Printf("[DEBUG] Routes of %s are mounted at '%s'\n", relDir, prefix)
+			return prefix
+		}
+	}
+
+	return "/" + packageName
+}
+
+// collectMountPrefixes finds the calls of route packages' RegisterRoutes
+// in the project, like
+//
+//	api := app.Group("/api/v1")
+//	users.RegisterRoutes(api.Group("/users"))
+//
+// in main.go, and resolves the prefix of the router passed in. Prefixes are
+// keyed by the route package's import path.
+func (a *Analyzer) collectMountPrefixes() map[string]string {
+	prefixes := make(map[string]string)
+	routeConstants := a.constants
+	defer func() { a.constants = routeConstants }()
+
+	filepath.Walk(a.projectPath, func(filePath string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if filePath != a.projectPath && (exampleSkippedDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(filePath, ".go") || strings.HasSuffix(filePath, "_test.go") {
+			return nil
+		}
+
+		src, err := parser.ParseFile(token.NewFileSet(), filePath, nil, 0)
+		if err != nil {
+			return nil
+		}
+		imports := make(map[string]string)
+		for _, imp := range src.Imports {
+			importPath, err := strconv.Unquote(imp.Path.Value)
+			if err != nil {
+				continue
+			}
+			name := path.Base(importPath)
+			if imp.Name != nil {
+				name = imp.Name.Name
+			}
+			imports[name] = importPath
+		}
+
+		// Group paths may be the file's constants
+		a.constants = make(map[string]ast.Expr)
+		for _, decl := range src.Decls {
+			if genDecl, ok := decl.(*ast.GenDecl); ok {
+				recordConstants(genDecl, a.constants)
+			}
+		}
+
+		for _, decl := range src.Decls {
+			if funcDecl, ok := decl.(*ast.FuncDecl); ok && funcDecl.Body != nil {
+				a.recordMountPrefixes(funcDecl.Body, imports, prefixes, a.relativePath(filePath))
+			}
+		}
+		return nil
+	})
+
+	return prefixes
+}
+
+// recordMountPrefixes records the prefixes of the routers a function passes
+// to the RegisterRoutes of imported packages
+func (a *Analyzer) recordMountPrefixes(body *ast.BlockStmt, imports, prefixes map[string]string, sourceFile string) {
+	groups := make(map[string]string)
+	ast.Inspect(body, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			if len(node.Lhs) != 1 || len(node.Rhs) != 1 {
+				return true
+			}
+			if ident, ok := node.Lhs[0].(*ast.Ident); ok {
+				if prefix, ok := a.groupPrefix(node.Rhs[0], groups); ok {
+					groups[ident.Name] = prefix
+				}
+			}
+		case *ast.CallExpr:
+			selExpr, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok || selExpr.Sel.Name != "RegisterRoutes" || len(node.Args) == 0 {
+				return true
+			}
+			pkg, ok := selExpr.X.(*ast.Ident)
+			if !ok || imports[pkg.Name] == "" {
+				return true
+			}
+			prefix, ok := a.groupPrefix(node.Args[0], groups)
+			if !ok {
+				// The root router, or one we can't follow
+				if ident, isIdent := node.Args[0].(*ast.Ident); !isIdent || groups[ident.Name] == "" {
+					prefix = ""
+				}
+			}
+			importPath := imports[pkg.Name]
+			if existing, exists := prefixes[importPath]; exists && existing != prefix {
+				fmt.Printf("Warning: %s registers the routes of %s under both '%s' and '%s'; using '%s'\n", sourceFile, importPath, existing, prefix, existing)
+				return true
+			}
+			prefixes[importPath] = prefix
+		}
+		return true
+	})
+}
+
+// groupPrefix returns the prefix of a router expression: a variable holding
+// a group, or a Group/Party call on one, like api.Group("/users")
+func (a *Analyzer) groupPrefix(expr ast.Expr, groups map[string]string) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.Ident:
+		prefix, exists := groups[e.Name]
+		return prefix, exists
+	case *ast.CallExpr:
+		selExpr, ok := e.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return "", false
+		}
+		// Fiber: app.Group("/api").Name("api.")
+		if selExpr.Sel.Name == "Name" {
+			return a.groupPrefix(selExpr.X, groups)
+		}
+		if (selExpr.Sel.Name != "Group" && selExpr.Sel.Name != "Party") || len(e.Args) == 0 {
+			return "", false
+		}
+		groupPath, ok := a.stringValue(e.Args[0])
+		if !ok {
+			return "", false
+		}
+		parent, _ := a.groupPrefix(selExpr.X, groups)
+		return parent + strings.TrimSuffix(groupPath, "/"), true
+	}
+	return "", false
+}
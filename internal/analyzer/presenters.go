@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// collectPresenters indexes the package-level functions of the project that
+// return a model, like toDTO(user) or presenter.User(user), by import path
+// and function name. Handlers passing their result to c.JSON through one of
+// them are documented with the function's return type.
+func (a *Analyzer) collectPresenters() map[string]string {
+	presenters := make(map[string]string)
+
+	filepath.Walk(a.projectPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return nil
+		}
+		if info.IsDir() {
+			if path != a.projectPath && (exampleSkippedDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+
+		src, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return nil
+		}
+		for _, decl := range src.Decls {
+			funcDecl, ok := decl.(*ast.FuncDecl)
+			if !ok || funcDecl.Recv != nil || funcDecl.Type.Results == nil || len(funcDecl.Type.Results.List) == 0 {
+				continue
+			}
+			if typeName := a.presenterResultType(funcDecl.Type.Results.List[0].Type); typeName != "" {
+				presenters[a.presenterKey(filepath.Dir(path), funcDecl.Name.Name)] = typeName
+			}
+		}
+		return nil
+	})
+
+	fmt.Printf("[DEBUG] Found %d presenter functions\n", len(presenters))
+	return presenters
+}
+
+// presenterKey keys a presenter by the import path of the package in dir,
+// or the directory when the project has no go.mod
+func (a *Analyzer) presenterKey(dir, funcName string) string {
+	if pkg := a.goPackage(dir); pkg != "" {
+		return pkg + "." + funcName
+	}
+	return dir + "." + funcName
+}
+
+// presenterResultType returns the result type of a presenter if it is a
+// struct model or a pointer to one. Slices, maps, builtin types and named
+// types that aren't models, like error, don't name a response schema.
+func (a *Analyzer) presenterResultType(expr ast.Expr) string {
+	if star, ok := expr.(*ast.StarExpr); ok {
+		expr = star.X
+	}
+	var typeName string
+	switch t := expr.(type) {
+	case *ast.Ident:
+		typeName = t.Name
+	case *ast.SelectorExpr, *ast.IndexExpr, *ast.IndexListExpr:
+		typeName = a.getTypeStringWithArrays(t)
+	}
+	if _, isModel := a.models[a.cleanTypeName(typeName)]; !isModel {
+		return ""
+	}
+	return typeName
+}
+
+// presenterCallType returns the type returned by a presenter call, or ""
+// if the call isn't to a known presenter
+func (a *Analyzer) presenterCallType(callExpr *ast.CallExpr) string {
+	if a.presenters == nil {
+		a.presenters = a.collectPresenters()
+	}
+	switch fun := callExpr.Fun.(type) {
+	case *ast.Ident:
+		return a.presenters[a.presenterKey(a.handlerDir, fun.Name)]
+	case *ast.SelectorExpr:
+		if pkg, ok := fun.X.(*ast.Ident); ok {
+			if path, imported := a.handlerImports[pkg.Name]; imported {
+				return a.presenters[path+"."+fun.Sel.Name]
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,386 @@
+package analyzer
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// ProtoInt64 is the type of 64-bit integer proto fields, which the proto3
+// JSON mapping encodes as decimal strings, like "42"
+const ProtoInt64 = "protojson.Int64"
+
+// protoScalarTypes maps proto scalar types to the Go types the generator
+// understands
+var protoScalarTypes = map[string]string{
+	"double":   "float64",
+	"float":    "float32",
+	"int32":    "int32",
+	"sint32":   "int32",
+	"sfixed32": "int32",
+	"int64":    ProtoInt64,
+	"sint64":   ProtoInt64,
+	"sfixed64": ProtoInt64,
+	"uint32":   "uint32",
+	"fixed32":  "uint32",
+	"uint64":   ProtoInt64,
+	"fixed64":  ProtoInt64,
+	"bool":     "bool",
+	"string":   "string",
+	"bytes":    "string",
+}
+
+// protoWellKnownTypes maps google.protobuf types to their JSON representation
+var protoWellKnownTypes = map[string]string{
+	"google.protobuf.Timestamp":   "time.Time",
+	"google.protobuf.Duration":    "string",
+	"google.protobuf.FieldMask":   "string",
+	"google.protobuf.Struct":      "map[string]interface{}",
+	"google.protobuf.Any":         "map[string]interface{}",
+	"google.protobuf.Value":       "interface{}",
+	"google.protobuf.ListValue":   "[]interface{}",
+	"google.protobuf.StringValue": "string",
+	"google.protobuf.BytesValue":  "string",
+	"google.protobuf.BoolValue":   "bool",
+	"google.protobuf.DoubleValue": "float64",
+	"google.protobuf.FloatValue":  "float32",
+	"google.protobuf.Int32Value":  "int32",
+	"google.protobuf.Int64Value":  ProtoInt64,
+	"google.protobuf.UInt32Value": "uint32",
+	"google.protobuf.UInt64Value": ProtoInt64,
+}
+
+const protoEmpty = "google.protobuf.Empty"
+
+// protoSkippedDirs hold vendored protos (google/api/*.proto and the like)
+// rather than the project's own services
+var protoSkippedDirs = map[string]bool{
+	"vendor":       true,
+	"third_party":  true,
+	"node_modules": true,
+}
+
+// Path template variables: {name} or {name=shelves/*}
+var protoPathVariable = regexp.MustCompile(`\{([^}=]+)(=[^}]*)?\}`)
+
+// protoAPI indexes the messages and enums of all parsed proto files
+type protoAPI struct {
+	messages map[string]*protoMessage
+	enums    map[string]*protoEnum
+}
+
+// parseProtoFiles builds routes and models from the google.api.http
+// bindings of the services declared in the project's .proto files
+func (a *Analyzer) parseProtoFiles(analysis *Analysis) error {
+	api := &protoAPI{
+		messages: make(map[string]*protoMessage),
+		enums:    make(map[string]*protoEnum),
+	}
+
+	type serviceFile struct {
+		service    *protoService
+		sourceFile string
+	}
+	var services []serviceFile
+
+	err := filepath.Walk(a.projectPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != a.projectPath && (protoSkippedDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".proto") {
+			return nil
+		}
+
+		src, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		file, err := parseProto(string(src))
+		if err != nil {
+			return fmt.Errorf("failed to parse proto file %s: %w", path, err)
+		}
+
+		for _, message := range file.messages {
+			api.messages[message.name] = message
+			if file.pkg != "" {
+				api.messages[file.pkg+"."+message.name] = message
+			}
+		}
+		for _, enum := range file.enums {
+			api.enums[enum.name] = enum
+			if file.pkg != "" {
+				api.enums[file.pkg+"."+enum.name] = enum
+			}
+		}
+		for _, service := range file.services {
+			services = append(services, serviceFile{service: service, sourceFile: a.relativePath(path)})
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, sf := range services {
+		for _, rpc := range sf.service.rpcs {
+			for _, rule := range rpc.rules {
+				route := a.protoRoute(api, sf.service, rpc, rule, analysis)
+				if route == nil {
+					continue
+				}
+				route.SourceFile = sf.sourceFile
+				analysis.Routes = append(analysis.Routes, *route)
+			}
+		}
+	}
+
+	fmt.Printf("[DEBUG] Found %d proto services with %d HTTP bindings\n", len(services), len(analysis.Routes))
+	return nil
+}
+
+// protoRoute builds the route for one HTTP binding of an rpc
+func (a *Analyzer) protoRoute(api *protoAPI, service *protoService, rpc protoRPC, rule httpRule, analysis *Analysis) *Route {
+	if !a.isHTTPMethod(rule.method) || rule.path == "" {
+		fmt.Printf("[DEBUG] Skipping %s.%s: unsupported HTTP binding %s %s\n", service.name, rpc.name, rule.method, rule.path)
+		return nil
+	}
+
+	path := protoPathVariable.ReplaceAllString(rule.path, "{$1}")
+	if strings.Contains(path, ":") {
+		// The generator reads :name as a path parameter
+		fmt.Printf("[DEBUG] Skipping %s.%s: custom verbs are not supported (%s)\n", service.name, rpc.name, rule.path)
+		return nil
+	}
+
+	route := &Route{
+		Path:        path,
+		Method:      rule.method,
+		Handler:     rpc.name,
+		Tags:        []string{service.name},
+		Annotations: parseAnnotations(rpc.comment),
+	}
+
+	request := api.message(rpc.request)
+
+	// Path variables bind request fields, possibly nested (shelf.id)
+	bound := make(map[string]bool)
+	for _, match := range protoPathVariable.FindAllStringSubmatch(rule.path, -1) {
+		name := match[1]
+		bound[strings.SplitN(name, ".", 2)[0]] = true
+		param := Parameter{Name: name, In: "path", Required: true, Type: "string"}
+		if field, ok := api.field(request, name); ok {
+			param.Type = a.protoParamType(api, field)
+		}
+		route.Parameters = append(route.Parameters, param)
+	}
+
+	if request != nil {
+		switch rule.body {
+		case "*":
+			body := request
+			if len(bound) > 0 {
+				// Fields bound to the path aren't repeated in the body
+				body = &protoMessage{name: rpc.name + "Body", comment: request.comment}
+				for _, field := range request.fields {
+					if !bound[field.name] {
+						body.fields = append(body.fields, field)
+					}
+				}
+			}
+			model := a.protoModel(api, body, analysis)
+			route.RequestBody = &model
+		case "":
+			route.Parameters = append(route.Parameters, a.protoQueryParameters(api, request, bound)...)
+		default:
+			if field, ok := api.field(request, rule.body); ok {
+				if message := api.message(field.typ); message != nil && !field.repeated {
+					model := a.protoModel(api, message, analysis)
+					route.RequestBody = &model
+				}
+			}
+			bound[rule.body] = true
+			route.Parameters = append(route.Parameters, a.protoQueryParameters(api, request, bound)...)
+		}
+	}
+
+	response := api.message(rpc.response)
+	if rule.responseBody != "" && response != nil {
+		response = nil
+		if field, ok := api.field(api.message(rpc.response), rule.responseBody); ok && !field.repeated {
+			response = api.message(field.typ)
+		}
+	}
+	if response != nil {
+		model := a.protoModel(api, response, analysis)
+		route.Response = &model
+	}
+
+	return route
+}
+
+// protoQueryParameters maps the scalar request fields not bound to the path
+// or body to query parameters
+func (a *Analyzer) protoQueryParameters(api *protoAPI, request *protoMessage, bound map[string]bool) []Parameter {
+	var params []Parameter
+	for _, field := range request.fields {
+		if bound[field.name] || field.mapKey != "" || api.message(field.typ) != nil {
+			continue
+		}
+		param := Parameter{
+			Name:        protoJSONName(field),
+			In:          "query",
+			Type:        a.protoParamType(api, field),
+			Description: sanitizeDescription(field.comment),
+		}
+		if enum := api.enum(field.typ); enum != nil && !field.repeated {
+			param.Enum = enum.values
+			param.EnumName = enum.name
+		}
+		params = append(params, param)
+	}
+	return params
+}
+
+// protoModel adds the model for a message, and for every message it
+// references, to the analysis
+func (a *Analyzer) protoModel(api *protoAPI, message *protoMessage, analysis *Analysis) Model {
+	if model, exists := analysis.Models[message.name]; exists {
+		return model
+	}
+
+	model := Model{
+		Name:        message.name,
+		Description: sanitizeDescription(message.comment),
+		Fields:      []Field{},
+	}
+	// Registered before its fields so recursive messages terminate
+	analysis.Models[message.name] = model
+
+	for _, field := range message.fields {
+		fieldType := api.goType(field)
+		modelField := Field{
+			Name:         field.name,
+			Type:         fieldType,
+			OriginalType: fieldType,
+			// proto3 fields are always optional
+			JSONTag:     protoJSONName(field) + ",omitempty",
+			Description: sanitizeDescription(field.comment),
+		}
+		if enum := api.enum(field.typ); enum != nil && field.mapKey == "" {
+			modelField.Enum = enum.values
+			modelField.EnumName = enum.name
+		}
+		model.Fields = append(model.Fields, modelField)
+
+		if nested := api.message(field.typ); nested != nil {
+			a.protoModel(api, nested, analysis)
+		}
+	}
+
+	analysis.Models[message.name] = model
+	return model
+}
+
+// goType returns the Go type the generator maps to the field's JSON schema
+func (api *protoAPI) goType(field protoField) string {
+	typ := api.elementType(field.typ)
+	if field.mapKey != "" {
+		return "map[string]" + typ
+	}
+	if field.repeated {
+		return "[]" + typ
+	}
+	return typ
+}
+
+func (api *protoAPI) elementType(typ string) string {
+	typ = strings.TrimPrefix(typ, ".")
+	if goType, ok := protoScalarTypes[typ]; ok {
+		return goType
+	}
+	if goType, ok := protoWellKnownTypes[typ]; ok {
+		return goType
+	}
+	if api.enum(typ) != nil {
+		// Enums are serialized by value name
+		return "string"
+	}
+	if message := api.message(typ); message != nil {
+		return message.name
+	}
+	return "interface{}"
+}
+
+// message resolves a message type reference, fully qualified or not
+func (api *protoAPI) message(typ string) *protoMessage {
+	typ = strings.TrimPrefix(typ, ".")
+	if typ == protoEmpty {
+		return nil
+	}
+	if message, ok := api.messages[typ]; ok {
+		return message
+	}
+	return api.messages[typ[strings.LastIndex(typ, ".")+1:]]
+}
+
+func (api *protoAPI) enum(typ string) *protoEnum {
+	typ = strings.TrimPrefix(typ, ".")
+	if enum, ok := api.enums[typ]; ok {
+		return enum
+	}
+	return api.enums[typ[strings.LastIndex(typ, ".")+1:]]
+}
+
+// field resolves a possibly nested field path like shelf.id
+func (api *protoAPI) field(message *protoMessage, path string) (protoField, bool) {
+	parts := strings.Split(path, ".")
+	for i, part := range parts {
+		if message == nil {
+			return protoField{}, false
+		}
+		var found *protoField
+		for j := range message.fields {
+			if message.fields[j].name == part {
+				found = &message.fields[j]
+				break
+			}
+		}
+		if found == nil {
+			return protoField{}, false
+		}
+		if i == len(parts)-1 {
+			return *found, true
+		}
+		message = api.message(found.typ)
+	}
+	return protoField{}, false
+}
+
+// protoJSONName returns the field's json_name option, or else its proto
+// name, which grpc-gateway accepts and which matches the generator's
+// snake_case property names
+func protoJSONName(field protoField) string {
+	if field.jsonName != "" {
+		return field.jsonName
+	}
+	return field.name
+}
+
+// protoParamType maps a field to a parameter type, keeping 64-bit integers
+// apart so they get the int64 format
+func (a *Analyzer) protoParamType(api *protoAPI, field protoField) string {
+	switch goType := api.goType(field); goType {
+	case ProtoInt64:
+		return "int64"
+	default:
+		return a.mapFieldTypeToParamType(goType)
+	}
+}
@@ -0,0 +1,520 @@
+package analyzer
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// protoFile is the subset of a .proto file needed to describe its HTTP API
+type protoFile struct {
+	pkg      string
+	messages []*protoMessage
+	enums    []*protoEnum
+	services []*protoService
+}
+
+type protoMessage struct {
+	name    string
+	comment string
+	fields  []protoField
+}
+
+type protoField struct {
+	name     string
+	typ      string // scalar or message/enum type name as written
+	comment  string
+	jsonName string // [json_name = "..."] option
+	repeated bool
+	mapKey   string // set for map<key, typ> fields
+}
+
+type protoEnum struct {
+	name   string
+	values []string
+}
+
+type protoService struct {
+	name string
+	rpcs []protoRPC
+}
+
+type protoRPC struct {
+	name     string
+	request  string
+	response string
+	comment  string
+	rules    []httpRule
+}
+
+// httpRule is a google.api.http binding
+type httpRule struct {
+	method       string
+	path         string
+	body         string
+	responseBody string
+}
+
+// protoScanner splits proto source into tokens, remembering the comment
+// directly above the last token
+type protoScanner struct {
+	src     string
+	pos     int
+	line    int
+	comment string // leading comment of the token just returned
+	peeked  *string
+}
+
+func newProtoScanner(src string) *protoScanner {
+	return &protoScanner{src: src, line: 1}
+}
+
+func (s *protoScanner) peek() string {
+	if s.peeked == nil {
+		tok := s.scan()
+		s.peeked = &tok
+	}
+	return *s.peeked
+}
+
+func (s *protoScanner) next() string {
+	if s.peeked != nil {
+		tok := *s.peeked
+		s.peeked = nil
+		return tok
+	}
+	return s.scan()
+}
+
+// scan returns the next token, or "" at the end of the source
+func (s *protoScanner) scan() string {
+	var comments []string
+	tokenLine := s.line
+	for s.pos < len(s.src) {
+		c := s.src[s.pos]
+		switch {
+		case c == '\n':
+			s.line++
+			s.pos++
+		case unicode.IsSpace(rune(c)):
+			s.pos++
+		case strings.HasPrefix(s.src[s.pos:], "//"):
+			end := strings.IndexByte(s.src[s.pos:], '\n')
+			if end < 0 {
+				end = len(s.src) - s.pos
+			}
+			// Comments trailing a statement on the same line are dropped
+			if s.line != tokenLine || s.pos == 0 {
+				comments = append(comments, strings.TrimSpace(s.src[s.pos+2:s.pos+end]))
+			}
+			s.pos += end
+		case strings.HasPrefix(s.src[s.pos:], "/*"):
+			end := strings.Index(s.src[s.pos+2:], "*/")
+			if end < 0 {
+				end = len(s.src) - s.pos - 2
+			}
+			block := s.src[s.pos+2 : s.pos+2+end]
+			s.line += strings.Count(block, "\n")
+			s.pos += end + 4
+		default:
+			s.comment = strings.Join(comments, "\n")
+			return s.token()
+		}
+	}
+	s.comment = ""
+	return ""
+}
+
+func (s *protoScanner) token() string {
+	start := s.pos
+	c := s.src[s.pos]
+	switch {
+	case c == '"' || c == '\'':
+		s.pos++
+		for s.pos < len(s.src) && s.src[s.pos] != c {
+			if s.src[s.pos] == '\\' {
+				s.pos++
+			}
+			s.pos++
+		}
+		s.pos++
+		if s.pos > len(s.src) {
+			s.pos = len(s.src)
+		}
+	case isProtoIdentChar(c):
+		for s.pos < len(s.src) && isProtoIdentChar(s.src[s.pos]) {
+			s.pos++
+		}
+	default:
+		s.pos++
+	}
+	return s.src[start:s.pos]
+}
+
+func isProtoIdentChar(c byte) bool {
+	return c == '_' || c == '.' || c == '-' || c == '+' ||
+		(c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// parseProto parses the messages, enums and services of a .proto file
+func parseProto(src string) (*protoFile, error) {
+	s := newProtoScanner(src)
+	file := &protoFile{}
+
+	for {
+		tok := s.next()
+		switch tok {
+		case "":
+			return file, nil
+		case "package":
+			file.pkg = s.next()
+			s.skipStatement()
+		case "message":
+			if err := s.parseMessage(file, ""); err != nil {
+				return nil, err
+			}
+		case "enum":
+			if err := s.parseEnum(file); err != nil {
+				return nil, err
+			}
+		case "service":
+			service, err := s.parseService()
+			if err != nil {
+				return nil, err
+			}
+			file.services = append(file.services, service)
+		case "extend":
+			s.next()
+			s.skipBlock()
+		case ";":
+		default:
+			// syntax, import, option
+			s.skipStatement()
+		}
+	}
+}
+
+func (s *protoScanner) expect(want string) error {
+	if tok := s.next(); tok != want {
+		return fmt.Errorf("line %d: expected %q, found %q", s.line, want, tok)
+	}
+	return nil
+}
+
+// skipStatement skips to the end of the current statement, including any
+// braced option values
+func (s *protoScanner) skipStatement() {
+	s.statement()
+}
+
+// statement returns the tokens up to the end of the current statement
+func (s *protoScanner) statement() []string {
+	var tokens []string
+	depth := 0
+	for {
+		switch tok := s.peek(); tok {
+		case "", "}":
+			if depth == 0 {
+				// Unterminated statement; leave the enclosing brace alone
+				return tokens
+			}
+			depth--
+		case "{":
+			depth++
+		case ";":
+			if depth == 0 {
+				s.next()
+				return tokens
+			}
+		}
+		tokens = append(tokens, s.next())
+	}
+}
+
+// skipBlock skips a braced block whose opening brace is the next token
+func (s *protoScanner) skipBlock() {
+	if s.next() != "{" {
+		return
+	}
+	for depth := 1; depth > 0; {
+		switch s.next() {
+		case "":
+			return
+		case "{":
+			depth++
+		case "}":
+			depth--
+		}
+	}
+}
+
+// parseMessage parses a message body. Nested messages are registered under
+// their own name.
+func (s *protoScanner) parseMessage(file *protoFile, comment string) error {
+	if comment == "" {
+		comment = s.comment
+	}
+	message := &protoMessage{name: s.next(), comment: comment}
+	if err := s.expect("{"); err != nil {
+		return err
+	}
+
+	for {
+		tok := s.next()
+		fieldComment := s.comment
+		switch tok {
+		case "":
+			return fmt.Errorf("message %s: unexpected end of file", message.name)
+		case "}":
+			file.messages = append(file.messages, message)
+			return nil
+		case ";":
+		case "message":
+			if err := s.parseMessage(file, fieldComment); err != nil {
+				return err
+			}
+		case "enum":
+			if err := s.parseEnum(file); err != nil {
+				return err
+			}
+		case "oneof":
+			s.next()
+			if err := s.expect("{"); err != nil {
+				return err
+			}
+			for s.peek() != "}" && s.peek() != "" {
+				tok := s.next()
+				if tok == "option" {
+					s.skipStatement()
+					continue
+				}
+				message.fields = append(message.fields, s.parseField(tok, s.comment))
+			}
+			s.next()
+		case "option", "reserved", "extensions":
+			s.skipStatement()
+		case "extend":
+			s.next()
+			s.skipBlock()
+		case "map":
+			field := protoField{comment: fieldComment}
+			s.expect("<")
+			field.mapKey = s.next()
+			s.expect(",")
+			field.typ = s.next()
+			s.expect(">")
+			field.name = s.next()
+			field.jsonName = jsonNameOption(s.statement())
+			message.fields = append(message.fields, field)
+		case "repeated":
+			field := s.parseField(s.next(), fieldComment)
+			field.repeated = true
+			message.fields = append(message.fields, field)
+		case "optional", "required":
+			message.fields = append(message.fields, s.parseField(s.next(), fieldComment))
+		default:
+			message.fields = append(message.fields, s.parseField(tok, fieldComment))
+		}
+	}
+}
+
+// parseField parses "name = number [options];" after the field's type
+func (s *protoScanner) parseField(typ, comment string) protoField {
+	field := protoField{typ: typ, name: s.next(), comment: comment}
+	field.jsonName = jsonNameOption(s.statement())
+	return field
+}
+
+// jsonNameOption returns the json_name option among a field's tokens
+func jsonNameOption(tokens []string) string {
+	for i := 0; i+2 < len(tokens); i++ {
+		if tokens[i] == "json_name" && tokens[i+1] == "=" {
+			return unquoteProto(tokens[i+2])
+		}
+	}
+	return ""
+}
+
+func (s *protoScanner) parseEnum(file *protoFile) error {
+	enum := &protoEnum{name: s.next()}
+	if err := s.expect("{"); err != nil {
+		return err
+	}
+	for {
+		tok := s.next()
+		switch tok {
+		case "":
+			return fmt.Errorf("enum %s: unexpected end of file", enum.name)
+		case "}":
+			file.enums = append(file.enums, enum)
+			return nil
+		case ";":
+		case "option", "reserved":
+			s.skipStatement()
+		default:
+			enum.values = append(enum.values, tok)
+			s.skipStatement()
+		}
+	}
+}
+
+func (s *protoScanner) parseService() (*protoService, error) {
+	service := &protoService{name: s.next()}
+	if err := s.expect("{"); err != nil {
+		return nil, err
+	}
+
+	for {
+		tok := s.next()
+		switch tok {
+		case "":
+			return nil, fmt.Errorf("service %s: unexpected end of file", service.name)
+		case "}":
+			return service, nil
+		case ";":
+		case "rpc":
+			rpc, err := s.parseRPC()
+			if err != nil {
+				return nil, fmt.Errorf("service %s: %w", service.name, err)
+			}
+			service.rpcs = append(service.rpcs, rpc)
+		default:
+			s.skipStatement()
+		}
+	}
+}
+
+// parseRPC parses "Name(Request) returns (Response) { options }"
+func (s *protoScanner) parseRPC() (protoRPC, error) {
+	comment := s.comment
+	rpc := protoRPC{comment: comment, name: s.next()}
+
+	messageType := func() (string, error) {
+		if err := s.expect("("); err != nil {
+			return "", err
+		}
+		typ := s.next()
+		if typ == "stream" {
+			typ = s.next()
+		}
+		return typ, s.expect(")")
+	}
+
+	var err error
+	if rpc.request, err = messageType(); err != nil {
+		return rpc, err
+	}
+	if err := s.expect("returns"); err != nil {
+		return rpc, err
+	}
+	if rpc.response, err = messageType(); err != nil {
+		return rpc, err
+	}
+
+	switch s.next() {
+	case ";":
+		return rpc, nil
+	case "{":
+	default:
+		return rpc, fmt.Errorf("line %d: malformed rpc %s", s.line, rpc.name)
+	}
+
+	for {
+		tok := s.next()
+		switch tok {
+		case "":
+			return rpc, fmt.Errorf("rpc %s: unexpected end of file", rpc.name)
+		case "}":
+			if s.peek() == ";" {
+				s.next()
+			}
+			return rpc, nil
+		case "option":
+			if s.peek() != "(" {
+				s.skipStatement()
+				continue
+			}
+			s.next()
+			name := s.next()
+			s.expect(")")
+			if name != "google.api.http" {
+				s.skipStatement()
+				continue
+			}
+			s.expect("=")
+			if err := s.expect("{"); err != nil {
+				return rpc, err
+			}
+			rules, err := s.parseHTTPRule()
+			if err != nil {
+				return rpc, fmt.Errorf("rpc %s: %w", rpc.name, err)
+			}
+			rpc.rules = append(rpc.rules, rules...)
+			if s.peek() == ";" {
+				s.next()
+			}
+		}
+	}
+}
+
+// parseHTTPRule parses the body of a google.api.http option up to its
+// closing brace, returning the rule followed by its additional bindings
+func (s *protoScanner) parseHTTPRule() ([]httpRule, error) {
+	var rule httpRule
+	var additional []httpRule
+
+	for {
+		key := s.next()
+		switch key {
+		case "":
+			return nil, fmt.Errorf("unterminated google.api.http option")
+		case "}":
+			return append([]httpRule{rule}, additional...), nil
+		case ",", ";":
+			continue
+		}
+
+		if s.peek() == ":" {
+			s.next()
+		}
+
+		switch key {
+		case "get", "put", "post", "delete", "patch":
+			rule.method = strings.ToUpper(key)
+			rule.path = unquoteProto(s.next())
+		case "body":
+			rule.body = unquoteProto(s.next())
+		case "response_body":
+			rule.responseBody = unquoteProto(s.next())
+		case "custom":
+			// custom: { kind: "HEAD" path: "/v1/..." }
+			s.expect("{")
+			for tok := s.next(); tok != "}" && tok != ""; tok = s.next() {
+				if s.peek() == ":" {
+					s.next()
+				}
+				switch tok {
+				case "kind":
+					rule.method = strings.ToUpper(unquoteProto(s.next()))
+				case "path":
+					rule.path = unquoteProto(s.next())
+				}
+			}
+		case "additional_bindings":
+			if err := s.expect("{"); err != nil {
+				return nil, err
+			}
+			rules, err := s.parseHTTPRule()
+			if err != nil {
+				return nil, err
+			}
+			additional = append(additional, rules...)
+		default:
+			s.next()
+		}
+	}
+}
+
+func unquoteProto(tok string) string {
+	return strings.Trim(tok, `"'`)
+}
@@ -0,0 +1,100 @@
+package analyzer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// How a handler reads a query parameter, from the most to the least
+// authoritative: a field of a struct bound with QueryParser declares its type
+// and documentation, a typed getter like c.QueryInt states its type, and a
+// plain c.Query call only reads a string
+const (
+	querySourceStruct = "struct"
+	querySourceGetter = "getter"
+	querySourceCall   = "call"
+)
+
+var querySourcePriority = map[string]int{
+	querySourceStruct: 0,
+	querySourceGetter: 1,
+	querySourceCall:   2,
+}
+
+// mergeQueryParameters merges the query parameters a handler reads more than
+// once, e.g. with c.Query("limit") and through a QueryParser struct with a
+// limit field, into one parameter each, in the order they are first read.
+// Attributes come from the most authoritative read that has them, except
+// the default: one passed in a getter call wins over the one guessed for a
+// struct field. Type conflicts are warned about.
+func mergeQueryParameters(handlerName string, params []QueryParameter) []QueryParameter {
+	var names []string
+	reads := make(map[string][]QueryParameter)
+	for _, param := range params {
+		if _, exists := reads[param.Name]; !exists {
+			names = append(names, param.Name)
+		}
+		reads[param.Name] = append(reads[param.Name], param)
+	}
+
+	merged := make([]QueryParameter, 0, len(names))
+	for _, name := range names {
+		group := reads[name]
+		if len(group) == 1 {
+			merged = append(merged, group[0])
+			continue
+		}
+		sort.SliceStable(group, func(i, j int) bool {
+			return querySourcePriority[group[i].Source] < querySourcePriority[group[j].Source]
+		})
+		merged = append(merged, mergeQueryReads(handlerName, group))
+	}
+	return merged
+}
+
+// mergeQueryReads merges the reads of one query parameter, sorted from the
+// most to the least authoritative
+func mergeQueryReads(handlerName string, group []QueryParameter) QueryParameter {
+	param := group[0]
+	param.Type = ""
+	defaultSource := param.Source
+	var types []string
+	for _, read := range group {
+		param.Required = param.Required || read.Required
+		if param.Description == "" {
+			param.Description = read.Description
+		}
+		if len(param.Enum) == 0 {
+			param.Enum, param.EnumName = read.Enum, read.EnumName
+		}
+		if param.GoType == "" {
+			param.GoType = read.GoType
+		}
+		if read.Default != nil && (param.Default == nil || defaultSource == querySourceStruct && read.Source != querySourceStruct) {
+			param.Default, defaultSource = read.Default, read.Source
+		}
+		// c.Query reads every parameter as a string, which says nothing
+		// about the type the handler parses it into
+		if read.Type == "" || read.Type == "string" && read.Source == querySourceCall {
+			continue
+		}
+		if param.Type == "" {
+			param.Type = read.Type
+		}
+		if !containsString(types, read.Type) {
+			types = append(types, read.Type)
+		}
+	}
+	if param.Type == "" {
+		param.Type = group[0].Type
+	}
+
+	if len(types) > 1 {
+		fmt.Printf("Warning: handler '%s' reads query parameter '%s' as %s; documenting it as %s\n",
+			handlerName, param.Name, strings.Join(types, " and "), param.Type)
+	} else {
+		fmt.Printf("[DEBUG] Merged %d reads of query parameter '%s' of handler '%s'\n", len(group), param.Name, handlerName)
+	}
+	return param
+}
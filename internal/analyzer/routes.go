@@ -3,7 +3,10 @@ import (
 	"fmt"
 	"go/ast"
 	"go/parser"
+	"go/token"
 	"path/filepath"
+	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -17,6 +20,9 @@ func (a *Analyzer) parseRoutes(analysis *Analysis) error {
 	anonymousModels := make(map[string]Model)
 
 	for _, routeFile := range routeFiles {
+		if err := a.canceled(); err != nil {
+			return err
+		}
 		if err := a.parseRouteFile(routeFile, analysis, anonymousModels); err != nil {
 			return fmt.Errorf("failed to parse route file %s: %w", routeFile, err)
 		}
@@ -36,11 +42,20 @@ func (a *Analyzer) parseRouteFile(filePath string, analysis *Analysis, anonymous
 	
 	src, err := parser.ParseFile(a.fileSet, filePath, nil, 0)
 	if err != nil {
-		return err
+		return a.skipFile(filePath, err)
 	}
 
 	// Extract package name for route grouping
 	packageName := src.Name.Name
+	a.routeImports = fileImports(src)
+
+	// Fiber v3 takes the handler before any middleware
+	a.fiberV3 = false
+	for _, imp := range src.Imports {
+		if strings.Trim(imp.Path.Value, `"`) == fiberV3Import {
+			a.fiberV3 = true
+		}
+	}
 
 	// Find handler files in the same directory
 	handlerDir := filepath.Dir(filePath)
@@ -48,6 +63,9 @@ func (a *Analyzer) parseRouteFile(filePath string, analysis *Analysis, anonymous
 	if err != nil {
 		return err
 	}
+	a.methodKeys = indexHandlerMethods(handlers)
+	a.parseImportedHandlers(src, handlerDir, handlers)
+	a.closures = make(map[string]*ast.FuncLit)
 	
 
 	// Collect anonymous models from handlers
@@ -57,208 +75,1002 @@ func (a *Analyzer) parseRouteFile(filePath string, analysis *Analysis, anonymous
 		}
 	}
 
-	ast.Inspect(src, func(n ast.Node) bool {
-		switch node := n.(type) {
+	// Collect the package's functions so mounted sub-routers and sub-apps
+	// set up in other files can be followed, and its route tables
+	pkg := &routePackage{
+		funcs:   make(map[string]*ast.FuncDecl),
+		tables:  make(map[string]*ast.CompositeLit),
+		structs: make(map[string]*ast.StructType),
+		consts:  make(map[string]ast.Expr),
+	}
+	siblings, _ := filepath.Glob(filepath.Join(handlerDir, "*.go"))
+	for _, sibling := range siblings {
+		if sibling == filePath || strings.HasSuffix(sibling, "_test.go") {
+			continue
+		}
+		if siblingSrc, err := parser.ParseFile(a.fileSet, sibling, nil, 0); err == nil && siblingSrc.Name.Name == packageName {
+			pkg.collect(siblingSrc)
+		}
+	}
+	pkg.collect(src)
+	a.constants = pkg.consts
+
+	if funcDecl, exists := pkg.funcs["RegisterRoutes"]; exists {
+		a.parseRegisterRoutesFunction(funcDecl, packageName, a.routeBasePath(handlerDir, packageName), a.relativePath(filePath), handlers, analysis, pkg)
+	}
+
+	return nil
+}
+
+// routePackage holds the package-level declarations of a route package
+type routePackage struct {
+	funcs   map[string]*ast.FuncDecl
+	tables  map[string]*ast.CompositeLit // slice and array literals, candidate route tables
+	structs map[string]*ast.StructType
+	consts  map[string]ast.Expr // constant values, for paths built from constants
+}
+
+func (pkg *routePackage) collect(file *ast.File) {
+	for _, decl := range file.Decls {
+		switch decl := decl.(type) {
 		case *ast.FuncDecl:
-			if node.Name.Name == "RegisterRoutes" {
-				a.parseRegisterRoutesFunction(node, packageName, handlers, analysis)
+			if decl.Recv == nil {
+				pkg.funcs[decl.Name.Name] = decl
+			}
+		case *ast.GenDecl:
+			recordConstants(decl, pkg.consts)
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					if structType, ok := spec.Type.(*ast.StructType); ok {
+						pkg.structs[spec.Name.Name] = structType
+					}
+				case *ast.ValueSpec:
+					for i, name := range spec.Names {
+						if i < len(spec.Values) {
+							recordRouteTable(name.Name, spec.Values[i], pkg.tables)
+						}
+					}
+				}
 			}
 		}
-		return true
-	})
+	}
+}
 
-	return nil
+// routeWalk holds the state shared while walking a RegisterRoutes function
+// and the sub-routers it nests or mounts
+type routeWalk struct {
+	packageName string
+	sourceFile  string
+	handlers    map[string]HandlerInfo
+	analysis    *Analysis
+	routeGroups map[string]RouteGroup
+	funcs       map[string]*ast.FuncDecl
+	tables      map[string]*ast.CompositeLit // route tables by variable, package-level and local
+	structs     map[string]*ast.StructType
+	mounted     map[string]bool        // functions currently being walked, guards against mount cycles
+	chained     map[*ast.CallExpr]bool // HandleFunc calls already matched to a .Methods() chain
+	firstRoute  int                    // index of the first route registered by this walk
 }
 
-func (a *Analyzer) parseRegisterRoutesFunction(funcDecl *ast.FuncDecl, packageName string, handlers map[string]HandlerInfo, analysis *Analysis) {
-	basePath := "/" + packageName
+func (a *Analyzer) parseRegisterRoutesFunction(funcDecl *ast.FuncDecl, packageName, basePath, sourceFile string, handlers map[string]HandlerInfo, analysis *Analysis, pkg *routePackage) {
+	walk := &routeWalk{
+		packageName: packageName,
+		sourceFile:  sourceFile,
+		handlers:    handlers,
+		analysis:    analysis,
+		// Track route groups (like v1, v2)
+		routeGroups: make(map[string]RouteGroup),
+		funcs:       pkg.funcs,
+		tables:      pkg.tables,
+		structs:     pkg.structs,
+		mounted:     map[string]bool{funcDecl.Name.Name: true},
+		chained:     make(map[*ast.CallExpr]bool),
+		firstRoute:  len(analysis.Routes),
+	}
 
-	// Track route groups (like v1, v2)
-	routeGroups := make(map[string]RouteGroup)
+	a.handlerVars = make(map[string]string)
+	a.recordHandlerParams(funcDecl)
 
-	ast.Inspect(funcDecl, func(n ast.Node) bool {
+	a.walkRoutes(funcDecl.Body, basePath, walk)
+}
+
+// walkRoutes collects the routes registered under node, with basePath as the
+// prefix for every route found
+func (a *Analyzer) walkRoutes(node ast.Node, basePath string, walk *routeWalk) {
+	// Local constants may hold paths, and are usually declared before use
+	ast.Inspect(node, func(n ast.Node) bool {
+		if decl, ok := n.(*ast.GenDecl); ok {
+			recordConstants(decl, a.constants)
+		}
+		return true
+	})
+	a.collectMounts(node, walk)
+
+	ast.Inspect(node, func(n ast.Node) bool {
 		switch node := n.(type) {
 		case *ast.AssignStmt:
-			// Look for route group assignments like: v1 := router.Group("/v1")
+			a.recordRouteGroup(node, walk)
 			if len(node.Lhs) == 1 && len(node.Rhs) == 1 {
 				if ident, ok := node.Lhs[0].(*ast.Ident); ok {
-					if callExpr, ok := node.Rhs[0].(*ast.CallExpr); ok {
-						if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-							if selExpr.Sel.Name == "Group" && len(callExpr.Args) > 0 {
-								if basicLit, ok := callExpr.Args[0].(*ast.BasicLit); ok {
-									groupPath := strings.Trim(basicLit.Value, `"`)
-									routeGroups[ident.Name] = RouteGroup{
-										Variable: ident.Name,
-										BasePath: groupPath,
-									}
-								}
-							}
-						}
-					}
+					recordRouteTable(ident.Name, node.Rhs[0], walk.tables)
+					a.recordHandlerVar(ident.Name, node.Rhs[0], walk.funcs)
+				}
+			}
+		case *ast.ValueSpec:
+			for i, name := range node.Names {
+				if i < len(node.Values) {
+					recordRouteTable(name.Name, node.Values[i], walk.tables)
+					a.recordHandlerVar(name.Name, node.Values[i], walk.funcs)
+				} else if node.Type != nil {
+					// var h UserHandler
+					a.handlerVars[name.Name] = a.cleanTypeName(a.extractTypeFromExpr(node.Type))
 				}
 			}
+		case *ast.RangeStmt:
+			// Routes registered in a loop over a static table
+			if a.walkRouteTable(node, basePath, walk) {
+				return false
+			}
 		case *ast.CallExpr:
+			// Sub-routers are walked with their own prefix
+			if a.walkSubRouter(node, basePath, walk) {
+				return false
+			}
+			// So are helpers that register routes on a group or sub-app
+			if a.walkRouterHelper(node, basePath, walk) {
+				return false
+			}
+			// Named routes are walked before their name is applied
+			if a.nameRoute(node, basePath, walk) {
+				return false
+			}
+
+			if a.parseAdapterRouteCall(node, basePath, walk) {
+				return true
+			}
+			if a.parseStaticRouteCall(node, basePath, walk) {
+				return true
+			}
+			if a.framework.patternRoutes {
+				a.parsePatternRouteCall(node, basePath, walk)
+				return true
+			}
+			if a.framework.methodsChain {
+				a.parseMuxRouteCall(node, basePath, walk)
+				return true
+			}
+			if a.parseMethodRouteCall(node, basePath, walk) {
+				return true
+			}
+			if a.parseAllRouteCall(node, basePath, walk) {
+				return true
+			}
+			if a.parseRegisterRouteCall(node, basePath, walk) {
+				return true
+			}
+
 			// Parse route calls
-			route := a.parseRouteCall(node, basePath, packageName, handlers, analysis, routeGroups)
+			route := a.parseRouteCall(node, basePath, walk.packageName, walk.handlers, walk.analysis, walk.routeGroups)
 			if route != nil {
-				analysis.Routes = append(analysis.Routes, *route)
+				route.SourceFile = walk.sourceFile
+				walk.analysis.Routes = append(walk.analysis.Routes, *route)
 			}
 		}
 		return true
 	})
 }
 
-func (a *Analyzer) parseRouteCall(callExpr *ast.CallExpr, basePath, packageName string, handlers map[string]HandlerInfo, analysis *Analysis, routeGroups map[string]RouteGroup) *Route {
-	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-		method := strings.ToUpper(selExpr.Sel.Name)
+// recordRouteGroup looks for route group assignments like
+// v1 := router.Group("/v1")
+func (a *Analyzer) recordRouteGroup(assign *ast.AssignStmt, walk *routeWalk) {
+	if len(assign.Lhs) != 1 || len(assign.Rhs) != 1 {
+		return
+	}
+	ident, ok := assign.Lhs[0].(*ast.Ident)
+	if !ok {
+		return
+	}
+	callExpr, ok := assign.Rhs[0].(*ast.CallExpr)
+	if !ok {
+		return
+	}
+	// Fiber: api := app.Group("/api").Name("api.")
+	if name, ok := callExpr.Fun.(*ast.SelectorExpr); ok && name.Sel.Name == "Name" {
+		if inner, ok := name.X.(*ast.CallExpr); ok {
+			callExpr = inner
+		}
+	}
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+	// Iris calls its groups parties: v1 := app.Party("/v1")
+	if (selExpr.Sel.Name == "Group" || selExpr.Sel.Name == "Party") && len(callExpr.Args) > 0 {
+		if groupPath, ok := a.stringValue(callExpr.Args[0]); ok {
+			// Groups of groups compose: admin := v1.Group("/admin")
+			walk.routeGroups[ident.Name] = RouteGroup{
+				Variable: ident.Name,
+				BasePath: a.routerPrefix(selExpr.X, walk.routeGroups) + groupPath,
+			}
+		}
+	}
+	// gorilla/mux: api := r.PathPrefix("/api").Subrouter()
+	if routeGroup, ok := a.muxSubrouter(ident.Name, callExpr, walk.routeGroups); ok {
+		walk.routeGroups[ident.Name] = routeGroup
+	}
+}
 
-		// Skip if not an HTTP method
-		if !a.isHTTPMethod(method) {
-			return nil
+// collectMounts registers Fiber sub-apps mounted with app.Mount("/api", api)
+// (Fiber v3: app.Use("/api", api)) as route groups before node is walked,
+// since routes are usually added to a sub-app before it is mounted
+func (a *Analyzer) collectMounts(node ast.Node, walk *routeWalk) {
+	subApps := make(map[string]bool)
+	ast.Inspect(node, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.AssignStmt:
+			a.recordRouteGroup(node, walk)
+			// api := fiber.New()
+			if len(node.Lhs) == 1 && len(node.Rhs) == 1 {
+				if ident, ok := node.Lhs[0].(*ast.Ident); ok && isFiberNewCall(node.Rhs[0]) {
+					subApps[ident.Name] = true
+				}
+			}
+		case *ast.CallExpr:
+			selExpr, ok := node.Fun.(*ast.SelectorExpr)
+			if !ok || len(node.Args) != 2 {
+				return true
+			}
+			subApp, ok := node.Args[1].(*ast.Ident)
+			if !ok {
+				return true
+			}
+			// Use also takes a prefix and middleware, so only known sub-apps count
+			if selExpr.Sel.Name != "Mount" && !(selExpr.Sel.Name == "Use" && subApps[subApp.Name]) {
+				return true
+			}
+			if _, ok := a.stringValue(node.Args[0]); !ok {
+				return true
+			}
+			walk.routeGroups[subApp.Name] = RouteGroup{
+				Variable: subApp.Name,
+				BasePath: a.routeFullPath(selExpr.X, "", a.routeCallPath(node), walk.routeGroups),
+			}
 		}
+		return true
+	})
+}
 
-		if len(callExpr.Args) < 2 {
-			return nil
+func isFiberNewCall(expr ast.Expr) bool {
+	callExpr, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "New" {
+		return false
+	}
+	ident, ok := selExpr.X.(*ast.Ident)
+	return ok && ident.Name == "fiber"
+}
+
+// walkRouterHelper follows calls like registerUserRoutes(api) to functions
+// of the package that register routes on a group or mounted sub-app passed
+// to them, prefixing those routes with the group's path. It reports whether
+// the call was one.
+func (a *Analyzer) walkRouterHelper(callExpr *ast.CallExpr, basePath string, walk *routeWalk) bool {
+	ident, ok := callExpr.Fun.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	funcDecl, exists := walk.funcs[ident.Name]
+	if !exists || walk.mounted[ident.Name] {
+		return false
+	}
+	for _, arg := range callExpr.Args {
+		isGroup := false
+		switch argExpr := arg.(type) {
+		case *ast.Ident:
+			_, isGroup = walk.routeGroups[argExpr.Name]
+		case *ast.CallExpr:
+			// A group created for the helper: registerAdmin(v1.Group("/admin"))
+			isGroup = a.routerPrefix(argExpr, walk.routeGroups) != ""
+		}
+		if isGroup {
+			walk.mounted[ident.Name] = true
+			a.walkRoutes(funcDecl.Body, basePath+a.routerPrefix(arg, walk.routeGroups), walk)
+			delete(walk.mounted, ident.Name)
+			return true
 		}
+	}
+	return false
+}
+
+// nameRoute applies route names given with app.Get("/users", h).Name("listUsers"),
+// walking the named route call first, and with app.Name("listUsers") after
+// the route is registered. It reports whether the call was a chained name.
+func (a *Analyzer) nameRoute(callExpr *ast.CallExpr, basePath string, walk *routeWalk) bool {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "Name" || len(callExpr.Args) != 1 {
+		return false
+	}
+	basicLit, ok := callExpr.Args[0].(*ast.BasicLit)
+	if !ok || basicLit.Kind != token.STRING {
+		return false
+	}
+	name, err := strconv.Unquote(basicLit.Value)
+	if err != nil || name == "" {
+		return false
+	}
 
-		// Extract path
-		var path string
-		if basicLit, ok := callExpr.Args[0].(*ast.BasicLit); ok {
-			path = strings.Trim(basicLit.Value, `"`)
+	routes := &walk.analysis.Routes
+	if inner, ok := selExpr.X.(*ast.CallExpr); ok {
+		before := len(*routes)
+		a.walkRoutes(inner, basePath, walk)
+		// Group names are prefixes, and a group registers no route
+		if len(*routes) == before+1 {
+			(*routes)[before].Name = name
 		}
+		return true
+	}
+
+	// app.Name names the route registered last
+	if len(*routes) > walk.firstRoute {
+		(*routes)[len(*routes)-1].Name = name
+	}
+	return false
+}
 
-		// Extract handler name
-		var handlerName string
-		lastArg := callExpr.Args[len(callExpr.Args)-1]
-		if ident, ok := lastArg.(*ast.Ident); ok {
-			handlerName = ident.Name
+// walkSubRouter follows chi-style nesting: r.Route("/prefix", func(r chi.Router) {...})
+// (Iris: app.PartyFunc("/prefix", func(p iris.Party) {...})),
+// r.Group(func(r chi.Router) {...}) and r.Mount("/prefix", subRouter()) where
+// subRouter is defined in the same file. It reports whether the call was a
+// sub-router.
+func (a *Analyzer) walkSubRouter(callExpr *ast.CallExpr, basePath string, walk *routeWalk) bool {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+
+	switch selExpr.Sel.Name {
+	case "Route", "PartyFunc":
+		if len(callExpr.Args) < 2 {
+			return false
+		}
+		funcLit, ok := callExpr.Args[1].(*ast.FuncLit)
+		if !ok {
+			return false
 		}
+		a.walkRoutes(funcLit.Body, basePath+a.routeCallPath(callExpr), walk)
+		return true
+	case "Group":
+		if len(callExpr.Args) != 1 {
+			return false
+		}
+		funcLit, ok := callExpr.Args[0].(*ast.FuncLit)
+		if !ok {
+			return false
+		}
+		a.walkRoutes(funcLit.Body, basePath, walk)
+		return true
+	case "Mount":
+		if len(callExpr.Args) != 2 {
+			return false
+		}
+		prefix := a.routeCallPath(callExpr)
+		// Sub-apps mounted by variable were registered as groups by collectMounts
+		if target, ok := callExpr.Args[1].(*ast.Ident); ok {
+			if _, exists := walk.routeGroups[target.Name]; exists {
+				return true
+			}
+		}
+		if target, ok := callExpr.Args[1].(*ast.CallExpr); ok {
+			if ident, ok := target.Fun.(*ast.Ident); ok {
+				if funcDecl, exists := walk.funcs[ident.Name]; exists && !walk.mounted[ident.Name] {
+					walk.mounted[ident.Name] = true
+					a.walkRoutes(funcDecl.Body, basePath+prefix, walk)
+					delete(walk.mounted, ident.Name)
+					return true
+				}
+			}
+		}
+		fmt.Printf("[DEBUG] Skipping mount at '%s%s': sub-router is not defined in %s\n", basePath, prefix, walk.sourceFile)
+		return true
+	}
+
+	return false
+}
+
+// muxSubrouter recognizes gorilla/mux sub-routers created with
+// router.PathPrefix("/prefix").Subrouter(). Prefixes compose when the parent
+// is itself a sub-router.
+func (a *Analyzer) muxSubrouter(variable string, callExpr *ast.CallExpr, routeGroups map[string]RouteGroup) (RouteGroup, bool) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "Subrouter" {
+		return RouteGroup{}, false
+	}
+	prefixCall, ok := selExpr.X.(*ast.CallExpr)
+	if !ok || len(prefixCall.Args) != 1 {
+		return RouteGroup{}, false
+	}
+	prefixSel, ok := prefixCall.Fun.(*ast.SelectorExpr)
+	if !ok || prefixSel.Sel.Name != "PathPrefix" {
+		return RouteGroup{}, false
+	}
 
+	basePath := a.routerPrefix(prefixSel.X, routeGroups) + a.routeCallPath(prefixCall)
+	return RouteGroup{Variable: variable, BasePath: basePath}, true
+}
+
+// parseMuxRouteCall parses gorilla/mux registrations of the form
+// r.HandleFunc("/users/{id}", getUser).Methods("GET", "PUT"), adding one
+// route per method
+func (a *Analyzer) parseMuxRouteCall(callExpr *ast.CallExpr, basePath string, walk *routeWalk) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return
+	}
+
+	switch selExpr.Sel.Name {
+	case "Methods":
+		handleCall, ok := selExpr.X.(*ast.CallExpr)
+		if !ok || len(handleCall.Args) < 2 {
+			return
+		}
+		handleSel, ok := handleCall.Fun.(*ast.SelectorExpr)
+		if !ok || (handleSel.Sel.Name != "HandleFunc" && handleSel.Sel.Name != "Handle") {
+			return
+		}
+		walk.chained[handleCall] = true
+
+		handlerName := a.routeHandlerName(handleCall.Args[1])
 		if handlerName == "" {
-			return nil
+			return
 		}
+		fullPath := a.routeFullPath(handleSel.X, basePath, a.routeCallPath(handleCall), walk.routeGroups)
 
-		// Determine the route group being used
-		var fullPath string
-		if xIdent, ok := selExpr.X.(*ast.Ident); ok {
-			if routeGroup, exists := routeGroups[xIdent.Name]; exists {
-				// This is using a route group like v1.Get()
-				fullPath = basePath + routeGroup.BasePath + path
-			} else {
-				// Direct router usage
-				fullPath = basePath + path
+		for _, arg := range callExpr.Args {
+			method := muxMethodName(arg)
+			if !a.isHTTPMethod(method) {
+				continue
+			}
+			route := a.newRoute(method, fullPath, handlerName, walk.packageName, walk.handlers, walk.analysis)
+			route.SourceFile = walk.sourceFile
+			walk.analysis.Routes = append(walk.analysis.Routes, *route)
+		}
+	case "HandleFunc", "Handle":
+		if !walk.chained[callExpr] && len(callExpr.Args) > 0 {
+			fmt.Printf("[DEBUG] Skipping route '%s' in %s: no .Methods() to determine the HTTP method\n", a.routeCallPath(callExpr), walk.sourceFile)
+		}
+	}
+}
+
+// parsePatternRouteCall parses Go 1.22 ServeMux registrations of the form
+// mux.HandleFunc("GET /items/{id}", getItem), where the pattern carries the
+// method, an optional host and the path
+func (a *Analyzer) parsePatternRouteCall(callExpr *ast.CallExpr, basePath string, walk *routeWalk) {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || (selExpr.Sel.Name != "HandleFunc" && selExpr.Sel.Name != "Handle") || len(callExpr.Args) != 2 {
+		return
+	}
+
+	pattern := a.routeCallPath(callExpr)
+	method, path, found := strings.Cut(pattern, " ")
+	if !found {
+		// Patterns without a method match every method
+		fmt.Printf("[DEBUG] Skipping route '%s' in %s: no method in the pattern to determine the HTTP method\n", pattern, walk.sourceFile)
+		return
+	}
+	if !a.isHTTPMethod(method) {
+		return
+	}
+	handlerName := a.routeHandlerName(callExpr.Args[1])
+	if handlerName == "" {
+		return
+	}
+
+	fullPath := a.routeFullPath(selExpr.X, basePath, servemuxPath(strings.TrimSpace(path)), walk.routeGroups)
+	route := a.newRoute(method, fullPath, handlerName, walk.packageName, walk.handlers, walk.analysis)
+	route.SourceFile = walk.sourceFile
+	for _, match := range servemuxWildcard.FindAllStringSubmatch(path, -1) {
+		for i, param := range route.Parameters {
+			if param.In == "path" && param.Name == match[1] {
+				route.Parameters[i].Wildcard = true
 			}
-		} else {
-			fullPath = basePath + path
 		}
+	}
+	walk.analysis.Routes = append(walk.analysis.Routes, *route)
+}
+
+// servemuxWildcard matches the {path...} wildcards of ServeMux patterns
+var servemuxWildcard = regexp.MustCompile(`\{([a-zA-Z_][a-zA-Z0-9_]*)\.\.\.\}`)
 
-		// Get handler info
-		handlerInfo, exists := handlers[handlerName]
-		if !exists {
-			handlerInfo = HandlerInfo{Name: handlerName}
+// servemuxPath converts a ServeMux pattern path to a route path: the host is
+// dropped, {path...} wildcards become {path} and the {$} end anchor goes away
+func servemuxPath(path string) string {
+	if !strings.HasPrefix(path, "/") {
+		if slash := strings.Index(path, "/"); slash >= 0 {
+			path = path[slash:]
 		}
+	}
+	path = strings.ReplaceAll(path, "...}", "}")
+	return strings.ReplaceAll(path, "{$}", "")
+}
 
-		route := &Route{
-			Path:    fullPath,
-			Method:  method,
-			Handler: handlerName,
-			Tags:    []string{packageName},
+// parseMethodRouteCall parses registrations that take the HTTP method as
+// their first argument, like httprouter's router.Handle("GET", "/users/:id", h)
+// or Hertz's h.Handle("GET", "/users/:id", mw, h). It reports whether the call
+// was one.
+func (a *Analyzer) parseMethodRouteCall(callExpr *ast.CallExpr, basePath string, walk *routeWalk) bool {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || !containsString(a.framework.methodCalls, selExpr.Sel.Name) || len(callExpr.Args) < 3 {
+		return false
+	}
+
+	// Fiber v3 takes a list of methods: app.Add([]string{"GET", "HEAD"}, "/users", h)
+	var methods []string
+	if compLit, ok := callExpr.Args[0].(*ast.CompositeLit); ok {
+		for _, elt := range compLit.Elts {
+			methods = append(methods, muxMethodName(elt))
+		}
+	} else {
+		methods = []string{muxMethodName(callExpr.Args[0])}
+	}
+	for _, method := range methods {
+		if !a.isHTTPMethod(method) {
+			return false
 		}
+	}
 
-		// Extract middleware
-		for i := 1; i < len(callExpr.Args)-1; i++ {
-			if callExpr, ok := callExpr.Args[i].(*ast.CallExpr); ok {
-				if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-					route.Middleware = append(route.Middleware, selExpr.Sel.Name)
-				}
+	handlerIndex := len(callExpr.Args) - 1
+	if a.fiberV3 {
+		handlerIndex = 2
+	}
+	handlerName := a.routeHandlerName(callExpr.Args[handlerIndex])
+	if handlerName == "" {
+		return false
+	}
+
+	path, _ := a.stringValue(callExpr.Args[1])
+	fullPath := a.routeFullPath(selExpr.X, basePath, path, walk.routeGroups)
+
+	for _, method := range methods {
+		route := a.newRoute(method, fullPath, handlerName, walk.packageName, walk.handlers, walk.analysis)
+		for i, arg := range callExpr.Args[2:] {
+			if i+2 == handlerIndex {
+				continue
+			}
+			if name := a.middlewareName(arg); name != "" {
+				route.Middleware = append(route.Middleware, name)
 			}
 		}
+		route.SourceFile = walk.sourceFile
+		walk.analysis.Routes = append(walk.analysis.Routes, *route)
+	}
+	return true
+}
 
-		// Map request/response models (clean the types)
-		if handlerInfo.RequestType != "" {
-			cleanRequestType := a.cleanTypeName(handlerInfo.RequestType)
-			if model, exists := analysis.Models[cleanRequestType]; exists {
-				route.RequestBody = &model
-			} else if handlerInfo.AnonymousRequestModel != nil {
-				// Use the anonymous model if available
-				route.RequestBody = handlerInfo.AnonymousRequestModel
-				// Add the anonymous model to the analysis models with a unique name
-				modelName := handlerInfo.AnonymousRequestModel.Name
-				// Ensure unique naming if there's a conflict
-				if _, exists := analysis.Models[modelName]; exists {
-					modelName = handlerName + modelName
-				}
-				handlerInfo.AnonymousRequestModel.Name = modelName
-				analysis.Models[modelName] = *handlerInfo.AnonymousRequestModel
-			} else {
-				// If we still don't have a model, try to find it with different variations
-				possibleNames := []string{
-					cleanRequestType,
-					handlerInfo.RequestType,
-					strings.TrimPrefix(handlerInfo.RequestType, "*"),
-					strings.TrimPrefix(handlerInfo.RequestType, "sdk."),
-				}
-				
-				for _, tryName := range possibleNames {
-					if model, exists := analysis.Models[tryName]; exists {
-						route.RequestBody = &model
-						break
-					}
-				}
-				
-				// Debug output if model not found
-				if route.RequestBody == nil && cleanRequestType != "" {
-					fmt.Printf("[DEBUG] Could not find request model '%s' for handler '%s'\n", cleanRequestType, handlerName)
+// parseAllRouteCall expands routes registered for every method, like
+// Fiber's app.All("/proxy", h), into a route per method of the analyzer's
+// all-methods list. It reports whether the call was one.
+func (a *Analyzer) parseAllRouteCall(callExpr *ast.CallExpr, basePath string, walk *routeWalk) bool {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || !containsString(a.framework.allCalls, selExpr.Sel.Name) || len(callExpr.Args) < 2 {
+		return false
+	}
+	for _, method := range a.allMethods {
+		route := a.routeFromCall(method, selExpr, callExpr, basePath, walk.packageName, walk.handlers, walk.analysis, walk.routeGroups)
+		if route == nil {
+			return false
+		}
+		route.SourceFile = walk.sourceFile
+		walk.analysis.Routes = append(walk.analysis.Routes, *route)
+	}
+	return true
+}
+
+// parseStaticRouteCall parses static file routes of the form
+// app.Static("/assets", "./public"), documented as a GET of any file under
+// the prefix when static routes are included. It reports whether the call
+// was one.
+func (a *Analyzer) parseStaticRouteCall(callExpr *ast.CallExpr, basePath string, walk *routeWalk) bool {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "Static" || len(callExpr.Args) < 2 {
+		return false
+	}
+	prefix, ok := a.stringValue(callExpr.Args[0])
+	if !ok {
+		return false
+	}
+	root, ok := a.stringValue(callExpr.Args[1])
+	if !ok {
+		return false
+	}
+
+	fullPath := a.routeFullPath(selExpr.X, basePath, strings.TrimSuffix(prefix, "/")+"/*", walk.routeGroups)
+	if !a.includeStatic {
+		fmt.Printf("[DEBUG] Skipping static route '%s' serving %s in %s\n", fullPath, root, walk.sourceFile)
+		return true
+	}
+
+	// The route has no handler of its own; its name must not pick up the
+	// info of a handler function named Static
+	handlerName := "Static"
+	for i := 2; ; i++ {
+		if _, exists := walk.handlers[handlerName]; !exists {
+			break
+		}
+		handlerName = fmt.Sprintf("Static%d", i)
+	}
+	route := a.newRoute("GET", fullPath, handlerName, walk.packageName, walk.handlers, walk.analysis)
+	route.StaticRoot = root
+	route.SourceFile = walk.sourceFile
+	walk.analysis.Routes = append(walk.analysis.Routes, *route)
+	return true
+}
+
+// parseRegisterRouteCall parses Fiber v3's chained registrations of the form
+// app.Route("/users").Get(listUsers).Post(createUser). It reports whether
+// the call was one.
+func (a *Analyzer) parseRegisterRouteCall(callExpr *ast.CallExpr, basePath string, walk *routeWalk) bool {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || len(callExpr.Args) == 0 {
+		return false
+	}
+	method := strings.ToUpper(selExpr.Sel.Name)
+	if !a.isHTTPMethod(method) {
+		return false
+	}
+
+	// Walk back over the other methods in the chain to the Route call
+	routeCall, ok := selExpr.X.(*ast.CallExpr)
+	for ok {
+		routeSel, isSel := routeCall.Fun.(*ast.SelectorExpr)
+		if !isSel {
+			return false
+		}
+		if routeSel.Sel.Name == "Route" && len(routeCall.Args) == 1 {
+			break
+		}
+		if !a.isHTTPMethod(strings.ToUpper(routeSel.Sel.Name)) {
+			return false
+		}
+		routeCall, ok = routeSel.X.(*ast.CallExpr)
+	}
+	if !ok {
+		return false
+	}
+
+	handlerName := a.routeHandlerName(callExpr.Args[0])
+	if handlerName == "" {
+		return false
+	}
+	fullPath := a.routeFullPath(routeCall.Fun.(*ast.SelectorExpr).X, basePath, a.routeCallPath(routeCall), walk.routeGroups)
+
+	route := a.newRoute(method, fullPath, handlerName, walk.packageName, walk.handlers, walk.analysis)
+	route.SourceFile = walk.sourceFile
+	walk.analysis.Routes = append(walk.analysis.Routes, *route)
+	return true
+}
+
+// muxMethodName returns the HTTP method named by a .Methods() argument,
+// either a string literal or an http.MethodGet-style constant
+func muxMethodName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		return strings.ToUpper(strings.Trim(e.Value, `"`))
+	case *ast.SelectorExpr:
+		return strings.ToUpper(strings.TrimPrefix(e.Sel.Name, "Method"))
+	}
+	return ""
+}
+
+// routeCallPath returns the constant path passed as a call's first argument
+func (a *Analyzer) routeCallPath(callExpr *ast.CallExpr) string {
+	path, _ := a.stringValue(callExpr.Args[0])
+	return path
+}
+
+func (a *Analyzer) parseRouteCall(callExpr *ast.CallExpr, basePath, packageName string, handlers map[string]HandlerInfo, analysis *Analysis, routeGroups map[string]RouteGroup) *Route {
+	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+		method := strings.ToUpper(selExpr.Sel.Name)
+
+		// Skip if not an HTTP method
+		if !a.isHTTPMethod(method) {
+			return nil
+		}
+
+		return a.routeFromCall(method, selExpr, callExpr, basePath, packageName, handlers, analysis, routeGroups)
+	}
+
+	return nil
+}
+
+// routeFromCall builds the route for a call of the form router.Method(path, handlers...)
+func (a *Analyzer) routeFromCall(method string, selExpr *ast.SelectorExpr, callExpr *ast.CallExpr, basePath, packageName string, handlers map[string]HandlerInfo, analysis *Analysis, routeGroups map[string]RouteGroup) *Route {
+	if len(callExpr.Args) < 2 {
+		return nil
+	}
+
+	// Extract path
+	path := a.routeCallPath(callExpr)
+
+	// Extract handler name (last argument, or the one right after the
+	// path for frameworks that take middleware after the handler)
+	handlerIndex := len(callExpr.Args) - 1
+	if a.framework.handlerFirst || a.fiberV3 {
+		handlerIndex = 1
+	}
+	handlerName := a.routeHandlerName(callExpr.Args[handlerIndex])
+	if handlerName == "" {
+		return nil
+	}
+
+	// Determine the route group being used
+	fullPath := a.routeFullPath(selExpr.X, basePath, path, routeGroups)
+
+	route := a.newRoute(method, fullPath, handlerName, packageName, handlers, analysis)
+
+	// Extract middleware, including chi's r.With(mw).Get(...)
+	if withCall, ok := selExpr.X.(*ast.CallExpr); ok {
+		if withSel, ok := withCall.Fun.(*ast.SelectorExpr); ok && withSel.Sel.Name == "With" {
+			for _, arg := range withCall.Args {
+				if name := a.middlewareName(arg); name != "" {
+					route.Middleware = append(route.Middleware, name)
 				}
 			}
 		}
+	}
+	for i := 1; i < len(callExpr.Args); i++ {
+		if i == handlerIndex {
+			continue
+		}
+		if callExpr, ok := callExpr.Args[i].(*ast.CallExpr); ok {
+			if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+				route.Middleware = append(route.Middleware, selExpr.Sel.Name)
+			}
+		}
+	}
+
+	return route
+}
+
+// routeHandlerName returns the handler function or method named by a route
+// argument, unwrapping http.HandlerFunc(h) conversions, the handler factory
+// called to build it: MakeListHandler(svc), or the key of an inline closure
+func (a *Analyzer) routeHandlerName(handlerArg ast.Expr) string {
+	if conv, ok := handlerArg.(*ast.CallExpr); ok && len(conv.Args) == 1 {
+		if convSel, ok := conv.Fun.(*ast.SelectorExpr); ok && convSel.Sel.Name == "HandlerFunc" {
+			handlerArg = conv.Args[0]
+		}
+	}
+	switch arg := handlerArg.(type) {
+	case *ast.Ident:
+		return arg.Name
+	case *ast.SelectorExpr:
+		// Controller-style method handlers: h.List
+		return a.methodHandlerName(arg)
+	case *ast.CallExpr:
+		if key := a.websocketKey(arg); key != "" {
+			return key
+		}
+		if name := a.routeHandlerName(arg.Fun); a.factories[name] {
+			return name
+		}
+	case *ast.FuncLit:
+		return a.closureKey(arg)
+	}
+	return ""
+}
+
+// routeFullPath joins the base path, the prefix of the route group the
+// router refers to (if any) and the route path
+func (a *Analyzer) routeFullPath(router ast.Expr, basePath, path string, routeGroups map[string]RouteGroup) string {
+	return basePath + a.routerPrefix(router, routeGroups) + path
+}
 
-		if handlerInfo.ResponseType != "" {
-			cleanResponseType := a.cleanTypeName(handlerInfo.ResponseType)
-			if model, exists := analysis.Models[cleanResponseType]; exists {
-				route.Response = &model
-			} else {
-				// Try variations
-				possibleNames := []string{
-					cleanResponseType,
-					handlerInfo.ResponseType,
-					strings.TrimPrefix(handlerInfo.ResponseType, "*"),
-					strings.TrimPrefix(handlerInfo.ResponseType, "sdk."),
+// routerPrefix returns the prefix of the route group a router expression
+// refers to: a group variable like v1, or a group created inline, like
+// v1.Group("/admin") in v1.Group("/admin").Get("/users", ListUsers). Direct
+// router usage has no prefix.
+func (a *Analyzer) routerPrefix(router ast.Expr, routeGroups map[string]RouteGroup) string {
+	switch r := router.(type) {
+	case *ast.Ident:
+		if routeGroup, exists := routeGroups[r.Name]; exists {
+			return routeGroup.BasePath
+		}
+	case *ast.CallExpr:
+		selExpr, ok := r.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return ""
+		}
+		switch selExpr.Sel.Name {
+		case "Name":
+			return a.routerPrefix(selExpr.X, routeGroups)
+		case "Group", "Party":
+			if len(r.Args) > 0 {
+				if groupPath, ok := a.stringValue(r.Args[0]); ok {
+					return a.routerPrefix(selExpr.X, routeGroups) + groupPath
 				}
-				
-				for _, tryName := range possibleNames {
-					if model, exists := analysis.Models[tryName]; exists {
-						route.Response = &model
-						break
-					}
+			}
+		case "Subrouter":
+			if routeGroup, ok := a.muxSubrouter("", r, routeGroups); ok {
+				return routeGroup.BasePath
+			}
+		}
+	}
+	return ""
+}
+
+// newRoute builds a route for a handler, resolving its request/response
+// models and parameters
+func (a *Analyzer) newRoute(method, fullPath, handlerName, packageName string, handlers map[string]HandlerInfo, analysis *Analysis) *Route {
+	// Inline closures are analyzed here, named after the route
+	if _, isClosure := a.closures[handlerName]; isClosure {
+		handlerName = a.closureHandler(handlerName, method, fullPath, handlers)
+	}
+	webSocket := strings.HasPrefix(handlerName, websocketPrefix)
+	handlerName = strings.TrimPrefix(handlerName, websocketPrefix)
+
+	// Get handler info
+	handlerInfo, exists := handlers[handlerName]
+	if !exists {
+		handlerInfo = HandlerInfo{Name: handlerName}
+	}
+
+	route := &Route{
+		Path:           fullPath,
+		Method:         method,
+		Handler:        handlerName,
+		Tags:           []string{packageName},
+		Annotations:    handlerInfo.Annotations,
+		NotImplemented: handlerInfo.NotImplemented,
+		WebSocket:      webSocket || handlerInfo.WebSocket,
+		Accepted:       handlerInfo.Accepted,
+	}
+
+	// Generic models the handlers use with type arguments
+	a.instantiateGenerics(analysis)
+
+	// Map request/response models (clean the types)
+	// Named types and aliases of models and slices, like type Tenants = []Tenant
+	handlerInfo.RequestType = a.resolveNamedType(handlerInfo.RequestType, analysis)
+	handlerInfo.ResponseType = a.resolveNamedType(handlerInfo.ResponseType, analysis)
+
+	if handlerInfo.RequestType != "" {
+		cleanRequestType := a.cleanTypeName(handlerInfo.RequestType)
+		if model, exists := analysis.Models[cleanRequestType]; exists {
+			route.RequestBody = &model
+		} else if handlerInfo.AnonymousRequestModel != nil {
+			// Use the anonymous model if available
+			route.RequestBody = handlerInfo.AnonymousRequestModel
+			// Add the anonymous model to the analysis models with a unique name
+			modelName := handlerInfo.AnonymousRequestModel.Name
+			// Ensure unique naming if there's a conflict
+			if _, exists := analysis.Models[modelName]; exists {
+				modelName = handlerInfo.Name + modelName
+			}
+			handlerInfo.AnonymousRequestModel.Name = modelName
+			analysis.Models[modelName] = *handlerInfo.AnonymousRequestModel
+		} else {
+			// If we still don't have a model, try to find it with different variations
+			possibleNames := []string{
+				cleanRequestType,
+				handlerInfo.RequestType,
+				strings.TrimPrefix(handlerInfo.RequestType, "*"),
+				strings.TrimPrefix(handlerInfo.RequestType, "sdk."),
+			}
+			
+			for _, tryName := range possibleNames {
+				if model, exists := analysis.Models[tryName]; exists {
+					route.RequestBody = &model
+					break
 				}
-				
-				// Debug output if model not found
-				if route.Response == nil && cleanResponseType != "" {
-					fmt.Printf("[DEBUG] Could not find response model '%s' for handler '%s'\n", cleanResponseType, handlerName)
+			}
+			
+			// Debug output if model not found
+			if route.RequestBody == nil && cleanRequestType != "" {
+				fmt.Printf("[DEBUG] Could not find request model '%s' for handler '%s'\n", cleanRequestType, handlerName)
+				a.unresolved(cleanRequestType)
+			}
+		}
+		if route.RequestBody != nil {
+			a.matched()
+		}
+	}
+
+	if handlerInfo.ResponseType != "" {
+		cleanResponseType := a.cleanTypeName(handlerInfo.ResponseType)
+		if model, exists := analysis.Models[cleanResponseType]; exists {
+			route.Response = &model
+		} else if model, exists := analysis.Models[strings.TrimPrefix(cleanResponseType, "[]")]; exists && strings.HasPrefix(cleanResponseType, "[]") {
+			// Slices of models: c.JSON([]sdk.User{}) or c.JSON(users)
+			route.Response = &model
+			route.ResponseArray = true
+		} else {
+			// Try variations
+			possibleNames := []string{
+				cleanResponseType,
+				handlerInfo.ResponseType,
+				strings.TrimPrefix(handlerInfo.ResponseType, "*"),
+				strings.TrimPrefix(handlerInfo.ResponseType, "sdk."),
+			}
+			
+			for _, tryName := range possibleNames {
+				if model, exists := analysis.Models[tryName]; exists {
+					route.Response = &model
+					break
 				}
 			}
+			
+			// Debug output if model not found
+			if route.Response == nil && cleanResponseType != "" {
+				fmt.Printf("[DEBUG] Could not find response model '%s' for handler '%s'\n", cleanResponseType, handlerName)
+				a.unresolved(cleanResponseType)
+			}
+		}
+		if route.Response != nil {
+			a.matched()
 		}
+	}
 
-		// Extract path parameters
-		route.Parameters = a.extractPathParameters(path)
+	// Extract path parameters (including those from nested prefixes)
+	route.Parameters = a.extractPathParameters(fullPath)
+	for i, param := range route.Parameters {
+		if paramType, ok := handlerInfo.PathParameterTypes[param.Name]; ok {
+			route.Parameters[i].Type = paramType
+		}
+	}
+	for _, name := range handlerInfo.PathParameters {
+		if !hasPathParameter(route.Parameters, name) {
+			fmt.Printf("[DEBUG] Handler '%s' reads path parameter '%s' that is not in %s\n", handlerName, name, fullPath)
+		}
+	}
 
-		// Add query parameters from handler analysis
-		for _, queryParam := range handlerInfo.QueryParameters {
-			param := Parameter{
-				Name:        queryParam.Name,
-				In:          "query",
-				Required:    queryParam.Required,
-				Type:        queryParam.Type,
-				Description: queryParam.Description,
-				Default:     queryParam.Default,
-				Enum:        queryParam.Enum,
-			}
-			route.Parameters = append(route.Parameters, param)
+	// Add query parameters from handler analysis
+	for _, queryParam := range mergeQueryParameters(handlerName, handlerInfo.QueryParameters) {
+		param := Parameter{
+			Name:        queryParam.Name,
+			In:          "query",
+			Required:    queryParam.Required,
+			Type:        queryParam.Type,
+			Description: queryParam.Description,
+			Default:     queryParam.Default,
+			Enum:        queryParam.Enum,
+			EnumName:    queryParam.EnumName,
+			GoType:      queryParam.GoType,
 		}
+		route.Parameters = append(route.Parameters, param)
+	}
+	route.Parameters = append(route.Parameters, handlerInfo.HeaderParameters...)
+	route.Parameters = append(route.Parameters, handlerInfo.CookieParameters...)
+	route.FormFields = handlerInfo.FormFields
+	route.Multipart = handlerInfo.Multipart
+	route.File = handlerInfo.File
+	route.Statuses = handlerInfo.Statuses
+
+	return route
+}
 
-		return route
+// middlewareName returns a readable name for a middleware expression such as
+// auth, middleware.Logger or middleware.Timeout(...)
+func (a *Analyzer) middlewareName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.CallExpr:
+		return a.middlewareName(e.Fun)
+	case *ast.SelectorExpr:
+		return e.Sel.Name
+	case *ast.Ident:
+		return e.Name
 	}
+	return ""
+}
 
-	return nil
-}
\ No newline at end of file
+func hasPathParameter(params []Parameter, name string) bool {
+	for _, param := range params {
+		if param.Name == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,274 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// statusArgs are the calls setting a response status, to the position of
+// the status among their arguments: c.Status(201).JSON(user),
+// c.JSON(http.StatusCreated, user) with status-first JSON calls,
+// w.WriteHeader(204), Iris's ctx.StatusCode(202), http.Error(w, msg, 404)
+// and errors like fiber.NewError(404, msg) the framework turns into responses
+var statusArgs = map[string]int{
+	"Status":              0,
+	"SendStatus":          0,
+	"StatusCode":          0,
+	"SetStatusCode":       0,
+	"WriteHeader":         0,
+	"JSON":                0,
+	"String":              0,
+	"NoContent":           0,
+	"AbortWithStatus":     0,
+	"AbortWithStatusJSON": 0,
+	"AbortWithError":      0,
+	"NewError":            0,
+	"NewHTTPError":        0,
+	"Error":               2,
+}
+
+// statusSetters are the calls setting the status of a later response:
+// c.Status(201), Iris's ctx.StatusCode(201), w.WriteHeader(201) and chi's
+// render.Status(r, 201)
+var statusSetters = map[string]bool{
+	"Status":      true,
+	"StatusCode":  true,
+	"WriteHeader": true,
+}
+
+// responseStatuses returns the response statuses a handler sets with
+// statusArgs calls or by returning Fiber's errors, like fiber.ErrNotFound,
+// sorted. A JSON call of a framework taking the body first, like c.JSON(data),
+// answers 200 unless a status was set before it.
+func (a *Analyzer) responseStatuses(funcDecl *ast.FuncDecl) []int {
+	seen := make(map[int]bool)
+	var statuses []int
+	record := func(status int) {
+		if !seen[status] {
+			seen[status] = true
+			statuses = append(statuses, status)
+		}
+	}
+	statusSet := a.statusSetScopes(funcDecl)
+	ast.Inspect(funcDecl, func(n ast.Node) bool {
+		// return fiber.ErrNotFound
+		if ret, ok := n.(*ast.ReturnStmt); ok {
+			for _, result := range ret.Results {
+				selExpr, ok := result.(*ast.SelectorExpr)
+				if !ok {
+					continue
+				}
+				if pkg, ok := selExpr.X.(*ast.Ident); !ok || pkg.Name != "fiber" {
+					continue
+				}
+				if name, ok := strings.CutPrefix(selExpr.Sel.Name, "Err"); ok && statusCodes[name] != 0 {
+					record(statusCodes[name])
+				}
+			}
+			return true
+		}
+		callExpr, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		if a.framework.jsonBodyArg != 1 && a.isJSONResponseCall(callExpr) &&
+			!chainsStatus(selExpr.X) && !statusSet(callExpr.Pos()) {
+			record(200)
+		}
+		position, ok := statusArgs[selExpr.Sel.Name]
+		if !ok || position >= len(callExpr.Args) {
+			return true
+		}
+		switch selExpr.Sel.Name {
+		case "JSON", "String":
+			// Fiber's c.JSON(data) takes the body first
+			if a.framework.jsonBodyArg == 0 {
+				return true
+			}
+		case "Error":
+			// http.Error(w, msg, code), not c.Error(err)
+			if pkg, ok := selExpr.X.(*ast.Ident); !ok || pkg.Name != "http" || len(callExpr.Args) != 3 {
+				return true
+			}
+		}
+		if status, ok := a.statusCode(callExpr.Args[position]); ok {
+			record(status)
+		}
+		return true
+	})
+	sort.Ints(statuses)
+	return statuses
+}
+
+// hasStatus reports whether a handler's statuses include a status
+func hasStatus(statuses []int, status int) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// statusSetScopes returns whether a position follows a statusSetters
+// statement in its block, or in a block enclosing it
+func (a *Analyzer) statusSetScopes(funcDecl *ast.FuncDecl) func(token.Pos) bool {
+	type scope struct{ from, to token.Pos }
+	var scopes []scope
+	ast.Inspect(funcDecl, func(n ast.Node) bool {
+		block, ok := n.(*ast.BlockStmt)
+		if !ok {
+			return true
+		}
+		for _, stmt := range block.List {
+			exprStmt, ok := stmt.(*ast.ExprStmt)
+			if !ok {
+				continue
+			}
+			callExpr, ok := exprStmt.X.(*ast.CallExpr)
+			if !ok {
+				continue
+			}
+			if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok && statusSetters[selExpr.Sel.Name] {
+				scopes = append(scopes, scope{stmt.End(), block.End()})
+			}
+		}
+		return true
+	})
+	return func(pos token.Pos) bool {
+		for _, s := range scopes {
+			if pos >= s.from && pos < s.to {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// chainsStatus reports whether a call's receiver sets the status, like
+// c.Status(201) in c.Status(201).JSON(user)
+func chainsStatus(expr ast.Expr) bool {
+	for {
+		callExpr, ok := expr.(*ast.CallExpr)
+		if !ok {
+			return false
+		}
+		selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return false
+		}
+		if statusSetters[selExpr.Sel.Name] {
+			return true
+		}
+		expr = selExpr.X
+	}
+}
+
+// statusCode folds a status argument to an HTTP status code: a literal like
+// 404, a constant of the route package, or a named status like
+// fiber.StatusCreated or http.StatusNotFound
+func (a *Analyzer) statusCode(expr ast.Expr) (int, bool) {
+	if selExpr, ok := expr.(*ast.SelectorExpr); ok {
+		return namedStatus(selExpr.Sel.Name)
+	}
+	value, ok := a.constValue(expr, 0)
+	if !ok {
+		return 0, false
+	}
+	code, ok := value.(int64)
+	if !ok || code < 100 || code > 599 {
+		return 0, false
+	}
+	return int(code), true
+}
+
+// statusCodes are the codes of the StatusXxx constants of net/http, which
+// Fiber, Hertz's consts and Iris mirror, without their Status prefix. Their
+// names don't always match the status text, like StatusTeapot for "I'm a
+// teapot" or StatusNonAuthoritativeInfo.
+var statusCodes = map[string]int{
+	"Continue":           100,
+	"SwitchingProtocols": 101,
+	"Processing":         102,
+	"EarlyHints":         103,
+
+	"OK":                   200,
+	"Created":              201,
+	"Accepted":             202,
+	"NonAuthoritativeInfo": 203,
+	"NoContent":            204,
+	"ResetContent":         205,
+	"PartialContent":       206,
+	"MultiStatus":          207,
+	"AlreadyReported":      208,
+	"IMUsed":               226,
+
+	"MultipleChoices":   300,
+	"MovedPermanently":  301,
+	"Found":             302,
+	"SeeOther":          303,
+	"NotModified":       304,
+	"UseProxy":          305,
+	"SwitchProxy":       306,
+	"TemporaryRedirect": 307,
+	"PermanentRedirect": 308,
+
+	"BadRequest":                   400,
+	"Unauthorized":                 401,
+	"PaymentRequired":              402,
+	"Forbidden":                    403,
+	"NotFound":                     404,
+	"MethodNotAllowed":             405,
+	"NotAcceptable":                406,
+	"ProxyAuthRequired":            407,
+	"RequestTimeout":               408,
+	"Conflict":                     409,
+	"Gone":                         410,
+	"LengthRequired":               411,
+	"PreconditionFailed":           412,
+	"RequestEntityTooLarge":        413,
+	"RequestURITooLong":            414,
+	"UnsupportedMediaType":         415,
+	"RequestedRangeNotSatisfiable": 416,
+	"ExpectationFailed":            417,
+	"Teapot":                       418,
+	"MisdirectedRequest":           421,
+	"UnprocessableEntity":          422,
+	"Locked":                       423,
+	"FailedDependency":             424,
+	"TooEarly":                     425,
+	"UpgradeRequired":              426,
+	"PreconditionRequired":         428,
+	"TooManyRequests":              429,
+	"RequestHeaderFieldsTooLarge":  431,
+	"UnavailableForLegalReasons":   451,
+
+	"InternalServerError":           500,
+	"NotImplemented":                501,
+	"BadGateway":                    502,
+	"ServiceUnavailable":            503,
+	"GatewayTimeout":                504,
+	"HTTPVersionNotSupported":       505,
+	"VariantAlsoNegotiates":         506,
+	"InsufficientStorage":           507,
+	"LoopDetected":                  508,
+	"NotExtended":                   510,
+	"NetworkAuthenticationRequired": 511,
+}
+
+// namedStatus returns the code of a StatusXxx constant, like 422 for
+// fiber.StatusUnprocessableEntity
+func namedStatus(name string) (int, bool) {
+	name, ok := strings.CutPrefix(name, "Status")
+	if !ok {
+		return 0, false
+	}
+	code, ok := statusCodes[name]
+	return code, ok
+}
@@ -0,0 +1,42 @@
+package analyzer
+
+import "fmt"
+
+// FileError is a project file the analyzer couldn't parse
+type FileError struct {
+	File string
+	Err  error
+}
+
+func (e FileError) Error() string {
+	return e.Err.Error()
+}
+
+// skipFile records a file that failed to parse, so the analysis continues
+// without it and the run reports it with the others once done. In strict
+// mode it also returns the error, failing the run.
+func (a *Analyzer) skipFile(file string, err error) error {
+	recorded := false
+	for _, skipped := range a.skipped {
+		recorded = recorded || skipped.File == file
+	}
+	if !recorded {
+		a.skipped = append(a.skipped, FileError{File: file, Err: err})
+	}
+	// Parse errors start with the file and position
+	if a.strict {
+		return err
+	}
+	return nil
+}
+
+// checkSkipped adds the skipped files to the analysis. Files skipped where
+// an error couldn't stop the analysis, like those of model packages, fail a
+// strict run once it's done.
+func (a *Analyzer) checkSkipped(analysis *Analysis) (*Analysis, error) {
+	analysis.SkippedFiles = a.skipped
+	if a.strict && len(a.skipped) > 0 {
+		return nil, fmt.Errorf("syntax errors in %d file(s), the first: %w", len(a.skipped), a.skipped[0].Err)
+	}
+	return analysis, nil
+}
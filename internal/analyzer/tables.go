@@ -0,0 +1,153 @@
+package analyzer
+
+import (
+	"fmt"
+	"go/ast"
+)
+
+// recordRouteTable remembers slice and array literals assigned to a
+// variable, which may be route tables iterated in a loop:
+// routes := []RouteDef{{Method: "GET", Path: "/users", Handler: ListUsers}}
+func recordRouteTable(name string, value ast.Expr, tables map[string]*ast.CompositeLit) {
+	compLit, ok := value.(*ast.CompositeLit)
+	if !ok {
+		return
+	}
+	if _, ok := compLit.Type.(*ast.ArrayType); ok {
+		tables[name] = compLit
+	}
+}
+
+// walkRouteTable evaluates loops over a static route table, like
+//
+//	for _, r := range routes {
+//		app.Add(r.Method, r.Path, r.Handler)
+//	}
+//
+// walking the loop body once per row with the row's fields in place of
+// r.Method, r.Path and r.Handler. Rows may be keyed or positional. It
+// reports whether the loop ranged over a route table.
+func (a *Analyzer) walkRouteTable(rangeStmt *ast.RangeStmt, basePath string, walk *routeWalk) bool {
+	rowVar, ok := rangeStmt.Value.(*ast.Ident)
+	if !ok {
+		return false
+	}
+	var table *ast.CompositeLit
+	switch x := rangeStmt.X.(type) {
+	case *ast.CompositeLit:
+		table = x
+	case *ast.Ident:
+		table = walk.tables[x.Name]
+	}
+	if table == nil {
+		return false
+	}
+	arrayType, ok := table.Type.(*ast.ArrayType)
+	if !ok {
+		return false
+	}
+	fields := a.tableFields(arrayType.Elt, walk)
+
+	before := len(walk.analysis.Routes)
+	for _, elt := range table.Elts {
+		row := tableRow(elt, fields)
+		if row == nil {
+			continue
+		}
+		ast.Inspect(rangeStmt.Body, func(n ast.Node) bool {
+			stmt, ok := n.(*ast.ExprStmt)
+			if !ok {
+				return true
+			}
+			if callExpr, ok := stmt.X.(*ast.CallExpr); ok {
+				a.walkRoutes(substituteRow(callExpr, rowVar.Name, row), basePath, walk)
+			}
+			return false
+		})
+	}
+
+	// Loops over other tables, like a list of middleware, are walked as usual
+	if len(walk.analysis.Routes) == before {
+		return false
+	}
+	fmt.Printf("[DEBUG] Found %d routes in a table of %d rows in %s\n", len(walk.analysis.Routes)-before, len(table.Elts), walk.sourceFile)
+	return true
+}
+
+// tableFields returns the field names of a table's row type in declaration
+// order, for positional rows
+func (a *Analyzer) tableFields(rowType ast.Expr, walk *routeWalk) []string {
+	if star, ok := rowType.(*ast.StarExpr); ok {
+		rowType = star.X
+	}
+	var structType *ast.StructType
+	switch t := rowType.(type) {
+	case *ast.StructType:
+		structType = t
+	case *ast.Ident:
+		structType = walk.structs[t.Name]
+	}
+	if structType == nil {
+		return nil
+	}
+
+	var fields []string
+	for _, field := range structType.Fields.List {
+		for _, name := range field.Names {
+			fields = append(fields, name.Name)
+		}
+	}
+	return fields
+}
+
+// tableRow maps the fields of a row literal to their values
+func tableRow(elt ast.Expr, fields []string) map[string]ast.Expr {
+	if unary, ok := elt.(*ast.UnaryExpr); ok {
+		elt = unary.X
+	}
+	compLit, ok := elt.(*ast.CompositeLit)
+	if !ok {
+		return nil
+	}
+
+	row := make(map[string]ast.Expr)
+	for i, value := range compLit.Elts {
+		if kv, ok := value.(*ast.KeyValueExpr); ok {
+			if key, ok := kv.Key.(*ast.Ident); ok {
+				row[key.Name] = kv.Value
+			}
+			continue
+		}
+		if i < len(fields) {
+			row[fields[i]] = value
+		}
+	}
+	return row
+}
+
+// substituteRow returns a copy of a call chain with the fields of the row
+// variable replaced by the row's values. Only the call chain is copied; the
+// rest of the tree is shared.
+func substituteRow(expr ast.Expr, rowVar string, row map[string]ast.Expr) ast.Expr {
+	switch e := expr.(type) {
+	case *ast.SelectorExpr:
+		if ident, ok := e.X.(*ast.Ident); ok && ident.Name == rowVar {
+			if value, ok := row[e.Sel.Name]; ok {
+				return value
+			}
+			return e
+		}
+		copied := *e
+		copied.X = substituteRow(e.X, rowVar, row)
+		return &copied
+	case *ast.CallExpr:
+		copied := *e
+		copied.Fun = substituteRow(e.Fun, rowVar, row)
+		copied.Args = make([]ast.Expr, len(e.Args))
+		for i, arg := range e.Args {
+			copied.Args[i] = substituteRow(arg, rowVar, row)
+		}
+		return &copied
+	}
+	return expr
+}
@@ -0,0 +1,246 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"go/ast"
+	"go/types"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// typeSpan locates an expression in a file, so types checked on the ASTs
+// go/packages parsed can be found for the same expression in ours
+type typeSpan struct {
+	file       string
+	start, end int
+}
+
+// typeCheckTimeout bounds how long loading the project's packages may take
+const typeCheckTimeout = 2 * time.Minute
+
+// loadTypes type-checks the project with go/packages, so the types of
+// handler variables, function results and imported models are read instead
+// of guessed: see typedModel, which the request and response arguments,
+// query parser targets and the handlers' variables are resolved with, and
+// loadTypedModel for models of packages no model package declares. Projects
+// that don't load (no go.mod, dependencies missing from the module cache,
+// code that doesn't compile) keep the AST heuristics, as do packages with
+// errors.
+func (a *Analyzer) loadTypes() {
+	if _, err := os.Stat(filepath.Join(a.projectPath, "go.mod")); err != nil {
+		fmt.Printf("Warning: no go.mod in %s; analyzing without type information\n", a.projectPath)
+		return
+	}
+
+	// Loading is offline and never touches the project's go.mod:
+	// dependencies come from the module cache or vendor directory
+	var goFlags []string
+	for _, flag := range strings.Fields(os.Getenv("GOFLAGS")) {
+		if !strings.HasPrefix(flag, "-mod=") {
+			goFlags = append(goFlags, flag)
+		}
+	}
+	ctx, cancel := context.WithTimeout(a.ctx, typeCheckTimeout)
+	defer cancel()
+	config := &packages.Config{
+		// Dependencies are checked from source rather than compiled for export data
+		Mode:    packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps,
+		Context: ctx,
+		Dir:     a.projectPath,
+		Env:     append(os.Environ(), "GOPROXY=off", "GOFLAGS="+strings.Join(goFlags, " ")),
+		Tests:   false,
+	}
+	pkgs, err := packages.Load(config, "./...")
+	if err != nil {
+		fmt.Printf("Warning: failed to load packages for type checking, using AST heuristics: %v\n", err)
+		return
+	}
+
+	a.exprTypes = make(map[typeSpan]types.Type)
+	record := func(pkg *packages.Package, node ast.Node, t types.Type) {
+		start := pkg.Fset.Position(node.Pos())
+		end := pkg.Fset.Position(node.End())
+		a.exprTypes[typeSpan{file: absPath(start.Filename), start: start.Offset, end: end.Offset}] = t
+	}
+	checked, failed := 0, 0
+	var firstErr error
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) > 0 {
+			failed++
+			if firstErr == nil {
+				firstErr = pkg.Errors[0]
+			}
+			continue
+		}
+		if pkg.TypesInfo == nil {
+			continue
+		}
+		checked++
+		for expr, tv := range pkg.TypesInfo.Types {
+			if tv.Type != nil && !tv.IsType() {
+				record(pkg, expr, tv.Type)
+			}
+		}
+		// Variables where they are declared: users, err := svc.List(ctx)
+		for ident, obj := range pkg.TypesInfo.Defs {
+			if v, ok := obj.(*types.Var); ok && !v.IsField() {
+				record(pkg, ident, v.Type())
+			}
+		}
+	}
+	if a.stats != nil {
+		a.stats.TypeChecked, a.stats.TypeCheckFailed = checked, failed
+	}
+	if failed > 0 {
+		fmt.Printf("Warning: %d of %d packages failed to type-check, e.g. %v; their handlers are analyzed with AST heuristics\n", failed, len(pkgs), firstErr)
+	}
+}
+
+// trackCheckedType records the checked model type of a variable an
+// assignment or declaration defines in variableTypes, replacing what the
+// heuristics guessed for it there and in the guesses maps, like a service
+// call result typed by its method name
+func (a *Analyzer) trackCheckedType(name ast.Expr, variableTypes map[string]string, guesses ...map[string]string) {
+	ident, ok := name.(*ast.Ident)
+	if !ok || ident.Name == "_" {
+		return
+	}
+	model := a.typedModel(ident)
+	if model == "" {
+		return
+	}
+	variableTypes[ident.Name] = model
+	for _, guessed := range guesses {
+		if _, exists := guessed[ident.Name]; exists {
+			guessed[ident.Name] = model
+		}
+	}
+}
+
+// exprType returns the checked type of an expression of a file parsed by
+// the analyzer, if its package type-checked
+func (a *Analyzer) exprType(expr ast.Expr) (types.Type, bool) {
+	if a.exprTypes == nil || expr == nil {
+		return nil, false
+	}
+	start := a.fileSet.Position(expr.Pos())
+	end := a.fileSet.Position(expr.End())
+	t, ok := a.exprTypes[typeSpan{file: absPath(start.Filename), start: start.Offset, end: end.Offset}]
+	return t, ok
+}
+
+// typedModel returns the model an expression's checked type is, a
+// pointer to or a slice of, named like the analyzer names types: User, or
+// []User. It returns "" for other types, which the heuristics handle.
+func (a *Analyzer) typedModel(expr ast.Expr) string {
+	t, ok := a.exprType(expr)
+	if !ok {
+		return ""
+	}
+	return a.modelOfType(t)
+}
+
+// typedElemModel returns the model of the elements of a channel expression's
+// checked type, like User for results of type chan *User, or ""
+func (a *Analyzer) typedElemModel(expr ast.Expr) string {
+	t, ok := a.exprType(expr)
+	if !ok {
+		return ""
+	}
+	ch, ok := types.Unalias(t).Underlying().(*types.Chan)
+	if !ok {
+		return ""
+	}
+	return a.modelOfType(ch.Elem())
+}
+
+// modelOfType returns the model a checked type is, see typedModel
+func (a *Analyzer) modelOfType(t types.Type) string {
+	prefix := ""
+	for {
+		switch u := t.(type) {
+		case *types.Pointer:
+			t = u.Elem()
+			continue
+		case *types.Slice:
+			if prefix != "" {
+				return ""
+			}
+			prefix = "[]"
+			t = u.Elem()
+			continue
+		case *types.Alias:
+			// type Tenants = []Tenant is its aliased type
+			t = types.Unalias(u)
+			continue
+		case *types.Named:
+			// The model must be this type, not one of the same name elsewhere
+			obj := u.Obj()
+			if u.TypeArgs().Len() > 0 {
+				if instance := a.typedInstance(u); instance != "" {
+					return prefix + instance
+				}
+				return ""
+			}
+			if obj.Pkg() == nil {
+				return ""
+			}
+			model, isModel := a.models[obj.Name()]
+			if !isModel && a.loadTypedModel(obj) {
+				model, isModel = a.models[obj.Name()]
+			}
+			if !isModel || (model.GoPackage != "" && model.GoPackage != obj.Pkg().Path()) {
+				return ""
+			}
+			return prefix + obj.Name()
+		}
+		return ""
+	}
+}
+
+// loadTypedModel loads a struct type no model package declares, like a
+// dto.User a service returns, as a model, with the structs its fields use.
+// It reports whether the type is now a model.
+func (a *Analyzer) loadTypedModel(obj *types.TypeName) bool {
+	path := obj.Pkg().Path()
+	if a.analysis == nil || !obj.Exported() || isStdImport(path) || isWellKnownImport(path) {
+		return false
+	}
+	if _, isStruct := obj.Type().Underlying().(*types.Struct); !isStruct {
+		return false
+	}
+	if a.loadRefs(a.analysis, []typeRef{{path: path, name: obj.Name()}}) == 0 {
+		return false
+	}
+	a.applyEnums(a.analysis)
+	a.checkOneOf(a.analysis)
+	return true
+}
+
+// typedInstance returns the model of a generic model's instance, like
+// PageUser for Page[User], or "" if the type isn't a generic model
+func (a *Analyzer) typedInstance(named *types.Named) string {
+	obj := named.Obj()
+	generic, isGeneric := a.generics[obj.Name()]
+	if !isGeneric || obj.Pkg() == nil || (generic.goPackage != "" && generic.goPackage != obj.Pkg().Path()) {
+		return ""
+	}
+	unqualified := func(*types.Package) string { return "" }
+	args := make([]string, named.TypeArgs().Len())
+	for i := range args {
+		args[i] = types.TypeString(named.TypeArgs().At(i), unqualified)
+	}
+	return a.genericInstanceName(obj.Name(), args)
+}
+
+func absPath(path string) string {
+	if abs, err := filepath.Abs(path); err == nil {
+		return abs
+	}
+	return path
+}
@@ -2,6 +2,7 @@ package analyzer
 
 import (
 	"go/ast"
+	"path/filepath"
 )
 
 func (a *Analyzer) isHTTPMethod(method string) bool {
@@ -14,40 +15,222 @@ func (a *Analyzer) isHTTPMethod(method string) bool {
 	return false
 }
 
-func (a *Analyzer) isFiberHandler(funcDecl *ast.FuncDecl) bool {
-	if funcDecl.Type.Params == nil || len(funcDecl.Type.Params.List) != 1 {
+// isHandlerFunc checks whether the function takes the framework's handler
+// context as its only parameter (e.g. func(c *fiber.Ctx) error), or is a
+// plain net/http handler for frameworks built on net/http
+func (a *Analyzer) isHandlerFunc(funcDecl *ast.FuncDecl) bool {
+	if a.adapter != nil {
+		if _, ok := a.adapter.MatchHandler(funcDecl); ok {
+			return true
+		}
+	}
+	if a.framework.netHTTP {
+		return a.isNetHTTPHandler(funcDecl)
+	}
+
+	contextArg := a.framework.contextArg
+	if funcDecl.Type.Params == nil || len(funcDecl.Type.Params.List) != contextArg+1 {
 		return false
 	}
+	// Hertz passes a context.Context ahead of the request context
+	for _, field := range funcDecl.Type.Params.List[:contextArg] {
+		if selExpr, ok := field.Type.(*ast.SelectorExpr); !ok || selExpr.Sel.Name != "Context" {
+			return false
+		}
+	}
 
-	param := funcDecl.Type.Params.List[0]
-	if starExpr, ok := param.Type.(*ast.StarExpr); ok {
-		if selExpr, ok := starExpr.X.(*ast.SelectorExpr); ok {
-			return selExpr.Sel.Name == "Ctx"
+	paramType := funcDecl.Type.Params.List[contextArg].Type
+	if starExpr, ok := paramType.(*ast.StarExpr); ok {
+		if !a.framework.contextPointer {
+			return false
 		}
+		paramType = starExpr.X
+	} else if a.framework.contextPointer && !a.framework.contextValue {
+		return false
+	}
+
+	if selExpr, ok := paramType.(*ast.SelectorExpr); ok {
+		return selExpr.Sel.Name == a.framework.contextType
 	}
 
 	return false
 }
 
+// isNetHTTPHandler checks for func(w http.ResponseWriter, r *http.Request),
+// optionally followed by ps httprouter.Params for httprouter
+func (a *Analyzer) isNetHTTPHandler(funcDecl *ast.FuncDecl) bool {
+	if funcDecl.Type.Params == nil {
+		return false
+	}
+	paramCount := len(funcDecl.Type.Params.List)
+	if paramCount != 2 && !(paramCount == 3 && a.framework.routerParams) {
+		return false
+	}
+
+	if paramCount == 3 {
+		params, ok := funcDecl.Type.Params.List[2].Type.(*ast.SelectorExpr)
+		if !ok || params.Sel.Name != "Params" {
+			return false
+		}
+	}
+
+	writer, ok := funcDecl.Type.Params.List[0].Type.(*ast.SelectorExpr)
+	if !ok || writer.Sel.Name != "ResponseWriter" {
+		return false
+	}
+
+	starExpr, ok := funcDecl.Type.Params.List[1].Type.(*ast.StarExpr)
+	if !ok {
+		return false
+	}
+	request, ok := starExpr.X.(*ast.SelectorExpr)
+	return ok && request.Sel.Name == "Request"
+}
+
+// handlerContextName returns the name of the handler's context parameter
+// (the *http.Request for net/http handlers)
+func (a *Analyzer) handlerContextName(funcDecl *ast.FuncDecl) string {
+	if a.adapter != nil {
+		if name, ok := a.adapter.MatchHandler(funcDecl); ok {
+			return name
+		}
+	}
+	if a.framework.netHTTP {
+		return paramName(funcDecl, 1, "r")
+	}
+	return paramName(funcDecl, a.framework.contextArg, "c")
+}
+
+// paramName returns the name of the function's index-th parameter
+func paramName(funcDecl *ast.FuncDecl, index int, fallback string) string {
+	params := funcDecl.Type.Params.List
+	if index < len(params) && len(params[index].Names) > 0 {
+		return params[index].Names[0].Name
+	}
+	return fallback
+}
+
+// isRouterParamCall checks if the call is ps.ByName() on the handler's
+// httprouter.Params argument, or r.PathValue() on a Go 1.22 ServeMux request
+func (a *Analyzer) isRouterParamCall(callExpr *ast.CallExpr) bool {
+	if len(callExpr.Args) != 1 {
+		return false
+	}
+	if a.framework.patternRoutes {
+		if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok && selExpr.Sel.Name == "PathValue" {
+			ident, ok := selExpr.X.(*ast.Ident)
+			return ok && ident.Name == a.contextName
+		}
+		return false
+	}
+	if !a.framework.routerParams {
+		return false
+	}
+	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok && selExpr.Sel.Name == "ByName" {
+		if ident, ok := selExpr.X.(*ast.Ident); ok {
+			return ident.Name == a.paramsName
+		}
+	}
+	return false
+}
+
 func (a *Analyzer) isBodyParserCall(callExpr *ast.CallExpr) bool {
 	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-		return selExpr.Sel.Name == "BodyParser"
+		if a.isBindCall(selExpr.X) {
+			return containsString(a.framework.bindBody, selExpr.Sel.Name)
+		}
+		return containsString(a.framework.bodyParsers, selExpr.Sel.Name)
 	}
 	return false
 }
 
 func (a *Analyzer) isJSONResponseCall(callExpr *ast.CallExpr) bool {
 	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
-		return selExpr.Sel.Name == "JSON"
+		// c.Bind().JSON(&req) binds a request rather than responding
+		return !a.isBindCall(selExpr.X) && containsString(a.framework.jsonMethods, selExpr.Sel.Name)
 	}
 	return false
 }
 
 func (a *Analyzer) isQueryParserCall(callExpr *ast.CallExpr) bool {
 	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok {
+		if a.isBindCall(selExpr.X) {
+			return containsString(a.framework.bindQuery, selExpr.Sel.Name)
+		}
 		if ident, ok := selExpr.X.(*ast.Ident); ok {
-			return ident.Name == "c" && selExpr.Sel.Name == "QueryParser"
+			return ident.Name == a.contextName && containsString(a.framework.queryParsers, selExpr.Sel.Name)
 		}
 	}
 	return false
-}
\ No newline at end of file
+}
+
+// isBindCall checks if the expression is Fiber v3's c.Bind()
+func (a *Analyzer) isBindCall(expr ast.Expr) bool {
+	if len(a.framework.bindBody) == 0 && len(a.framework.bindQuery) == 0 {
+		return false
+	}
+	callExpr, ok := expr.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	if selExpr, ok := callExpr.Fun.(*ast.SelectorExpr); ok && selExpr.Sel.Name == "Bind" {
+		if ident, ok := selExpr.X.(*ast.Ident); ok {
+			return ident.Name == a.contextName
+		}
+	}
+	return false
+}
+
+// isStubHandler checks whether a handler body is empty or does nothing but
+// return nil / a "not implemented" error or status
+func (a *Analyzer) isStubHandler(funcDecl *ast.FuncDecl) bool {
+	if funcDecl.Body == nil || len(funcDecl.Body.List) == 0 {
+		return true
+	}
+	if len(funcDecl.Body.List) != 1 {
+		return false
+	}
+
+	returnStmt, ok := funcDecl.Body.List[0].(*ast.ReturnStmt)
+	if !ok {
+		// Frameworks without error returns: a single c.Status(501)-style call
+		if exprStmt, ok := funcDecl.Body.List[0].(*ast.ExprStmt); ok {
+			return a.mentionsNotImplemented(exprStmt.X)
+		}
+		return false
+	}
+	if len(returnStmt.Results) == 0 {
+		return true
+	}
+	// return nil is a live handler answering 200, like a health check
+	return a.mentionsNotImplemented(returnStmt.Results[0])
+}
+
+// mentionsNotImplemented reports whether an expression references
+// ErrNotImplemented or a 501 status, StatusNotImplemented or a literal 501
+func (a *Analyzer) mentionsNotImplemented(expr ast.Expr) bool {
+	found := false
+	ast.Inspect(expr, func(n ast.Node) bool {
+		switch node := n.(type) {
+		case *ast.Ident:
+			if node.Name == "ErrNotImplemented" || node.Name == "StatusNotImplemented" {
+				found = true
+			}
+		case *ast.BasicLit:
+			if node.Value == "501" {
+				found = true
+			}
+		}
+		return !found
+	})
+	return found
+}
+
+// relativePath returns a slash-separated path relative to the project root
+func (a *Analyzer) relativePath(path string) string {
+	rel, err := filepath.Rel(a.projectPath, path)
+	if err != nil {
+		return filepath.ToSlash(path)
+	}
+	return filepath.ToSlash(rel)
+}
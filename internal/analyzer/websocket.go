@@ -0,0 +1,94 @@
+package analyzer
+
+import (
+	"go/ast"
+	"go/types"
+	"path/filepath"
+)
+
+// websocketPrefix marks the handler names of WebSocket routes, see
+// websocketKey
+const websocketPrefix = "ws@"
+
+// websocketKey returns the handler name of a Fiber WebSocket route,
+// websocket.New(handleChat), marked with websocketPrefix. Inline handlers,
+// websocket.New(func(c *websocket.Conn) { ... }), are named WebSocket. It
+// returns "" if the call isn't a WebSocket handler.
+func (a *Analyzer) websocketKey(callExpr *ast.CallExpr) string {
+	selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+	if !ok || selExpr.Sel.Name != "New" || len(callExpr.Args) == 0 {
+		return ""
+	}
+	if pkg, ok := selExpr.X.(*ast.Ident); !ok || pkg.Name != "websocket" {
+		return ""
+	}
+
+	name := "WebSocket"
+	switch handler := callExpr.Args[0].(type) {
+	case *ast.Ident:
+		name = handler.Name
+	case *ast.SelectorExpr:
+		if method := a.methodHandlerName(handler); method != "" {
+			name = method
+		}
+	}
+	return websocketPrefix + name
+}
+
+// upgradesWebSocket reports whether a handler upgrades its connection to a
+// WebSocket itself: upgrader.Upgrade(w, r, nil) with gorilla/websocket, or
+// websocket.Accept(w, r, nil) with nhooyr.io/websocket. The upgrader must
+// be a websocket Upgrader, by its checked type or, without type
+// information, by the handler's file importing a websocket package, so
+// other Upgrade methods taking three arguments aren't mistaken for it.
+func (a *Analyzer) upgradesWebSocket(funcDecl *ast.FuncDecl) bool {
+	if funcDecl.Body == nil {
+		return false
+	}
+	upgrades := false
+	ast.Inspect(funcDecl.Body, func(n ast.Node) bool {
+		callExpr, ok := n.(*ast.CallExpr)
+		if !ok || upgrades {
+			return !upgrades
+		}
+		selExpr, ok := callExpr.Fun.(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		switch selExpr.Sel.Name {
+		case "Upgrade":
+			upgrades = len(callExpr.Args) == 3 && a.isUpgrader(selExpr.X)
+		case "Accept":
+			pkg, ok := selExpr.X.(*ast.Ident)
+			upgrades = ok && isWebSocketImport(a.handlerImports[pkg.Name])
+		}
+		return !upgrades
+	})
+	return upgrades
+}
+
+// isUpgrader reports whether an expression is a websocket Upgrader, or a
+// pointer to one
+func (a *Analyzer) isUpgrader(expr ast.Expr) bool {
+	if t, ok := a.exprType(expr); ok {
+		if ptr, isPtr := t.(*types.Pointer); isPtr {
+			t = ptr.Elem()
+		}
+		named, isNamed := t.(*types.Named)
+		return isNamed && named.Obj().Name() == "Upgrader" && named.Obj().Pkg() != nil &&
+			isWebSocketImport(named.Obj().Pkg().Path())
+	}
+	for _, path := range a.handlerImports {
+		if isWebSocketImport(path) {
+			return true
+		}
+	}
+	return false
+}
+
+// isWebSocketImport reports whether an import path is a websocket package,
+// like github.com/gorilla/websocket, github.com/fasthttp/websocket or
+// nhooyr.io/websocket
+func isWebSocketImport(path string) bool {
+	return path != "" && filepath.Base(path) == "websocket"
+}
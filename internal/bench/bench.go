@@ -0,0 +1,106 @@
+package bench
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"time"
+)
+
+// DefaultName is the baseline file the bench subcommand compares against
+const DefaultName = "openapigen-bench.json"
+
+// NoiseMillis is the slowdown below which timings never regress, so small
+// projects measured in a few milliseconds don't fail on scheduler noise
+const NoiseMillis = 5
+
+// Result is the measured cost of generating a project's spec: the medians
+// of several runs of analysis and generation
+type Result struct {
+	Time           time.Time `json:"time"`
+	ToolVersion    string    `json:"tool_version"`
+	Project        string    `json:"project"`
+	Runs           int       `json:"runs"`
+	AnalyzeMillis  float64   `json:"analyze_ms"`
+	GenerateMillis float64   `json:"generate_ms"`
+	AllocBytes     uint64    `json:"alloc_bytes"` // heap allocated by a run
+}
+
+// TotalMillis is the time of analysis and generation together
+func (r Result) TotalMillis() float64 {
+	return r.AnalyzeMillis + r.GenerateMillis
+}
+
+// Regression is a metric that got worse than the threshold allows
+type Regression struct {
+	Metric   string
+	Baseline float64
+	Current  float64
+	Unit     string
+}
+
+// Percent is how much worse the metric got
+func (r Regression) Percent() float64 {
+	return (r.Current - r.Baseline) / r.Baseline * 100
+}
+
+func (r Regression) String() string {
+	return fmt.Sprintf("%s: %.1f%s -> %.1f%s (+%.1f%%)", r.Metric, r.Baseline, r.Unit, r.Current, r.Unit, r.Percent())
+}
+
+// Compare returns the metrics of the current result that are more than
+// threshold percent worse than the baseline
+func Compare(baseline, current Result, threshold float64) []Regression {
+	var regressions []Regression
+	check := func(metric string, base, value, noise float64, unit string) {
+		if base > 0 && value-base > noise && value > base*(1+threshold/100) {
+			regressions = append(regressions, Regression{Metric: metric, Baseline: base, Current: value, Unit: unit})
+		}
+	}
+	check("total time", baseline.TotalMillis(), current.TotalMillis(), NoiseMillis, "ms")
+	check("analyze time", baseline.AnalyzeMillis, current.AnalyzeMillis, NoiseMillis, "ms")
+	check("generate time", baseline.GenerateMillis, current.GenerateMillis, NoiseMillis, "ms")
+	check("allocated memory", float64(baseline.AllocBytes)/(1<<20), float64(current.AllocBytes)/(1<<20), 0, "MiB")
+	return regressions
+}
+
+// Median returns the middle of the values, or the mean of the two middle
+// ones
+func Median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64{}, values...)
+	sort.Float64s(sorted)
+	middle := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[middle-1] + sorted[middle]) / 2
+	}
+	return sorted[middle]
+}
+
+// Read loads a baseline
+func Read(path string) (Result, error) {
+	var result Result
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return result, fmt.Errorf("failed to read baseline: %w", err)
+	}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return result, fmt.Errorf("failed to parse baseline: %w", err)
+	}
+	return result, nil
+}
+
+// Write saves a result as the baseline
+func (r Result) Write(path string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode baseline: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write baseline: %w", err)
+	}
+	return nil
+}
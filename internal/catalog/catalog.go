@@ -0,0 +1,203 @@
+package catalog
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Entity describes the Backstage API entity for a generated spec
+type Entity struct {
+	Name        string
+	Description string
+	Owner       string
+	Lifecycle   string
+	System      string
+	SpecPath    string // path to the generated spec
+}
+
+type apiEntity struct {
+	APIVersion string      `yaml:"apiVersion"`
+	Kind       string      `yaml:"kind"`
+	Metadata   apiMetadata `yaml:"metadata"`
+	Spec       apiSpec     `yaml:"spec"`
+}
+
+type apiMetadata struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description,omitempty"`
+}
+
+type apiSpec struct {
+	Type       string            `yaml:"type"`
+	Lifecycle  string            `yaml:"lifecycle"`
+	Owner      string            `yaml:"owner"`
+	System     string            `yaml:"system,omitempty"`
+	Definition map[string]string `yaml:"definition"`
+}
+
+// EntityName converts a title into a valid Backstage entity name
+func EntityName(title string) string {
+	name := regexp.MustCompile(`[^a-z0-9]+`).ReplaceAllString(strings.ToLower(title), "-")
+	name = strings.Trim(name, "-")
+	if name == "" {
+		return "api"
+	}
+	return name
+}
+
+// Write creates catalogPath with an API entity referencing the spec, or
+// updates the matching API entity in an existing (multi-document) file while
+// leaving the other entities and comments untouched
+func Write(catalogPath string, entity Entity) error {
+	definition, err := filepath.Rel(filepath.Dir(catalogPath), entity.SpecPath)
+	if err != nil {
+		definition = entity.SpecPath
+	}
+	definition = filepath.ToSlash(definition)
+	if !strings.HasPrefix(definition, ".") && !filepath.IsAbs(definition) {
+		definition = "./" + definition
+	}
+
+	docs, err := readDocuments(catalogPath)
+	if err != nil {
+		return err
+	}
+
+	updated := false
+	for _, doc := range docs {
+		if len(doc.Content) == 0 || !isAPIEntity(doc.Content[0], entity.Name) {
+			continue
+		}
+		root := doc.Content[0]
+		setPath(root, []string{"spec", "definition", "$text"}, definition)
+		setPath(root, []string{"spec", "type"}, "openapi")
+		if entity.Description != "" {
+			setPath(root, []string{"metadata", "description"}, entity.Description)
+		}
+		updated = true
+		break
+	}
+
+	if !updated {
+		var node yaml.Node
+		if err := node.Encode(newAPIEntity(entity, definition)); err != nil {
+			return fmt.Errorf("failed to build catalog entity: %w", err)
+		}
+		docs = append(docs, &yaml.Node{Kind: yaml.DocumentNode, Content: []*yaml.Node{&node}})
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(2)
+	for _, doc := range docs {
+		if err := encoder.Encode(doc); err != nil {
+			return fmt.Errorf("failed to encode catalog info: %w", err)
+		}
+	}
+	encoder.Close()
+
+	if err := os.WriteFile(catalogPath, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("failed to write catalog info: %w", err)
+	}
+	return nil
+}
+
+func newAPIEntity(entity Entity, definition string) apiEntity {
+	lifecycle := entity.Lifecycle
+	if lifecycle == "" {
+		lifecycle = "production"
+	}
+	owner := entity.Owner
+	if owner == "" {
+		owner = "unknown"
+	}
+
+	return apiEntity{
+		APIVersion: "backstage.io/v1alpha1",
+		Kind:       "API",
+		Metadata: apiMetadata{
+			Name:        entity.Name,
+			Description: entity.Description,
+		},
+		Spec: apiSpec{
+			Type:       "openapi",
+			Lifecycle:  lifecycle,
+			Owner:      owner,
+			System:     entity.System,
+			Definition: map[string]string{"$text": definition},
+		},
+	}
+}
+
+func readDocuments(path string) ([]*yaml.Node, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read catalog info: %w", err)
+	}
+
+	var docs []*yaml.Node
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc yaml.Node
+		if err := decoder.Decode(&doc); err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return nil, fmt.Errorf("failed to parse catalog info: %w", err)
+		}
+		docs = append(docs, &doc)
+	}
+	return docs, nil
+}
+
+func isAPIEntity(node *yaml.Node, name string) bool {
+	kind := lookup(node, "kind")
+	entityName := lookup(lookup(node, "metadata"), "name")
+	return kind != nil && kind.Value == "API" && entityName != nil && entityName.Value == name
+}
+
+func lookup(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setPath sets a scalar at the given key path, creating mappings as needed
+func setPath(node *yaml.Node, keys []string, value string) {
+	for i, key := range keys {
+		child := lookup(node, key)
+		last := i == len(keys)-1
+		if child == nil {
+			child = &yaml.Node{Kind: yaml.MappingNode}
+			if last {
+				child = &yaml.Node{Kind: yaml.ScalarNode}
+			}
+			node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Value: key}, child)
+		}
+		if last {
+			child.Kind, child.Tag, child.Value = yaml.ScalarNode, "!!str", value
+			child.Content = nil
+		} else if child.Kind != yaml.MappingNode {
+			child.Kind, child.Tag, child.Value = yaml.MappingNode, "", ""
+			child.Content = nil
+		}
+		node = child
+	}
+}
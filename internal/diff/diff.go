@@ -0,0 +1,133 @@
+package diff
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+	"gopkg.in/yaml.v3"
+)
+
+var httpMethods = []string{"get", "put", "post", "delete", "options", "head", "patch", "trace"}
+
+// Endpoint identifies a single operation in a spec
+type Endpoint struct {
+	Method string
+	Path   string
+}
+
+func (e Endpoint) String() string {
+	return strings.ToUpper(e.Method) + " " + e.Path
+}
+
+// Result lists endpoint level differences between two specs
+type Result struct {
+	Added   []Endpoint
+	Removed []Endpoint
+	Changed []Endpoint
+}
+
+// Empty reports whether the two specs have the same endpoints
+func (r *Result) Empty() bool {
+	return len(r.Added) == 0 && len(r.Removed) == 0 && len(r.Changed) == 0
+}
+
+// Load reads a JSON or YAML spec file into a generic document
+func Load(path string) (interface{}, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	var doc interface{}
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse spec %s: %w", path, err)
+	}
+	// Unquoted response codes such as `200:` decode as integer keys, which
+	// JSON cannot marshal when the operations are compared
+	return generator.StringKeys(doc), nil
+}
+
+// Compare diffs the operations of two specs. Either side may be a generated
+// spec struct or a document returned by Load; a nil previous spec means every
+// endpoint is new.
+func Compare(previous, current interface{}) (*Result, error) {
+	oldOps, err := operations(previous)
+	if err != nil {
+		return nil, err
+	}
+	newOps, err := operations(current)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &Result{}
+	for endpoint, op := range newOps {
+		oldOp, exists := oldOps[endpoint]
+		if !exists {
+			result.Added = append(result.Added, endpoint)
+		} else if !bytes.Equal(oldOp, op) {
+			result.Changed = append(result.Changed, endpoint)
+		}
+	}
+	for endpoint := range oldOps {
+		if _, exists := newOps[endpoint]; !exists {
+			result.Removed = append(result.Removed, endpoint)
+		}
+	}
+
+	sortEndpoints(result.Added)
+	sortEndpoints(result.Removed)
+	sortEndpoints(result.Changed)
+	return result, nil
+}
+
+// operations normalizes a spec through JSON so structs and decoded YAML
+// compare equal, and returns each operation's canonical encoding
+func operations(spec interface{}) (map[Endpoint][]byte, error) {
+	ops := make(map[Endpoint][]byte)
+	if spec == nil {
+		return ops, nil
+	}
+
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode spec: %w", err)
+	}
+
+	var doc struct {
+		Paths map[string]map[string]interface{} `json:"paths"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode spec: %w", err)
+	}
+
+	for path, item := range doc.Paths {
+		for _, method := range httpMethods {
+			op, exists := item[method]
+			if !exists || op == nil {
+				continue
+			}
+			// Re-encoding a generic value sorts map keys, giving a canonical form
+			encoded, err := json.Marshal(op)
+			if err != nil {
+				return nil, err
+			}
+			ops[Endpoint{Method: method, Path: path}] = encoded
+		}
+	}
+	return ops, nil
+}
+
+func sortEndpoints(endpoints []Endpoint) {
+	sort.Slice(endpoints, func(i, j int) bool {
+		if endpoints[i].Path != endpoints[j].Path {
+			return endpoints[i].Path < endpoints[j].Path
+		}
+		return endpoints[i].Method < endpoints[j].Method
+	})
+}
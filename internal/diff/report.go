@@ -0,0 +1,29 @@
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Summary renders the result as a short markdown message suitable for chat
+// webhooks
+func (r *Result) Summary(title string) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%s* API changes: %d added, %d changed, %d removed\n",
+		title, len(r.Added), len(r.Changed), len(r.Removed))
+
+	writeSection(&b, "Added", r.Added)
+	writeSection(&b, "Changed", r.Changed)
+	writeSection(&b, "Removed", r.Removed)
+	return b.String()
+}
+
+func writeSection(b *strings.Builder, heading string, endpoints []Endpoint) {
+	if len(endpoints) == 0 {
+		return
+	}
+	fmt.Fprintf(b, "\n*%s*\n", heading)
+	for _, endpoint := range endpoints {
+		fmt.Fprintf(b, "- `%s`\n", endpoint)
+	}
+}
@@ -0,0 +1,37 @@
+package docserver
+
+import "html/template"
+
+// indexTemplate renders Swagger UI for /openapi.json and reloads the page
+// when the server announces a new spec. It reconnects after the server
+// restarts.
+var indexTemplate = template.Must(template.New("index").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>{{.}}</title>
+  <link rel="stylesheet" href="https://unpkg.com/swagger-ui-dist@5/swagger-ui.css">
+</head>
+<body>
+  <div id="swagger-ui"></div>
+  <script src="https://unpkg.com/swagger-ui-dist@5/swagger-ui-bundle.js"></script>
+  <script>
+    window.ui = SwaggerUIBundle({ url: "/openapi.json", dom_id: "#swagger-ui" });
+
+    function connect() {
+      var scheme = location.protocol === "https:" ? "wss://" : "ws://";
+      var socket = new WebSocket(scheme + location.host + "/livereload");
+      socket.onmessage = function (event) {
+        if (JSON.parse(event.data).type === "reload") {
+          location.reload();
+        }
+      };
+      socket.onclose = function () {
+        setTimeout(connect, 1000);
+      };
+    }
+    connect();
+  </script>
+</body>
+</html>
+`))
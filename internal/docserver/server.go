@@ -0,0 +1,152 @@
+package docserver
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// reloadMessage is pushed to connected browsers after the spec changes
+const reloadMessage = `{"type":"reload"}`
+
+const writeTimeout = 5 * time.Second
+
+// Server serves Swagger UI for the current spec and pushes a reload event
+// over a websocket whenever the spec is replaced with Update
+type Server struct {
+	title string
+
+	mu      sync.RWMutex
+	spec    []byte
+	clients map[*client]bool
+}
+
+type client struct {
+	conn net.Conn
+	mu   sync.Mutex // serializes writes from broadcasts and pong replies
+}
+
+func New(title string) *Server {
+	return &Server{
+		title:   title,
+		clients: make(map[*client]bool),
+	}
+}
+
+// Update replaces the served spec and tells connected browsers to reload
+func (s *Server) Update(spec interface{}) error {
+	data, err := json.MarshalIndent(spec, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode spec: %w", err)
+	}
+
+	s.mu.Lock()
+	s.spec = data
+	clients := make([]*client, 0, len(s.clients))
+	for c := range s.clients {
+		clients = append(clients, c)
+	}
+	s.mu.Unlock()
+
+	for _, c := range clients {
+		if err := c.write(opText, []byte(reloadMessage)); err != nil {
+			s.remove(c)
+		}
+	}
+	return nil
+}
+
+// Clients returns the number of connected browsers
+func (s *Server) Clients() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.clients)
+}
+
+// Handler serves the docs page at /, the spec at /openapi.json and the
+// live-reload websocket at /livereload
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", s.serveIndex)
+	mux.HandleFunc("/openapi.json", s.serveSpec)
+	mux.HandleFunc("/livereload", s.serveLiveReload)
+	return mux
+}
+
+func (s *Server) serveIndex(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path != "/" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	if err := indexTemplate.Execute(w, s.title); err != nil {
+		fmt.Printf("Warning: failed to render docs page: %v\n", err)
+	}
+}
+
+func (s *Server) serveSpec(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	spec := s.spec
+	s.mu.RUnlock()
+
+	if spec == nil {
+		http.Error(w, "spec not generated yet", http.StatusServiceUnavailable)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Write(spec)
+}
+
+func (s *Server) serveLiveReload(w http.ResponseWriter, r *http.Request) {
+	conn, reader, err := upgrade(w, r)
+	if err != nil {
+		fmt.Printf("[DEBUG] Live-reload connection rejected: %v\n", err)
+		return
+	}
+
+	c := &client{conn: conn}
+	s.mu.Lock()
+	s.clients[c] = true
+	s.mu.Unlock()
+
+	go s.readLoop(c, reader)
+}
+
+// readLoop answers pings and waits for the browser to go away
+func (s *Server) readLoop(c *client, reader *bufio.Reader) {
+	defer s.remove(c)
+	for {
+		opcode, payload, err := readFrame(reader)
+		if err != nil {
+			return
+		}
+		switch opcode {
+		case opPing:
+			if err := c.write(opPong, payload); err != nil {
+				return
+			}
+		case opClose:
+			c.write(opClose, nil)
+			return
+		}
+	}
+}
+
+func (s *Server) remove(c *client) {
+	s.mu.Lock()
+	delete(s.clients, c)
+	s.mu.Unlock()
+	c.conn.Close()
+}
+
+func (c *client) write(opcode byte, payload []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	return writeFrame(c.conn, opcode, payload)
+}
@@ -0,0 +1,78 @@
+package docserver
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// skippedDirs are never scanned for changes
+var skippedDirs = map[string]bool{
+	".git":         true,
+	"vendor":       true,
+	"node_modules": true,
+}
+
+// fileState is what a change is detected from
+type fileState struct {
+	modTime time.Time
+	size    int64
+}
+
+// Watch polls the .go files under root every interval and calls changed
+// when any is added, removed or modified. It blocks until stop is closed.
+// Polling keeps the tool free of platform-specific file notification
+// dependencies; a project's sources are small enough to stat each tick.
+func Watch(root string, interval time.Duration, stop <-chan struct{}, changed func()) {
+	previous := snapshot(root)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			current := snapshot(root)
+			if !sameSnapshot(previous, current) {
+				previous = current
+				changed()
+			}
+		}
+	}
+}
+
+func snapshot(root string) map[string]fileState {
+	files := make(map[string]fileState)
+	filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			// Files can disappear mid-walk while an editor saves
+			return nil
+		}
+		if info.IsDir() {
+			if path != root && (skippedDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if strings.HasSuffix(path, ".go") && !strings.HasSuffix(path, "_test.go") {
+			files[path] = fileState{modTime: info.ModTime(), size: info.Size()}
+		}
+		return nil
+	})
+	return files
+}
+
+func sameSnapshot(a, b map[string]fileState) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for path, state := range a {
+		other, exists := b[path]
+		if !exists || !state.modTime.Equal(other.modTime) || state.size != other.size {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,160 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+)
+
+// jobIDFields are the JSON names of response fields identifying an
+// asynchronous job, lowercased without separators
+var jobIDFields = []string{"jobid", "taskid", "operationid"}
+
+// asyncOperation is an operation answering 202 Accepted, linked to the
+// operation reporting the job's status once all paths are known
+type asyncOperation struct {
+	operation *Operation
+	route     analyzer.Route
+	idField   string // JSON name of the job ID field of the response, if any
+}
+
+// asyncJob reports whether a route starts an asynchronous job: it has an
+// @async annotation, its handler answers 202 Accepted, or it isn't a GET
+// and its response has a job ID field, like job_id. It returns the JSON
+// name of that field, if any.
+func (g *Generator) asyncJob(route analyzer.Route) (string, bool) {
+	idField := ""
+	if route.Response != nil && !route.ResponseArray {
+		idField = g.jobIDField(*route.Response)
+	}
+	if _, annotated := route.Annotations["async"]; annotated || route.Accepted {
+		return idField, true
+	}
+	method := strings.ToUpper(route.Method)
+	return idField, idField != "" && method != "GET" && method != "HEAD"
+}
+
+// jobIDField returns the JSON name of a model's job ID field, or ""
+func (g *Generator) jobIDField(model analyzer.Model) string {
+	for _, field := range model.Fields {
+		name := strings.Split(field.JSONTag, ",")[0]
+		if name == "-" {
+			continue
+		}
+		if name == "" {
+			name = g.toSnakeCase(field.Name)
+		}
+		normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(name))
+		for _, jobID := range jobIDFields {
+			if normalized == jobID {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// acceptedResponse is the 202 response of a route starting an asynchronous
+// job: the job as the handler responds with it, where to poll its status
+// and when
+func (g *Generator) acceptedResponse(route analyzer.Route) Response {
+	response := Response{
+		Description: "Accepted: the job runs asynchronously",
+		Headers: map[string]Header{
+			"Location": {
+				Description: "URL of the job's status",
+				Schema:      Schema{Type: "string", Format: "uri-reference"},
+			},
+			"Retry-After": {
+				Description: "Seconds to wait before polling the job's status",
+				Schema:      Schema{Type: "integer"},
+			},
+		},
+	}
+	if route.Response != nil && !g.isSkippedModel(route.Response.Name) {
+		response.Content = map[string]MediaType{
+			"application/json": {Schema: g.responseSchema(route)},
+		}
+	}
+	return response
+}
+
+// linkJobStatus links the 202 responses of the operations starting
+// asynchronous jobs to the GET operation reporting the job's status, passing
+// it the job ID from the response
+func (g *Generator) linkJobStatus(spec *OpenAPISpec) {
+	for _, async := range g.asyncOps {
+		path, status := g.jobStatusOperation(spec, async)
+		if status == nil {
+			fmt.Printf("[DEBUG] No job status operation found for %s %s (%s); its 202 response isn't linked\n", async.route.Method, async.route.Path, async.route.Handler)
+			continue
+		}
+		link := Link{OperationID: status.OperationID, Description: "Status of the job"}
+		if params := pathParamPattern.FindAllString(path, -1); len(params) > 0 && async.idField != "" {
+			link.Parameters = map[string]string{strings.Trim(params[len(params)-1], "{}"): "$response.body#/" + async.idField}
+		}
+		response := async.operation.Responses["202"]
+		response.Links = map[string]Link{"status": link}
+		async.operation.Responses["202"] = response
+	}
+}
+
+// jobStatusOperation finds the GET operation reporting the status of an
+// asynchronous job: the path of the @async annotation, with or without the
+// prefix of its route package, or else the only GET of a single resource
+// with the job's response model, or else the only one under a path named
+// after the job ID field, like /jobs/{id} for job_id
+func (g *Generator) jobStatusOperation(spec *OpenAPISpec, async asyncOperation) (string, *Operation) {
+	var paths, annotated []string
+	annotation := async.route.Annotations["async"]
+	if annotation != "" {
+		annotation = g.convertPathFormat(annotation)
+	}
+	for path, item := range spec.Paths {
+		if item.Get == nil {
+			continue
+		}
+		if strings.HasSuffix(path, "}") || strings.HasSuffix(path, "}/status") {
+			paths = append(paths, path)
+		}
+		// The annotated path may leave out the prefix of the route package
+		if annotation != "" && strings.HasSuffix(path, annotation) {
+			annotated = append(annotated, path)
+		}
+	}
+	if annotation != "" {
+		sort.Strings(annotated)
+		if len(annotated) > 0 && (len(annotated) == 1 || annotated[0] == annotation) {
+			return annotated[0], spec.Paths[annotated[0]].Get
+		}
+		return "", nil
+	}
+	sort.Strings(paths)
+
+	var sameModel, named []string
+	jobRef := async.operation.Responses["202"].Content["application/json"].Schema.Ref
+	// job_id, jobId and jobID name the job resource
+	resource := ""
+	if async.idField != "" {
+		resource = strings.ToLower(strings.TrimRight(async.idField[:len(async.idField)-2], "_-"))
+	}
+	for _, path := range paths {
+		if ref := spec.Paths[path].Get.Responses["200"].Content["application/json"].Schema.Ref; jobRef != "" && ref == jobRef {
+			sameModel = append(sameModel, path)
+		}
+		segments := strings.Split(strings.TrimSuffix(path, "/status"), "/")
+		if resource != "" && len(segments) > 1 {
+			if parent := strings.ToLower(segments[len(segments)-2]); parent == resource || parent == resource+"s" {
+				named = append(named, path)
+			}
+		}
+	}
+	for _, candidates := range [][]string{sameModel, named} {
+		if len(candidates) == 1 {
+			return candidates[0], spec.Paths[candidates[0]].Get
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,158 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MergeIntoBase injects the generated paths, components and tags into a
+// hand-maintained base document, keeping everything else of the base as
+// written: info, servers, security, extensions, key order and comments.
+// Generated paths and schemas replace the base's entries of the same name;
+// the base's security schemes are kept over generated ones.
+func MergeIntoBase(spec *OpenAPISpec, basePath string) (*yaml.Node, error) {
+	data, err := os.ReadFile(basePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read base spec: %w", err)
+	}
+	var base yaml.Node
+	if err := yaml.Unmarshal(data, &base); err != nil {
+		return nil, fmt.Errorf("failed to parse base spec %s: %w", basePath, err)
+	}
+	if base.Kind != yaml.DocumentNode || len(base.Content) == 0 || base.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("base spec %s is not a YAML or JSON object", basePath)
+	}
+
+	var generated yaml.Node
+	if err := generated.Encode(spec); err != nil {
+		return nil, fmt.Errorf("failed to encode generated spec: %w", err)
+	}
+
+	root := base.Content[0]
+	mergeMapping(root, "paths", mappingValue(&generated, "paths"), func(path string) {
+		fmt.Printf("Warning: generated path %s replaces the one in %s\n", path, basePath)
+	})
+
+	if components := mappingValue(&generated, "components"); components != nil {
+		baseComponents := mappingValue(root, "components")
+		if baseComponents == nil {
+			baseComponents = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+			setMappingValue(root, "components", baseComponents)
+		}
+		for i := 0; i+1 < len(components.Content); i += 2 {
+			section, entries := components.Content[i].Value, components.Content[i+1]
+			if section == "securitySchemes" {
+				addMissing(baseComponents, section, entries)
+				continue
+			}
+			mergeMapping(baseComponents, section, entries, nil)
+		}
+	}
+
+	if tags := mappingValue(&generated, "tags"); tags != nil {
+		baseTags := mappingValue(root, "tags")
+		if baseTags == nil {
+			setMappingValue(root, "tags", tags)
+		} else {
+			names := make(map[string]bool)
+			for _, tag := range baseTags.Content {
+				if name := mappingValue(tag, "name"); name != nil {
+					names[name.Value] = true
+				}
+			}
+			for _, tag := range tags.Content {
+				if name := mappingValue(tag, "name"); name != nil && !names[name.Value] {
+					baseTags.Content = append(baseTags.Content, tag)
+				}
+			}
+		}
+	}
+
+	return &base, nil
+}
+
+// mergeMapping sets the entries of the mapping under key in node, creating
+// it if needed. replaced is called with the keys of replaced entries.
+func mergeMapping(node *yaml.Node, key string, entries *yaml.Node, replaced func(key string)) {
+	if entries == nil || entries.Kind != yaml.MappingNode {
+		return
+	}
+	target := mappingValue(node, key)
+	if target == nil || target.Kind != yaml.MappingNode {
+		setMappingValue(node, key, entries)
+		return
+	}
+	for i := 0; i+1 < len(entries.Content); i += 2 {
+		if mappingValue(target, entries.Content[i].Value) != nil && replaced != nil {
+			replaced(entries.Content[i].Value)
+		}
+		setMappingValue(target, entries.Content[i].Value, entries.Content[i+1])
+	}
+}
+
+// addMissing adds the entries of the mapping under key in node that it
+// doesn't have yet
+func addMissing(node *yaml.Node, key string, entries *yaml.Node) {
+	target := mappingValue(node, key)
+	if target == nil || target.Kind != yaml.MappingNode {
+		setMappingValue(node, key, entries)
+		return
+	}
+	for i := 0; i+1 < len(entries.Content); i += 2 {
+		if mappingValue(target, entries.Content[i].Value) == nil {
+			setMappingValue(target, entries.Content[i].Value, entries.Content[i+1])
+		}
+	}
+}
+
+// mappingValue returns the value of key in a mapping node, or nil
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// setMappingValue replaces the value of key in a mapping node, or appends
+// the key
+func setMappingValue(node *yaml.Node, key string, value *yaml.Node) {
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			node.Content[i+1] = value
+			return
+		}
+	}
+	node.Content = append(node.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+}
+
+// StringKeys converts the map[interface{}]interface{} values YAML decodes
+// for mappings with non-string keys, such as unquoted `200:` response codes,
+// into map[string]interface{} so the document can be marshaled as JSON
+func StringKeys(value interface{}) interface{} {
+	switch v := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(v))
+		for key, item := range v {
+			converted[fmt.Sprint(key)] = StringKeys(item)
+		}
+		return converted
+	case map[string]interface{}:
+		for key, item := range v {
+			v[key] = StringKeys(item)
+		}
+		return v
+	case []interface{}:
+		for i, item := range v {
+			v[i] = StringKeys(item)
+		}
+		return v
+	}
+	return value
+}
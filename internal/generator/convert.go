@@ -5,9 +5,13 @@ import (
 )
 
 func (g *Generator) convertPathFormat(path string) string {
-	// Convert :param to {param}
-	re := regexp.MustCompile(`:([a-zA-Z][a-zA-Z0-9_]*)`)
-	converted := re.ReplaceAllString(path, "{$1}")
+	// Strip regex constraints from {param:regex}
+	constrained := regexp.MustCompile(`\{([a-zA-Z][a-zA-Z0-9_]*):[^}]*\}`)
+	converted := constrained.ReplaceAllString(path, "{$1}")
+
+	// Convert :param and named catch-alls (*param) to {param}
+	re := regexp.MustCompile(`[:*]([a-zA-Z][a-zA-Z0-9_]*)`)
+	converted = re.ReplaceAllString(converted, "{$1}")
 
 	// Ensure the path starts with /
 	if !strings.HasPrefix(converted, "/") {
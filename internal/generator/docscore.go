@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"math"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// generatedDescription matches the operation descriptions generateDescription
+// makes up when the handler has none
+var generatedDescription = regexp.MustCompile(`^\S+ handler for [a-z]+ \S+$`)
+
+// DocScore measures how well the operations of a tag and the schemas they
+// use are documented
+type DocScore struct {
+	Tag               string `json:"tag"`
+	Score             int    `json:"score"` // 0 to 100, the average of the ratios below
+	Operations        int    `json:"operations"`
+	DescribedOps      int    `json:"described_operations"` // with a summary and a description of their own
+	Fields            int    `json:"fields"`               // parameters and properties of the schemas used
+	DescribedFields   int    `json:"described_fields"`
+	FieldsWithExample int    `json:"fields_with_example"`
+}
+
+// docScoreCounter accumulates the counts of a tag
+type docScoreCounter struct {
+	score   DocScore
+	schemas map[string]bool // component schemas counted, each once per tag
+}
+
+// DocScores computes the documentation score of each tag of a spec, sorted
+// by tag; operations without tags are scored under ""
+func DocScores(spec *OpenAPISpec) []DocScore {
+	counters := make(map[string]*docScoreCounter)
+	for path, item := range spec.Paths {
+		for _, operation := range []*Operation{item.Get, item.Post, item.Put, item.Delete, item.Patch} {
+			if operation == nil {
+				continue
+			}
+			tags := operation.Tags
+			if len(tags) == 0 {
+				tags = []string{""}
+			}
+			for _, tag := range tags {
+				counter := counters[tag]
+				if counter == nil {
+					counter = &docScoreCounter{score: DocScore{Tag: tag}, schemas: make(map[string]bool)}
+					counters[tag] = counter
+				}
+				counter.addOperation(spec, path, operation)
+			}
+		}
+	}
+
+	scores := make([]DocScore, 0, len(counters))
+	for _, counter := range counters {
+		scores = append(scores, counter.total())
+	}
+	sort.Slice(scores, func(i, j int) bool { return scores[i].Tag < scores[j].Tag })
+	return scores
+}
+
+func (c *docScoreCounter) addOperation(spec *OpenAPISpec, path string, operation *Operation) {
+	c.score.Operations++
+	if operation.Summary != "" && operation.Description != "" && !generatedDescription.MatchString(operation.Description) {
+		c.score.DescribedOps++
+	}
+	for _, param := range operation.Parameters {
+		c.addField(param.Description, param.Example != nil || param.Schema.Example != nil)
+		c.addSchema(spec, param.Schema)
+	}
+	if operation.RequestBody != nil {
+		for _, media := range operation.RequestBody.Content {
+			c.addSchema(spec, media.Schema)
+		}
+	}
+	for _, response := range operation.Responses {
+		for _, media := range response.Content {
+			c.addSchema(spec, media.Schema)
+		}
+	}
+}
+
+func (c *docScoreCounter) addField(description string, example bool) {
+	c.score.Fields++
+	if strings.TrimSpace(description) != "" {
+		c.score.DescribedFields++
+	}
+	if example {
+		c.score.FieldsWithExample++
+	}
+}
+
+// addSchema counts the properties of a schema and the schemas it uses
+func (c *docScoreCounter) addSchema(spec *OpenAPISpec, schema Schema) {
+	if schema.Ref != "" {
+		name := strings.TrimPrefix(schema.Ref, "#/components/schemas/")
+		resolved, exists := spec.Components.Schemas[name]
+		if c.schemas[name] || !exists {
+			return
+		}
+		c.schemas[name] = true
+		c.addSchema(spec, resolved)
+		return
+	}
+	names := make([]string, 0, len(schema.Properties))
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		property := schema.Properties[name]
+		// A referenced schema's description documents the property too
+		description := property.Description
+		if resolved, exists := spec.Components.Schemas[strings.TrimPrefix(property.Ref, "#/components/schemas/")]; property.Ref != "" && exists {
+			description = resolved.Description
+		}
+		c.addField(description, property.Example != nil)
+		c.addSchema(spec, property)
+	}
+	if schema.Items != nil {
+		c.addSchema(spec, *schema.Items)
+	}
+	if values, ok := schema.AdditionalProperties.(*Schema); ok {
+		c.addSchema(spec, *values)
+	}
+	for _, parts := range [][]Schema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, part := range parts {
+			c.addSchema(spec, part)
+		}
+	}
+}
+
+// total computes the score from the counts
+func (c *docScoreCounter) total() DocScore {
+	score := c.score
+	var ratios []float64
+	if score.Operations > 0 {
+		ratios = append(ratios, float64(score.DescribedOps)/float64(score.Operations))
+	}
+	if score.Fields > 0 {
+		ratios = append(ratios,
+			float64(score.DescribedFields)/float64(score.Fields),
+			float64(score.FieldsWithExample)/float64(score.Fields))
+	}
+	sum := 0.0
+	for _, ratio := range ratios {
+		sum += ratio
+	}
+	if len(ratios) > 0 {
+		score.Score = int(math.Round(sum / float64(len(ratios)) * 100))
+	}
+	return score
+}
+
+// scoreTags sets the x-doc-score of the spec's tags
+func (g *Generator) scoreTags(spec *OpenAPISpec) {
+	scores := make(map[string]int)
+	for _, score := range DocScores(spec) {
+		scores[score.Tag] = score.Score
+	}
+	for i, tag := range spec.Tags {
+		if score, exists := scores[tag.Name]; exists {
+			spec.Tags[i].DocScore = &score
+		}
+	}
+}
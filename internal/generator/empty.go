@@ -0,0 +1,65 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+)
+
+// Policies for models without exported fields, set with Config.EmptyStructs
+const (
+	EmptyObject   = "object"
+	EmptyFreeForm = "free-form"
+	EmptySkip     = "skip"
+)
+
+// findEmptyModels records the models whose schema has no properties, like
+// marker structs or structs with only unexported fields, and with the skip
+// policy leaves them out of the models schemas may reference
+func (g *Generator) findEmptyModels(models map[string]analyzer.Model) {
+	g.emptyModels = make(map[string]bool)
+	switch g.config.EmptyStructs {
+	case "", EmptyObject, EmptyFreeForm, EmptySkip:
+	default:
+		fmt.Printf("Warning: unknown empty struct policy '%s'; use object, free-form or skip\n", g.config.EmptyStructs)
+	}
+
+	var skipped []string
+	for _, model := range models {
+		if len(g.modelSchema(model, map[string]bool{model.Name: true}).Properties) > 0 {
+			continue
+		}
+		// Types with a MarshalJSON method often keep their state unexported
+		if _, custom := g.jsonTypeSchema(model.Name); custom {
+			continue
+		}
+		name := g.cleanSchemaName(model.Name)
+		g.emptyModels[name] = true
+		if g.config.EmptyStructs == EmptySkip {
+			delete(g.models, name)
+			skipped = append(skipped, name)
+		}
+	}
+	sort.Strings(skipped)
+	for _, name := range skipped {
+		fmt.Printf("Warning: %s has no exported fields; it is left out of the spec, and bodies of that type are not documented\n", name)
+	}
+}
+
+// emptyModelSchema is the schema of a model without exported fields: an
+// object with neither properties nor required ones, which accepts any
+// properties unless the free-form policy says so explicitly
+func (g *Generator) emptyModelSchema(model analyzer.Model) Schema {
+	schema := Schema{Type: "object", Description: model.Description}
+	if g.config.EmptyStructs == EmptyFreeForm {
+		schema.AdditionalProperties = true
+	}
+	return schema
+}
+
+// isSkippedModel reports whether a model is left out of the spec by the
+// skip policy for empty structs
+func (g *Generator) isSkippedModel(name string) bool {
+	return g.config.EmptyStructs == EmptySkip && g.emptyModels[g.cleanSchemaName(name)]
+}
@@ -0,0 +1,110 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// defaultMaxInlineEnum is the largest enum repeated inline when the config
+// doesn't set a limit
+const defaultMaxInlineEnum = 20
+
+// enumSchema adds the enum values to schema. Enums larger than the
+// configured limit are emitted once as a named schema and referenced, so
+// long lists like country codes aren't repeated at every usage.
+func (g *Generator) enumSchema(schema Schema, name string, values []string) Schema {
+	enum := make([]interface{}, len(values))
+	for i, v := range values {
+		enum[i] = enumValue(schema.Type, v)
+	}
+
+	limit := g.config.MaxInlineEnum
+	if limit == 0 {
+		limit = defaultMaxInlineEnum
+	}
+	if limit < 0 || len(values) <= limit || schema.Ref != "" {
+		schema.Enum = enum
+		return schema
+	}
+
+	named := Schema{Type: schema.Type, Format: schema.Format, Enum: enum}
+	if named.Type == "" {
+		named.Type = "string"
+	}
+	schemaName := g.enumSchemaName(name, named)
+	g.enums[schemaName] = named
+	return Schema{Ref: "#/components/schemas/" + schemaName}
+}
+
+// enumValue types an enum value after its schema, so the constants of an
+// integer type like Priority are numbers
+func enumValue(schemaType, value string) interface{} {
+	switch schemaType {
+	case "integer":
+		if number, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return number
+		}
+	case "number":
+		if number, err := strconv.ParseFloat(value, 64); err == nil {
+			return number
+		}
+	}
+	return value
+}
+
+// builtinSchemas are the component schemas the generator adds for error and
+// map responses
+var builtinSchemas = []string{"ErrorResponse", "ProblemDetails", "StandardResponse"}
+
+// enumSchemaName picks a schema name for an externalized enum, reusing the
+// name of an identical enum and avoiding the names of models, paginated
+// envelopes and the other schemas of the spec
+func (g *Generator) enumSchemaName(name string, schema Schema) string {
+	base := enumTypeName(name)
+	if g.models[base] {
+		base += "Enum"
+	}
+
+	candidate := base
+	for i := 2; ; i++ {
+		existing, isEnum := g.enums[candidate]
+		if isEnum && reflect.DeepEqual(existing, schema) {
+			return candidate
+		}
+		if !isEnum && !g.schemaTaken(candidate) {
+			return candidate
+		}
+		candidate = fmt.Sprintf("%s%d", base, i)
+	}
+}
+
+// schemaTaken reports whether a component schema name is used by a model, a
+// paginated envelope, a builtin schema or a schema already in the spec
+func (g *Generator) schemaTaken(name string) bool {
+	if _, exists := g.schemas[name]; exists || g.models[name] {
+		return true
+	}
+	if _, exists := g.pages[name]; exists {
+		return true
+	}
+	for _, builtin := range builtinSchemas {
+		if name == builtin {
+			return true
+		}
+	}
+	return false
+}
+
+// enumTypeName turns a field or parameter name like country_code into a
+// schema name like CountryCode
+func enumTypeName(name string) string {
+	var b strings.Builder
+	for _, part := range strings.FieldsFunc(name, func(r rune) bool {
+		return r == '_' || r == '-' || r == '.' || r == ' '
+	}) {
+		b.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	return b.String()
+}
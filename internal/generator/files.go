@@ -0,0 +1,23 @@
+package generator
+
+import "github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+
+// fileResponse documents the file a handler responds with, c.SendFile and
+// the like, as binary content of its media type
+func (g *Generator) fileResponse(file *analyzer.FileResponse) Response {
+	response := Response{
+		Description: "File content",
+		Content: map[string]MediaType{
+			file.MediaType: {Schema: Schema{Type: "string", Format: "binary"}},
+		},
+	}
+	if file.Attachment {
+		response.Headers = map[string]Header{
+			"Content-Disposition": {
+				Description: "Sends the file as an attachment to download, with its file name",
+				Schema:      Schema{Type: "string", Example: `attachment; filename="report.pdf"`},
+			},
+		}
+	}
+	return response
+}
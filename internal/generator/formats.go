@@ -0,0 +1,103 @@
+package generator
+
+import (
+	"strings"
+	"unicode"
+)
+
+// formatSuffixes infers the format of a named string type from the end of
+// its name, e.g. ContactEmail -> email, ISODate -> date. Longer suffixes are
+// listed first so DateTime wins over Date.
+var formatSuffixes = []struct {
+	suffix string
+	format string
+}{
+	{"datetime", "date-time"},
+	{"timestamp", "date-time"},
+	{"hostname", "hostname"},
+	{"password", "password"},
+	{"email", "email"},
+	{"ipv4", "ipv4"},
+	{"ipv6", "ipv6"},
+	{"uuid", "uuid"},
+	{"date", "date"},
+	{"url", "uri"},
+	{"uri", "uri"},
+}
+
+// wellKnownFormats are the formats of string-encoded types of common
+// libraries by package and name: uuid.UUID of google/uuid and gofrs/uuid
+var wellKnownFormats = map[string]string{
+	"uuid.UUID": "uuid",
+}
+
+// stringFormat returns the format of a named string type. Formats set in the
+// config win, then those of well-known types; otherwise it is inferred from
+// the name of string types declared in the SDK. An empty configured format switches inference off
+// for that type.
+func (g *Generator) stringFormat(typeName string) (string, bool) {
+	name := g.cleanTypeName(typeName)
+	if name == "" {
+		return "", false
+	}
+
+	if format, ok := g.config.Formats[name]; ok {
+		return format, format != ""
+	}
+	if format, ok := wellKnownFormats[strings.ReplaceAll(typeName, "*", "")]; ok {
+		return format, true
+	}
+
+	if g.namedTypes[name] != "string" {
+		return "", false
+	}
+	format := inferFormat(name)
+	return format, format != ""
+}
+
+func (g *Generator) isFormattedType(typeName string) bool {
+	if strings.HasPrefix(typeName, "[]") || strings.HasPrefix(typeName, "map[") {
+		return false
+	}
+	_, ok := g.stringFormat(typeName)
+	return ok
+}
+
+// namedUnderlying returns the underlying type of a named type or alias
+// declared in the models, like string for UserID. Models are never named
+// types.
+func (g *Generator) namedUnderlying(typeName string) (string, bool) {
+	name := g.cleanTypeName(typeName)
+	underlying, named := g.namedTypes[name]
+	if !named {
+		return "", false
+	}
+	if _, isModel := g.modelDefs[name]; isModel {
+		return "", false
+	}
+	return underlying, true
+}
+
+// withTypeName records the named type a schema was generated from in
+// x-go-type-name. References and compositions already carry a name.
+func (g *Generator) withTypeName(schema Schema, typeName string) Schema {
+	if schema.Ref == "" && len(schema.AllOf) == 0 {
+		schema.GoTypeName = g.cleanTypeName(typeName)
+	}
+	return schema
+}
+
+func inferFormat(typeName string) string {
+	lower := strings.ToLower(typeName)
+	for _, rule := range formatSuffixes {
+		if !strings.HasSuffix(lower, rule.suffix) {
+			continue
+		}
+		// The suffix must start a word: ISODate, not Update
+		start := len(typeName) - len(rule.suffix)
+		if start == 0 || unicode.IsUpper(rune(typeName[start])) || typeName[start-1] == '_' {
+			return rule.format
+		}
+	}
+	return ""
+}
@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+)
+
+// formRequestBody documents the form fields a handler reads, with
+// c.FormValue and the like, as a URL-encoded form body, or a multipart one
+// when the handler reads files
+func (g *Generator) formRequestBody(route analyzer.Route) *RequestBody {
+	if len(route.FormFields) == 0 && !route.Multipart {
+		return nil
+	}
+	if bodylessMethods[strings.ToUpper(route.Method)] {
+		fmt.Printf("[DEBUG] %s %s (%s) reads form fields; they are not documented without a request body\n", strings.ToUpper(route.Method), route.Path, route.Handler)
+		return nil
+	}
+
+	schema := Schema{Type: "object", Properties: make(map[string]Schema)}
+	for _, field := range route.FormFields {
+		var property Schema
+		switch field.Type {
+		case "file":
+			property = Schema{Type: "string", Format: "binary"}
+		case "files":
+			property = Schema{Type: "array", Items: &Schema{Type: "string", Format: "binary"}}
+		default:
+			property = g.generateParameterSchema(field)
+		}
+		property.Description = field.Description
+		property.Default = field.Default
+		schema.Properties[field.Name] = property
+		if field.Required {
+			schema.Required = append(schema.Required, field.Name)
+		}
+	}
+
+	mediaType, description := "application/x-www-form-urlencoded", "Form fields"
+	if route.Multipart {
+		mediaType, description = "multipart/form-data", "Files and form fields"
+	}
+	return &RequestBody{
+		Description: description,
+		Required:    len(schema.Required) > 0,
+		Content: map[string]MediaType{
+			mediaType: {Schema: schema},
+		},
+	}
+}
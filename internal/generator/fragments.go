@@ -0,0 +1,143 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// MergeFragments merges spec fragments into the generated spec. Fragments
+// come from parts of the project documented by a spec-first framework
+// (Huma, Fuego, oapi-codegen) and are authoritative for their operations.
+// Fragment schemas whose name is taken by a different generated schema are
+// renamed with a numeric suffix and their references updated. Fields the
+// generator doesn't model are dropped.
+func (g *Generator) MergeFragments(spec *OpenAPISpec, paths []string) error {
+	for _, path := range paths {
+		fragment, err := loadFragment(path)
+		if err != nil {
+			return err
+		}
+		g.mergeFragment(spec, fragment, path)
+	}
+	return nil
+}
+
+// loadFragment reads a YAML or JSON spec fragment
+func loadFragment(path string) (*OpenAPISpec, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec fragment: %w", err)
+	}
+	var fragment OpenAPISpec
+	if err := yaml.Unmarshal(data, &fragment); err != nil {
+		return nil, fmt.Errorf("failed to parse spec fragment %s: %w", path, err)
+	}
+	return &fragment, nil
+}
+
+func (g *Generator) mergeFragment(spec *OpenAPISpec, fragment *OpenAPISpec, source string) {
+	if spec.Paths == nil {
+		spec.Paths = make(map[string]PathItem)
+	}
+	if spec.Components.Schemas == nil {
+		spec.Components.Schemas = make(map[string]Schema)
+	}
+
+	names := make([]string, 0, len(fragment.Components.Schemas))
+	for name := range fragment.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	renames := make(map[string]string)
+	for _, name := range names {
+		existing, exists := spec.Components.Schemas[name]
+		if !exists || reflect.DeepEqual(existing, fragment.Components.Schemas[name]) {
+			continue
+		}
+		newName := name
+		for i := 2; ; i++ {
+			newName = fmt.Sprintf("%s%d", name, i)
+			_, taken := spec.Components.Schemas[newName]
+			_, takenByFragment := fragment.Components.Schemas[newName]
+			if !taken && !takenByFragment {
+				break
+			}
+		}
+		fmt.Printf("Warning: schema '%s' from %s differs from the generated one; merged as '%s'\n", name, source, newName)
+		renames[name] = newName
+	}
+	for _, name := range names {
+		target := name
+		if newName, renamed := renames[name]; renamed {
+			target = newName
+		}
+		spec.Components.Schemas[target] = g.updateSchemaReferences(fragment.Components.Schemas[name], renames)
+	}
+
+	operations := 0
+	for path, fragmentItem := range fragment.Paths {
+		pathItem := spec.Paths[path]
+		for _, method := range []string{"GET", "POST", "PUT", "DELETE", "PATCH"} {
+			operation := *pathItemOperation(&fragmentItem, method)
+			if operation == nil {
+				continue
+			}
+			g.updateOperationReferences(operation, renames)
+
+			target := pathItemOperation(&pathItem, method)
+			if *target != nil {
+				fmt.Printf("Warning: %s %s from %s replaces the generated operation\n", method, path, source)
+			}
+			*target = operation
+			operations++
+		}
+		spec.Paths[path] = pathItem
+	}
+
+	for name, scheme := range fragment.Components.SecuritySchemes {
+		if _, exists := spec.Components.SecuritySchemes[name]; !exists {
+			if spec.Components.SecuritySchemes == nil {
+				spec.Components.SecuritySchemes = make(map[string]SecurityScheme)
+			}
+			spec.Components.SecuritySchemes[name] = scheme
+		}
+	}
+
+	for _, tag := range fragment.Tags {
+		if !hasTag(spec.Tags, tag.Name) {
+			spec.Tags = append(spec.Tags, tag)
+		}
+	}
+
+	fmt.Printf("Merged %d operations and %d schemas from %s\n", operations, len(names), source)
+}
+
+// pathItemOperation returns the operation slot of a path item for a method
+func pathItemOperation(item *PathItem, method string) **Operation {
+	switch method {
+	case "GET":
+		return &item.Get
+	case "POST":
+		return &item.Post
+	case "PUT":
+		return &item.Put
+	case "DELETE":
+		return &item.Delete
+	default:
+		return &item.Patch
+	}
+}
+
+func hasTag(tags []Tag, name string) bool {
+	for _, tag := range tags {
+		if tag.Name == name {
+			return true
+		}
+	}
+	return false
+}
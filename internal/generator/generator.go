@@ -1,6 +1,7 @@
 package generator
 
 import (
+	"context"
 	"fmt"
 	"strings"
 
@@ -11,7 +12,9 @@ func New(config Config) *Generator {
 	return &Generator{config: config}
 }
 
-func (g *Generator) Generate(analysis *analyzer.Analysis) *OpenAPISpec {
+// Generate generates the spec of an analysis. It stops with the context's
+// error once the context is canceled or times out.
+func (g *Generator) Generate(ctx context.Context, analysis *analyzer.Analysis) (*OpenAPISpec, error) {
 	spec := &OpenAPISpec{
 		OpenAPI: "3.0.3",
 		Info: Info{
@@ -27,21 +30,53 @@ func (g *Generator) Generate(analysis *analyzer.Analysis) *OpenAPISpec {
 		},
 		Paths: make(map[string]PathItem),
 		Components: Components{
-			Schemas: make(map[string]Schema),
-			SecuritySchemes: map[string]SecurityScheme{
-				"bearerAuth": {
-					Type:         "http",
-					Scheme:       "bearer",
-					BearerFormat: "JWT",
-					Description:  "Authorization header using Bearer token",
-				},
-			},
+			Schemas:         make(map[string]Schema),
+			SecuritySchemes: g.securitySchemes(),
 		},
 	}
+	g.checkSecurityConfig(spec.Components.SecuritySchemes)
+
+	g.schemas = spec.Components.Schemas
+	g.enums = make(map[string]Schema)
+	g.pages = make(map[string]Schema)
+	g.models = make(map[string]bool)
+	g.asyncOps = nil
+	g.unknownStability = make(map[string][]string)
+	g.namedTypes = analysis.NamedTypes
+	g.modelDefs = analysis.Models
+	g.marshalers = analysis.Marshalers
+	g.warnMarshalers()
+	g.checkWellKnown()
+	g.checkDeadRoutes()
+	for _, model := range analysis.Models {
+		g.models[g.cleanSchemaName(model.Name)] = true
+	}
+	g.findEmptyModels(analysis.Models)
 
 	// Generate schemas from models first
 	for _, model := range analysis.Models {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("generation canceled: %w", context.Cause(ctx))
+		}
+		if g.isSkippedModel(model.Name) {
+			continue
+		}
 		schema := g.generateSchemaFromModel(model)
+		if custom, ok := g.jsonTypeSchema(model.Name); ok {
+			// Types with a MarshalJSON method don't have their fields' shape
+			custom.Description = model.Description
+			schema = custom
+		} else if g.emptyModels[g.cleanSchemaName(model.Name)] {
+			schema = g.emptyModelSchema(model)
+		}
+		// Let code generators map the schema back to the Go type
+		if model.GoPackage != "" {
+			schema.GoType = model.GoPackage + "." + model.Name
+			if model.GoName != "" {
+				schema.GoType = model.GoPackage + "." + model.GoName
+			}
+			schema.GoPackage = model.GoPackage
+		}
 		cleanName := g.cleanSchemaName(model.Name)
 		spec.Components.Schemas[cleanName] = schema
 	}
@@ -56,6 +91,20 @@ func (g *Generator) Generate(analysis *analyzer.Analysis) *OpenAPISpec {
 		}
 	}
 
+	if _, exists := spec.Components.Schemas["ProblemDetails"]; g.config.ProblemJSON && !exists {
+		spec.Components.Schemas["ProblemDetails"] = Schema{
+			Type:        "object",
+			Description: "RFC 7807 problem details",
+			Properties: map[string]Schema{
+				"type":     {Type: "string", Format: "uri", Description: "URI reference identifying the problem type", Default: "about:blank"},
+				"title":    {Type: "string", Description: "Short, human-readable summary of the problem type"},
+				"status":   {Type: "integer", Description: "HTTP status code (ErrorResponse.code)"},
+				"detail":   {Type: "string", Description: "Human-readable explanation of this occurrence (ErrorResponse.error)"},
+				"instance": {Type: "string", Format: "uri", Description: "URI reference identifying this occurrence"},
+			},
+		}
+	}
+
 	if _, exists := spec.Components.Schemas["StandardResponse"]; !exists {
 		spec.Components.Schemas["StandardResponse"] = Schema{
 			Type: "object",
@@ -67,23 +116,59 @@ func (g *Generator) Generate(analysis *analyzer.Analysis) *OpenAPISpec {
 		}
 	}
 
+	// Route names become operation IDs unless several routes share one
+	g.routeNames = make(map[string]int)
+	for _, route := range analysis.Routes {
+		if route.Name != "" {
+			g.routeNames[route.Name]++
+		}
+	}
+	for name, count := range g.routeNames {
+		if count > 1 {
+			fmt.Printf("Warning: route name '%s' is used by %d routes; their operation IDs are generated\n", name, count)
+		}
+	}
+
 	// Generate paths from routes
 	tags := make(map[string]bool)
-	processedPaths := make(map[string]bool) // Track processed paths to avoid duplicates
+	merger := newPathMerger() // operations of all route files by path
 
 	for _, route := range analysis.Routes {
+		if ctx.Err() != nil {
+			return nil, fmt.Errorf("generation canceled: %w", context.Cause(ctx))
+		}
 		// Convert Fiber path format to OpenAPI format
 		openAPIPath := g.convertPathFormat(route.Path)
 
-		// Skip duplicate paths
-		pathKey := route.Method + ":" + openAPIPath
-		if processedPaths[pathKey] {
+		// Skip operations whose stability level is excluded from this spec
+		stability := g.routeStability(route)
+		if g.isExcludedStability(stability) {
+			continue
+		}
+
+		// Skip stub handlers when dead routes are excluded
+		if route.NotImplemented && g.config.DeadRoutes == DeadRoutesExclude {
+			fmt.Printf("Excluding not implemented route: %s %s (%s)\n", route.Method, route.Path, route.Handler)
+			continue
+		}
+
+		// Skip WebSocket routes when they are excluded
+		if route.WebSocket && g.config.ExcludeWebSocket {
+			fmt.Printf("Excluding WebSocket route: %s %s (%s)\n", route.Method, route.Path, route.Handler)
+			continue
+		}
+
+		// Skip methods another route documents, and merge paths that only
+		// differ in parameter names
+		mergedPath, added := merger.add(route, openAPIPath)
+		if !added {
 			continue
 		}
-		processedPaths[pathKey] = true
 
-		pathItem := spec.Paths[openAPIPath]
+		pathItem := spec.Paths[mergedPath]
 		operation := g.generateOperation(route)
+		operation.Stability = stability
+		renamePathParams(operation, openAPIPath, mergedPath)
 
 		// Add to tags collection
 		for _, tag := range route.Tags {
@@ -103,7 +188,22 @@ func (g *Generator) Generate(analysis *analyzer.Analysis) *OpenAPISpec {
 			pathItem.Patch = operation
 		}
 
-		spec.Paths[openAPIPath] = pathItem
+		spec.Paths[mergedPath] = pathItem
+	}
+	if merger.conflicts > 0 {
+		fmt.Printf("Warning: %d route(s) conflict with routes registered for the same method and path\n", merger.conflicts)
+	}
+	g.warnStability()
+
+	// Operations starting asynchronous jobs link to the job's status
+	g.linkJobStatus(spec)
+
+	// Enums too large to repeat inline
+	for name, schema := range g.enums {
+		spec.Components.Schemas[name] = schema
+	}
+	for name, schema := range g.pages {
+		spec.Components.Schemas[name] = schema
 	}
 
 	// Generate tags
@@ -114,16 +214,47 @@ func (g *Generator) Generate(analysis *analyzer.Analysis) *OpenAPISpec {
 		})
 	}
 
+	if g.config.Markdown {
+		g.markdownDescriptions(spec)
+	}
+
 	// Validate and clean the spec
 	if err := g.ValidateAndCleanSpec(spec); err != nil {
 		fmt.Printf("Warning: Validation errors found: %v\n", err)
 		// Continue anyway, but log the error
 	}
 
-	return spec
+	if g.config.DocScore {
+		g.scoreTags(spec)
+	}
+
+	// Component-only specs carry the models alone, without the schemas and
+	// security schemes operations use
+	if g.config.ComponentsOnly {
+		for _, name := range builtinSchemas {
+			if !g.models[name] {
+				delete(spec.Components.Schemas, name)
+			}
+		}
+		spec.Components.SecuritySchemes = nil
+	}
+
+	return spec, nil
 }
 
 func (g *Generator) generateSchemaFromModel(model analyzer.Model) Schema {
+	if g.config.EmbeddedStructs == EmbedAllOf {
+		if schema, composed := g.composedSchema(model); composed {
+			return schema
+		}
+	}
+	return g.modelSchema(model, map[string]bool{model.Name: true})
+}
+
+// modelSchema generates the schema of a model. Fields of inlined structs
+// are promoted unless the model has a field of the same name, as with
+// encoding/json; seen guards against embedding cycles.
+func (g *Generator) modelSchema(model analyzer.Model, seen map[string]bool) Schema {
 	schema := Schema{
 		Type:        "object",
 		Description: model.Description,
@@ -131,7 +262,12 @@ func (g *Generator) generateSchemaFromModel(model analyzer.Model) Schema {
 		Required:    []string{},
 	}
 
+	var inlined []analyzer.Field
 	for _, field := range model.Fields {
+		if field.Inline {
+			inlined = append(inlined, field)
+			continue
+		}
 		fieldSchema := g.generateSchemaFromField(field)
 
 		// Use JSON tag name if available, otherwise use field name
@@ -152,21 +288,125 @@ func (g *Generator) generateSchemaFromModel(model analyzer.Model) Schema {
 			fieldName = g.toSnakeCase(fieldName)
 		}
 
+		// Pointers may be nil, so they're nullable and only required when
+		// validated as such
+		required := field.Required
+		if g.isNullablePointer(field) {
+			fieldSchema = nullableSchema(fieldSchema)
+			required = required && validatesRequired(field.Validate)
+		}
+
 		schema.Properties[fieldName] = fieldSchema
 
-		if field.Required {
+		if required {
 			schema.Required = append(schema.Required, fieldName)
 		}
 	}
 
+	for _, field := range inlined {
+		name := g.cleanTypeName(field.Type)
+		embedded, exists := g.modelDefs[name]
+		if !exists || seen[name] {
+			continue
+		}
+		seen[name] = true
+		embeddedSchema := g.modelSchema(embedded, seen)
+		delete(seen, name)
+
+		// Fields of a nil embedded pointer are omitted
+		optional := strings.HasPrefix(field.Type, "*")
+		for propName, propSchema := range embeddedSchema.Properties {
+			if _, shadowed := schema.Properties[propName]; shadowed {
+				continue
+			}
+			schema.Properties[propName] = propSchema
+			if !optional && isRequired(embeddedSchema, propName) {
+				schema.Required = append(schema.Required, propName)
+			}
+		}
+	}
+
 	return schema
 }
 
+// EmbedAllOf composes the schemas of models with embedded structs from the
+// embedded structs' schemas instead of promoting their fields
+const EmbedAllOf = "allof"
+
+// composedSchema generates the schema of a model as an allOf of its
+// embedded structs' schemas and an object of its own fields. Embedded
+// pointers, whose fields are optional, and embedded structs with fields the
+// model shadows can't be composed and are flattened into the model's own
+// part. It reports false when no embedded struct can be composed.
+func (g *Generator) composedSchema(model analyzer.Model) (Schema, bool) {
+	own := model
+	own.Fields = nil
+	for _, field := range model.Fields {
+		if !field.Inline {
+			own.Fields = append(own.Fields, field)
+		}
+	}
+	ownSchema := g.modelSchema(own, map[string]bool{model.Name: true})
+
+	var parts []Schema
+	rest := model
+	rest.Fields = nil
+	for _, field := range model.Fields {
+		name := g.cleanTypeName(field.Type)
+		embedded, exists := g.modelDefs[name]
+		if !field.Inline || !exists || name == model.Name || strings.HasPrefix(field.Type, "*") {
+			rest.Fields = append(rest.Fields, field)
+			continue
+		}
+		shadowed := false
+		for propName := range g.modelSchema(embedded, map[string]bool{model.Name: true, name: true}).Properties {
+			if _, exists := ownSchema.Properties[propName]; exists {
+				shadowed = true
+				break
+			}
+		}
+		if shadowed {
+			rest.Fields = append(rest.Fields, field)
+			continue
+		}
+		parts = append(parts, Schema{Ref: "#/components/schemas/" + g.cleanSchemaName(field.Type)})
+	}
+	if len(parts) == 0 {
+		return Schema{}, false
+	}
+
+	restSchema := g.modelSchema(rest, map[string]bool{model.Name: true})
+	restSchema.Description = ""
+	if len(restSchema.Properties) > 0 {
+		parts = append(parts, restSchema)
+	}
+	return Schema{Description: model.Description, AllOf: parts}, true
+}
+
+func isRequired(schema Schema, property string) bool {
+	for _, name := range schema.Required {
+		if name == property {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *Generator) generateSchemaFromField(field analyzer.Field) Schema {
 	schema := Schema{
 		Description: field.Description,
 	}
 
+	// Explicit oneOf annotation takes precedence over the Go type
+	if len(field.OneOf) > 0 {
+		for _, typeName := range field.OneOf {
+			schema.OneOf = append(schema.OneOf, Schema{
+				Ref: "#/components/schemas/" + g.cleanSchemaName(typeName),
+			})
+		}
+		return schema
+	}
+
 	// Use original type for better accuracy
 	typeToCheck := field.OriginalType
 	if typeToCheck == "" {
@@ -176,13 +416,43 @@ func (g *Generator) generateSchemaFromField(field analyzer.Field) Schema {
 	// Clean the field type - remove any asterisks
 	cleanType := strings.ReplaceAll(typeToCheck, "*", "")
 
+	// Types of common packages the generator knows, like sql.NullString
+	if known, ok := g.wellKnownSchema(cleanType); ok {
+		// The field's description comes first, the unit of a duration after it
+		if field.Description != "" && known.Description != "" {
+			known.Description = field.Description + "\n\n" + known.Description
+		} else if field.Description != "" {
+			known.Description = field.Description
+		}
+		if field.Example != nil {
+			known.Example = field.Example
+		}
+		return known
+	}
+
+	// Types whose JSON isn't their Go shape; models keep their reference
+	if custom, ok := g.jsonTypeSchema(cleanType); ok && !g.models[g.cleanSchemaName(cleanType)] {
+		custom.Description = field.Description
+		custom.Example = field.Example
+		return g.withTypeName(custom, cleanType)
+	}
+
+	// Named types and aliases, like type UserID string, are documented as
+	// their underlying type, unless they have a format of their own
+	if underlying, named := g.namedUnderlying(cleanType); named && !g.isFormattedType(cleanType) {
+		field.Type, field.OriginalType = underlying, underlying
+		return g.withTypeName(g.generateSchemaFromField(field), cleanType)
+	}
+
 	// Map Go types to OpenAPI types
 	switch {
+	case g.isFormattedType(cleanType):
+		schema.Type = "string"
+		schema.Format, _ = g.stringFormat(cleanType)
 	case strings.HasPrefix(cleanType, "[]"):
 		// Handle array types properly
 		schema.Type = "array"
 		elementType := strings.TrimPrefix(cleanType, "[]")
-		elementType = g.cleanTypeName(elementType)
 
 		// Create items schema
 		if g.isCustomType(elementType) {
@@ -220,6 +490,9 @@ func (g *Generator) generateSchemaFromField(field analyzer.Field) Schema {
 			valueSchema := g.generateSchemaFromFieldType(mapValueType)
 			schema.AdditionalProperties = &valueSchema
 		}
+		if field.Validate != "" {
+			schema = g.applyValidation(schema, field.Validate, field.Name)
+		}
 		// Return early to avoid default case
 		return schema
 	case cleanType == "interface{}" || cleanType == "interface":
@@ -258,10 +531,41 @@ func (g *Generator) generateSchemaFromField(field analyzer.Field) Schema {
 		}
 	}
 
+	if schema.Type == "integer" {
+		g.applyIntegerBounds(&schema, cleanType)
+	}
+
+	if field.Validate != "" {
+		schema = g.applyValidation(schema, field.Validate, field.Name)
+	}
+
 	if field.Example != nil {
 		schema.Example = field.Example
 	}
 
+	if len(field.Enum) > 0 {
+		enumName := field.EnumName
+		if enumName == "" {
+			enumName = field.Name
+		}
+		if schema.Type == "array" && schema.Items != nil {
+			items := g.enumSchema(*schema.Items, enumName, field.Enum)
+			schema.Items = &items
+		} else {
+			schema = g.enumSchema(schema, enumName, field.Enum)
+		}
+	}
+
+	// Siblings of a $ref are ignored in OpenAPI 3.0, so a reference keeps
+	// the field's own description by being wrapped in allOf
+	if schema.Ref != "" && (schema.Description != "" || schema.Example != nil) {
+		schema = Schema{
+			AllOf:       []Schema{{Ref: schema.Ref}},
+			Description: schema.Description,
+			Example:     schema.Example,
+		}
+	}
+
 	return schema
 }
 
@@ -282,13 +586,19 @@ func (g *Generator) generateSchemaFromFieldType(fieldType string) Schema {
 	case "string":
 		return Schema{Type: "string"}
 	case "int", "int32", "int8", "int16":
-		return Schema{Type: "integer", Format: "int32"}
+		schema := Schema{Type: "integer", Format: "int32"}
+		g.applyIntegerBounds(&schema, cleanType)
+		return schema
 	case "int64":
 		return Schema{Type: "integer", Format: "int64"}
 	case "uint", "uint32", "uint8", "uint16":
-		return Schema{Type: "integer", Format: "int32"}
+		schema := Schema{Type: "integer", Format: "int32"}
+		g.applyIntegerBounds(&schema, cleanType)
+		return schema
 	case "uint64":
-		return Schema{Type: "integer", Format: "int64"}
+		schema := Schema{Type: "integer", Format: "int64"}
+		g.applyIntegerBounds(&schema, cleanType)
+		return schema
 	case "float32":
 		return Schema{Type: "number", Format: "float"}
 	case "float64", "float":
@@ -302,6 +612,18 @@ func (g *Generator) generateSchemaFromFieldType(fieldType string) Schema {
 	case "rune":
 		return Schema{Type: "integer", Format: "int32"}
 	default:
+		if known, ok := g.wellKnownSchema(fieldType); ok {
+			return known
+		}
+		if custom, ok := g.jsonTypeSchema(fieldType); ok && !g.models[g.cleanSchemaName(cleanType)] {
+			return g.withTypeName(custom, cleanType)
+		}
+		if format, ok := g.stringFormat(fieldType); ok {
+			return Schema{Type: "string", Format: format}
+		}
+		if underlying, named := g.namedUnderlying(cleanType); named {
+			return g.withTypeName(g.generateSchemaFromField(analyzer.Field{Type: underlying}), cleanType)
+		}
 		if g.isCustomType(cleanType) {
 			// Clean the type name before creating reference
 			cleanRefType := g.cleanSchemaName(cleanType)
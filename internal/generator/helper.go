@@ -1,11 +1,24 @@
 package generator
 
 import (
+	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
 )
 
+// Handling of stub handlers' routes, set with Config.DeadRoutes
+const (
+	DeadRoutesMark    = "mark"
+	DeadRoutesExclude = "exclude"
+	DeadRoutesIgnore  = "ignore"
+)
+
+// bodylessMethods are the methods whose requests carry no body by convention
+var bodylessMethods = map[string]bool{"GET": true, "HEAD": true, "DELETE": true}
+
 func (g *Generator) generateOperation(route analyzer.Route) *Operation {
 	operation := &Operation{
 		Tags:        route.Tags,
@@ -24,31 +37,47 @@ func (g *Generator) generateOperation(route analyzer.Route) *Operation {
 			Required:    param.Required,
 			Description: param.Description,
 			Schema:      g.generateParameterSchema(param),
+			Wildcard:    param.Wildcard,
 		}
 
 		// Add enum values if present
 		if len(param.Enum) > 0 {
-			opParam.Schema.Enum = make([]interface{}, len(param.Enum))
-			for i, v := range param.Enum {
-				opParam.Schema.Enum[i] = v
+			enumName := param.EnumName
+			if enumName == "" {
+				enumName = param.Name
 			}
+			opParam.Schema = g.enumSchema(opParam.Schema, enumName, param.Enum)
 		}
 
 		// Add default value if present
-		if param.Default != nil {
+		if param.Default != nil && opParam.Schema.Ref == "" {
 			opParam.Schema.Default = param.Default
 		}
 
 		// Add example if present
 		if param.Example != "" {
-			opParam.Example = param.Example
+			opParam.Example = g.parameterExample(param.Example, opParam.Schema)
 		}
 
 		operation.Parameters = append(operation.Parameters, opParam)
 	}
 
-	// Add request body if present
-	if route.RequestBody != nil {
+	// Add request body if present; many gateways and clients reject bodies
+	// of GET, HEAD and DELETE requests
+	requestBody := route.RequestBody
+	if requestBody != nil && bodylessMethods[strings.ToUpper(route.Method)] {
+		if g.config.StripGetBodies {
+			fmt.Printf("Warning: %s %s (%s) reads a request body; it is left out of the spec\n", strings.ToUpper(route.Method), route.Path, route.Handler)
+			requestBody = nil
+		} else {
+			fmt.Printf("Warning: %s %s (%s) reads a request body, which gateways may reject; -strip-get-bodies leaves it out\n", strings.ToUpper(route.Method), route.Path, route.Handler)
+		}
+	}
+	if requestBody != nil && g.isSkippedModel(requestBody.Name) {
+		fmt.Printf("[DEBUG] Request body %s of %s has no exported fields; it is not documented\n", requestBody.Name, route.Handler)
+		requestBody = nil
+	}
+	if requestBody != nil {
 		// Check if it's an anonymous model that needs to be added to schemas
 		modelName := route.RequestBody.Name
 
@@ -72,19 +101,34 @@ func (g *Generator) generateOperation(route analyzer.Route) *Operation {
 				},
 			},
 		}
+	} else if route.RequestBody == nil {
+		operation.RequestBody = g.formRequestBody(route)
 	}
 
 	// Add response
-	if route.Response != nil {
-		// Clean the response name before creating reference
-		cleanResponseName := g.cleanSchemaName(route.Response.Name)
+	if route.WebSocket {
+		operation.WebSocket = true
+		operation.Responses["101"] = Response{
+			Description: "Switching protocols: the connection is upgraded to a WebSocket",
+		}
+	} else if route.StaticRoot != "" {
+		operation.Responses["200"] = Response{
+			Description: "File served from " + route.StaticRoot,
+			Content: map[string]MediaType{
+				"application/octet-stream": {
+					Schema: Schema{Type: "string", Format: "binary"},
+				},
+			},
+		}
+		operation.Responses["404"] = Response{Description: "File not found"}
+	} else if route.File != nil && route.Response == nil {
+		operation.Responses["200"] = g.fileResponse(route.File)
+	} else if route.Response != nil && !g.isSkippedModel(route.Response.Name) {
 		operation.Responses["200"] = Response{
 			Description: "Successful operation",
 			Content: map[string]MediaType{
 				"application/json": {
-					Schema: Schema{
-						Ref: "#/components/schemas/" + cleanResponseName,
-					},
+					Schema: g.responseSchema(route),
 				},
 			},
 		}
@@ -94,36 +138,64 @@ func (g *Generator) generateOperation(route analyzer.Route) *Operation {
 		}
 	}
 
-	// Add error responses
-	operation.Responses["400"] = Response{
-		Description: "Bad request",
-		Content: map[string]MediaType{
-			"application/json": {
-				Schema: Schema{
-					Ref: "#/components/schemas/ErrorResponse",
-				},
-			},
-		},
+	// Add error responses; static files take no input to reject
+	if route.StaticRoot == "" {
+		operation.Responses["400"] = g.generateErrorResponse("Bad request")
 	}
-	operation.Responses["500"] = Response{
-		Description: "Internal server error",
+	operation.Responses["500"] = g.generateErrorResponse("Internal server error")
+
+	// Move the response to the statuses the handler sets
+	g.applyStatuses(operation, route)
+
+	// Add the 202 response of routes starting an asynchronous job
+	if idField, async := g.asyncJob(route); async && !route.WebSocket && route.StaticRoot == "" {
+		operation.Responses["202"] = g.acceptedResponse(route)
+		g.asyncOps = append(g.asyncOps, asyncOperation{operation: operation, route: route, idField: idField})
+	}
+
+	// Add security if configured for the route or its middleware, or if
+	// middleware indicates authentication
+	operation.Security = g.routeSecurity(route)
+
+	// Add the tenancy headers required under the route's path
+	g.applyTenancy(operation, route)
+
+	// Flag stub handlers so they don't look live
+	if route.NotImplemented && g.config.DeadRoutes != DeadRoutesExclude && g.config.DeadRoutes != DeadRoutesIgnore {
+		operation.Deprecated = true
+		operation.NotImplemented = true
+	}
+
+	// Deprecate operations with a sunset date
+	g.applySunset(operation, route)
+
+	// Add ownership metadata based on the route's source file
+	g.applyOwnership(operation, route.SourceFile)
+
+	// Add SLOs configured for the route or its tags
+	g.applySLA(operation, route)
+
+	return operation
+}
+
+// generateErrorResponse builds an error response using either the internal
+// ErrorResponse schema or RFC 7807 problem details
+func (g *Generator) generateErrorResponse(description string) Response {
+	mediaType, schemaName := "application/json", "ErrorResponse"
+	if g.config.ProblemJSON {
+		mediaType, schemaName = "application/problem+json", "ProblemDetails"
+	}
+
+	return Response{
+		Description: description,
 		Content: map[string]MediaType{
-			"application/json": {
+			mediaType: {
 				Schema: Schema{
-					Ref: "#/components/schemas/ErrorResponse",
+					Ref: "#/components/schemas/" + schemaName,
 				},
 			},
 		},
 	}
-
-	// Add security if middleware indicates authentication
-	if g.hasAuthMiddleware(route.Middleware) {
-		operation.Security = []map[string][]string{
-			{"bearerAuth": {}},
-		}
-	}
-
-	return operation
 }
 
 func (g *Generator) generateParameterSchema(param analyzer.Parameter) Schema {
@@ -146,12 +218,85 @@ func (g *Generator) generateParameterSchema(param analyzer.Parameter) Schema {
 		schema.Items = &Schema{Type: "string"} // Default to string array
 	default:
 		schema.Type = "string"
+		schema.Format, _ = g.stringFormat(param.GoType)
 	}
 
 	return schema
 }
 
+// parameterExample converts an example to the parameter's schema type, so
+// integer parameters get 42 rather than "42"
+func (g *Generator) parameterExample(example string, schema Schema) interface{} {
+	switch schema.Type {
+	case "integer":
+		if value, err := strconv.ParseInt(example, 0, 64); err == nil {
+			return value
+		}
+	case "number":
+		if value, err := strconv.ParseFloat(example, 64); err == nil {
+			return value
+		}
+	case "boolean":
+		if value, err := strconv.ParseBool(example); err == nil {
+			return value
+		}
+	}
+	return example
+}
+
+// routeStability returns the route's @stability annotation or the configured default
+func (g *Generator) routeStability(route analyzer.Route) string {
+	stability := strings.ToLower(route.Annotations["stability"])
+	if stability == "" {
+		stability = strings.ToLower(g.config.DefaultStability)
+	}
+
+	switch stability {
+	case "", "alpha", "beta", "ga":
+	default:
+		g.unknownStability[stability] = append(g.unknownStability[stability], route.Handler)
+	}
+	return stability
+}
+
+// checkDeadRoutes warns about an unknown dead routes policy, which marks
+// the routes like the default
+func (g *Generator) checkDeadRoutes() {
+	switch g.config.DeadRoutes {
+	case "", DeadRoutesMark, DeadRoutesExclude, DeadRoutesIgnore:
+	default:
+		fmt.Printf("Warning: unknown dead routes policy '%s'; use mark, exclude or ignore\n", g.config.DeadRoutes)
+	}
+}
+
+// warnStability warns once per unknown stability level of the spec being
+// generated, naming its handlers
+func (g *Generator) warnStability() {
+	levels := make([]string, 0, len(g.unknownStability))
+	for stability := range g.unknownStability {
+		levels = append(levels, stability)
+	}
+	sort.Strings(levels)
+	for _, stability := range levels {
+		fmt.Printf("Warning: unknown stability '%s' on handlers %s (expected alpha, beta or ga)\n",
+			stability, strings.Join(g.unknownStability[stability], ", "))
+	}
+}
+
+func (g *Generator) isExcludedStability(stability string) bool {
+	for _, excluded := range g.config.ExcludeStability {
+		if stability != "" && strings.EqualFold(stability, excluded) {
+			return true
+		}
+	}
+	return false
+}
+
 func (g *Generator) generateOperationID(route analyzer.Route) string {
+	if route.Name != "" && g.routeNames[route.Name] == 1 {
+		return route.Name
+	}
+
 	method := strings.ToLower(route.Method)
 	path := g.convertPathFormat(route.Path)
 
@@ -224,7 +369,7 @@ func (g *Generator) getActionFromMethod(method string) string {
 func (g *Generator) getResourceFromPath(path string) string {
 	parts := strings.Split(path, "/")
 	for i := len(parts) - 1; i >= 0; i-- {
-		if parts[i] != "" && !strings.HasPrefix(parts[i], ":") && !strings.HasPrefix(parts[i], "{") {
+		if parts[i] != "" && !strings.HasPrefix(parts[i], ":") && !strings.HasPrefix(parts[i], "{") && !strings.HasPrefix(parts[i], "*") {
 			return strings.Title(parts[i])
 		}
 	}
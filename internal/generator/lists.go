@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+)
+
+// List response modes, set with Config.ListResponses or a handler's @list
+// annotation
+const (
+	ListArray     = "array"
+	ListPaginated = "paginated"
+)
+
+// responseSchema is the schema of a route's response model: a reference to
+// the model, or a list of it when the handler returns a slice, its @list
+// annotation says so, or ListResponses applies to the route
+func (g *Generator) responseSchema(route analyzer.Route) Schema {
+	ref := Schema{Ref: "#/components/schemas/" + g.cleanSchemaName(route.Response.Name)}
+	switch g.listMode(route) {
+	case ListArray:
+		return Schema{Type: "array", Items: &ref}
+	case ListPaginated:
+		return g.pageSchema(route.Response.Name, ref)
+	}
+	return ref
+}
+
+// listMode returns how a route's response is wrapped in a list, or "" for
+// none. A @list annotation wins over the configured heuristic; @list none
+// opts a route out of it.
+func (g *Generator) listMode(route analyzer.Route) string {
+	if route.ResponseArray {
+		return ListArray
+	}
+	if mode, annotated := route.Annotations["list"]; annotated {
+		switch mode = strings.ToLower(strings.TrimSpace(mode)); mode {
+		case "", ListArray:
+			return ListArray
+		case ListPaginated:
+			return ListPaginated
+		case "none":
+			return ""
+		}
+		fmt.Printf("Warning: unknown @list mode '%s' on %s; use array, paginated or none\n", mode, route.Handler)
+		return ""
+	}
+	if g.config.ListResponses != "" && g.isCollectionRoute(route) && g.isSingularModel(*route.Response) {
+		return g.config.ListResponses
+	}
+	return ""
+}
+
+// isCollectionRoute reports whether a route reads a collection: a GET whose
+// path doesn't end in a parameter, like /users rather than /users/{id}
+func (g *Generator) isCollectionRoute(route analyzer.Route) bool {
+	if !strings.EqualFold(route.Method, "GET") {
+		return false
+	}
+	segments := strings.Split(strings.Trim(g.convertPathFormat(route.Path), "/"), "/")
+	last := segments[len(segments)-1]
+	return last != "" && !strings.HasPrefix(last, "{")
+}
+
+// isSingularModel reports whether a model looks like a single entity rather
+// than a list or an envelope: its name doesn't say otherwise and none of its
+// fields is a list of models
+func (g *Generator) isSingularModel(model analyzer.Model) bool {
+	name := g.cleanSchemaName(model.Name)
+	if name == "StandardResponse" {
+		return false
+	}
+	for _, marker := range []string{"List", "Page", "Response", "Result", "Collection"} {
+		if strings.Contains(name, marker) {
+			return false
+		}
+	}
+	for _, field := range model.Fields {
+		fieldType := strings.TrimPrefix(field.Type, "*")
+		if strings.HasPrefix(fieldType, "[]") && g.models[g.cleanSchemaName(strings.TrimPrefix(fieldType, "[]"))] {
+			return false
+		}
+	}
+	return true
+}
+
+// pageSchema returns a reference to the paginated envelope of a model,
+// UserPage for User, adding it to the components. The envelope is inlined
+// when a model or an externalized enum already has its name.
+func (g *Generator) pageSchema(modelName string, ref Schema) Schema {
+	envelope := Schema{
+		Type:        "object",
+		Description: "Page of " + g.cleanSchemaName(modelName) + " items",
+		Properties: map[string]Schema{
+			"items": {Type: "array", Items: &ref},
+			"total": {Type: "integer", Description: "Total number of items across all pages"},
+		},
+		Required: []string{"items"},
+	}
+	name := g.cleanSchemaName(modelName) + "Page"
+	if _, isEnum := g.enums[name]; g.models[name] || isEnum {
+		return envelope
+	}
+	g.pages[name] = envelope
+	return Schema{Ref: "#/components/schemas/" + name}
+}
@@ -0,0 +1,66 @@
+package generator
+
+import (
+	"go/doc/comment"
+	"strings"
+)
+
+// markdownDescriptions converts the descriptions of the schemas,
+// operations and parameters, which come from Go doc comments, to CommonMark for doc
+// renderers: paragraphs are joined, lists, code blocks and headings become
+// their Markdown equivalents, and characters Markdown would interpret, like
+// the underscore of role_name or an HTML tag, are escaped
+func (g *Generator) markdownDescriptions(spec *OpenAPISpec) {
+	for name, schema := range spec.Components.Schemas {
+		spec.Components.Schemas[name] = markdownSchema(schema)
+	}
+	for path, item := range spec.Paths {
+		for _, operation := range []*Operation{item.Get, item.Post, item.Put, item.Delete, item.Patch} {
+			if operation == nil {
+				continue
+			}
+			operation.Description = markdown(operation.Description)
+			for i := range operation.Parameters {
+				operation.Parameters[i].Description = markdown(operation.Parameters[i].Description)
+			}
+		}
+		spec.Paths[path] = item
+	}
+}
+
+func markdownSchema(schema Schema) Schema {
+	schema.Description = markdown(schema.Description)
+	if len(schema.Properties) > 0 {
+		properties := make(map[string]Schema, len(schema.Properties))
+		for name, property := range schema.Properties {
+			properties[name] = markdownSchema(property)
+		}
+		schema.Properties = properties
+	}
+	if schema.Items != nil {
+		items := markdownSchema(*schema.Items)
+		schema.Items = &items
+	}
+	if values, ok := schema.AdditionalProperties.(*Schema); ok {
+		converted := markdownSchema(*values)
+		schema.AdditionalProperties = &converted
+	}
+	for _, parts := range [][]Schema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for i := range parts {
+			parts[i] = markdownSchema(parts[i])
+		}
+	}
+	return schema
+}
+
+// markdown converts a doc comment text to CommonMark
+func markdown(text string) string {
+	if text == "" {
+		return ""
+	}
+	var parser comment.Parser
+	printer := comment.Printer{
+		HeadingID: func(*comment.Heading) string { return "" },
+	}
+	return strings.TrimSpace(string(printer.Markdown(parser.Parse(text))))
+}
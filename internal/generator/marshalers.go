@@ -0,0 +1,45 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// jsonTypeSchema returns the schema of a type whose JSON doesn't follow its
+// Go shape: a type mapped in Config.JSONTypes, by name with or without its
+// package, to "type" or "type:format", or a type with a MarshalJSON method,
+// documented as the JSON type the method produces, or as any value when
+// that couldn't be inferred
+func (g *Generator) jsonTypeSchema(typeName string) (Schema, bool) {
+	typeName = strings.ReplaceAll(typeName, "*", "")
+	if strings.HasPrefix(typeName, "[]") || strings.HasPrefix(typeName, "map[") {
+		return Schema{}, false
+	}
+	for _, name := range []string{typeName, g.cleanTypeName(typeName)} {
+		if mapped, ok := g.config.JSONTypes[name]; ok {
+			jsonType, format, _ := strings.Cut(mapped, ":")
+			return Schema{Type: jsonType, Format: format}, true
+		}
+	}
+	jsonType, custom := g.marshalers[g.cleanTypeName(typeName)]
+	if !custom {
+		return Schema{}, false
+	}
+	return Schema{Type: jsonType}, true
+}
+
+// warnMarshalers warns about the types with a MarshalJSON method whose JSON
+// type couldn't be inferred and isn't configured
+func (g *Generator) warnMarshalers() {
+	var unknown []string
+	for name, jsonType := range g.marshalers {
+		if _, mapped := g.config.JSONTypes[name]; !mapped && jsonType == "" {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		fmt.Printf("Warning: %s implements MarshalJSON; its JSON type couldn't be inferred, so it is documented as any value. Set it with -json-types %s=string\n", name, name)
+	}
+}
@@ -1,7 +1,22 @@
 package generator
 
+import "github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+
 type Generator struct {
 	config Config
+	enums  map[string]Schema // enums externalized to named schemas during Generate
+	pages  map[string]Schema // paginated list envelopes added during Generate, see responseSchema
+	models map[string]bool   // schema names taken by models
+
+	namedTypes  map[string]string         // named SDK types to their underlying type
+	modelDefs   map[string]analyzer.Model // models by name, for inlined structs
+	routeNames  map[string]int            // routes using each route name
+	emptyModels map[string]bool           // models without exported fields, see findEmptyModels
+	marshalers  map[string]string         // types with a MarshalJSON method, see Analysis.Marshalers
+	asyncOps    []asyncOperation          // operations answering 202 Accepted, linked by linkJobStatus
+	schemas     map[string]Schema         // component schemas of the spec being generated, see enumSchemaName
+
+	unknownStability map[string][]string // handlers by unknown stability level, see warnStability
 }
 
 type Config struct {
@@ -9,6 +24,31 @@ type Config struct {
 	Version     string
 	Description string
 	ServerURL   string
+	ProblemJSON bool // emit error responses as RFC 7807 problem details
+	Ownership   []OwnershipRule
+	CodeOwners  []OwnershipRule
+
+	DefaultStability string   // x-stability for operations without a @stability annotation
+	ExcludeStability []string // stability levels left out of the spec (e.g. alpha for a public variant)
+	SLA              SLAConfig
+	Security         SecurityConfig
+	Tenancy          TenancyConfig
+	ExcludeWebSocket bool              // leave WebSocket routes out instead of marking them with x-websocket
+	ComponentsOnly   bool              // emit the model schemas alone, for a shared schema package
+	DeadRoutes       string            // stub handlers: mark (default), exclude or ignore
+	MaxInlineEnum    int               // enums with more values become named schemas; 0 uses the default, negative keeps all inline
+	Formats          map[string]string // string formats by named Go type, e.g. Email -> email; "" disables inference
+	Profile          string            // output profile applied by ApplyProfile: "" or oapi-codegen
+	EmbeddedStructs  string            // embedded structs: flatten (default) promotes their fields, allof composes their schemas
+	ListResponses    string            // singular responses of GET collection routes: "" (kept), array or paginated
+	StripGetBodies   bool              // leave request bodies of GET, HEAD and DELETE routes out
+	EmptyStructs     string            // models without exported fields: object (default), free-form or skip
+	JSONTypes        map[string]string // JSON schema types by Go type whose JSON isn't its shape, e.g. Money -> string, Stamp -> integer:int64
+	Markdown         bool              // convert the descriptions from doc comments to CommonMark
+	Decimals         string            // decimal and big number types: string (default) or number
+	Durations        string            // time.Duration: integer (default) nanoseconds or string like 1h30m
+	DocScore         bool              // set the x-doc-score of tags, see DocScores
+	Pointers         string            // pointer fields: nullable (default) and optional, or plain as their value
 }
 
 type OpenAPISpec struct {
@@ -48,6 +88,15 @@ type Operation struct {
 	RequestBody *RequestBody          `json:"requestBody,omitempty" yaml:"requestBody,omitempty"`
 	Responses   map[string]Response   `json:"responses" yaml:"responses"`
 	Security    []map[string][]string `json:"security,omitempty" yaml:"security,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty" yaml:"deprecated,omitempty"`
+	Owner       string                `json:"x-owner,omitempty" yaml:"x-owner,omitempty"`
+	Team        string                `json:"x-team,omitempty" yaml:"x-team,omitempty"`
+	Stability   string                `json:"x-stability,omitempty" yaml:"x-stability,omitempty"`
+	SLA         *SLA                  `json:"x-sla,omitempty" yaml:"x-sla,omitempty"`
+	Sunset      string                `json:"x-sunset,omitempty" yaml:"x-sunset,omitempty"` // @sunset date, see applySunset
+
+	NotImplemented bool `json:"x-not-implemented,omitempty" yaml:"x-not-implemented,omitempty"`
+	WebSocket      bool `json:"x-websocket,omitempty" yaml:"x-websocket,omitempty"`
 }
 
 type Parameter struct {
@@ -57,6 +106,7 @@ type Parameter struct {
 	Required    bool        `json:"required,omitempty" yaml:"required,omitempty"`
 	Schema      Schema      `json:"schema" yaml:"schema"`
 	Example     interface{} `json:"example,omitempty" yaml:"example,omitempty"`
+	Wildcard    bool        `json:"x-wildcard,omitempty" yaml:"x-wildcard,omitempty"` // catch-all path parameter, may contain slashes
 }
 
 type RequestBody struct {
@@ -67,7 +117,22 @@ type RequestBody struct {
 
 type Response struct {
 	Description string               `json:"description" yaml:"description"`
+	Headers     map[string]Header    `json:"headers,omitempty" yaml:"headers,omitempty"`
 	Content     map[string]MediaType `json:"content,omitempty" yaml:"content,omitempty"`
+	Links       map[string]Link      `json:"links,omitempty" yaml:"links,omitempty"`
+}
+
+type Header struct {
+	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	Schema      Schema `json:"schema" yaml:"schema"`
+}
+
+// Link describes an operation a response leads to, with the parameter
+// values taken from the response
+type Link struct {
+	OperationID string            `json:"operationId" yaml:"operationId"`
+	Parameters  map[string]string `json:"parameters,omitempty" yaml:"parameters,omitempty"`
+	Description string            `json:"description,omitempty" yaml:"description,omitempty"`
 }
 
 type MediaType struct {
@@ -86,9 +151,23 @@ type Schema struct {
 	Description          string            `json:"description,omitempty" yaml:"description,omitempty"`
 	Enum                 []interface{}     `json:"enum,omitempty" yaml:"enum,omitempty"`
 	Default              interface{}       `json:"default,omitempty" yaml:"default,omitempty"`
+	Nullable             bool              `json:"nullable,omitempty" yaml:"nullable,omitempty"`
+	Minimum              *int64            `json:"minimum,omitempty" yaml:"minimum,omitempty"`
+	Maximum              *int64            `json:"maximum,omitempty" yaml:"maximum,omitempty"`
+	ExclusiveMinimum     bool              `json:"exclusiveMinimum,omitempty" yaml:"exclusiveMinimum,omitempty"`
+	ExclusiveMaximum     bool              `json:"exclusiveMaximum,omitempty" yaml:"exclusiveMaximum,omitempty"`
+	MinLength            *int64            `json:"minLength,omitempty" yaml:"minLength,omitempty"`
+	MaxLength            *int64            `json:"maxLength,omitempty" yaml:"maxLength,omitempty"`
+	MinItems             *int64            `json:"minItems,omitempty" yaml:"minItems,omitempty"`
+	MaxItems             *int64            `json:"maxItems,omitempty" yaml:"maxItems,omitempty"`
 	AllOf                []Schema          `json:"allOf,omitempty" yaml:"allOf,omitempty"`
 	OneOf                []Schema          `json:"oneOf,omitempty" yaml:"oneOf,omitempty"`
 	AnyOf                []Schema          `json:"anyOf,omitempty" yaml:"anyOf,omitempty"`
+	GoType               string            `json:"x-go-type,omitempty" yaml:"x-go-type,omitempty"`
+	GoPackage            string            `json:"x-go-package,omitempty" yaml:"x-go-package,omitempty"`
+	GoTypeName           string            `json:"x-go-type-name,omitempty" yaml:"x-go-type-name,omitempty"` // named Go type of a property, like UserID
+	GoTypeImport         *GoTypeImport     `json:"x-go-type-import,omitempty" yaml:"x-go-type-import,omitempty"`
+	EnumVarNames         []string          `json:"x-enum-varnames,omitempty" yaml:"x-enum-varnames,omitempty"`
 }
 
 type Components struct {
@@ -100,10 +179,13 @@ type SecurityScheme struct {
 	Type         string `json:"type" yaml:"type"`
 	Scheme       string `json:"scheme,omitempty" yaml:"scheme,omitempty"`
 	BearerFormat string `json:"bearerFormat,omitempty" yaml:"bearerFormat,omitempty"`
+	In           string `json:"in,omitempty" yaml:"in,omitempty"`     // apiKey: header, query or cookie
+	Name         string `json:"name,omitempty" yaml:"name,omitempty"` // apiKey: name of the header, query parameter or cookie
 	Description  string `json:"description,omitempty" yaml:"description,omitempty"`
 }
 
 type Tag struct {
 	Name        string `json:"name" yaml:"name"`
 	Description string `json:"description,omitempty" yaml:"description,omitempty"`
+	DocScore    *int   `json:"x-doc-score,omitempty" yaml:"x-doc-score,omitempty"` // see DocScores
 }
\ No newline at end of file
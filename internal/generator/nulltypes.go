@@ -0,0 +1,42 @@
+package generator
+
+import "strings"
+
+// nullTypes are the schemas of the nullable wrappers of database/sql and
+// pgx's pgtype (v4 and v5), by package and name. They are documented as
+// the value they wrap, or null, like the JSON of pgtype's types.
+var nullTypes = map[string]Schema{
+	"sql.NullString":     {Type: "string"},
+	"sql.NullInt64":      {Type: "integer", Format: "int64"},
+	"sql.NullInt32":      {Type: "integer", Format: "int32"},
+	"sql.NullInt16":      {Type: "integer", Format: "int32"},
+	"sql.NullByte":       {Type: "integer", Format: "int32"},
+	"sql.NullFloat64":    {Type: "number", Format: "double"},
+	"sql.NullBool":       {Type: "boolean"},
+	"sql.NullTime":       {Type: "string", Format: "date-time"},
+	"pgtype.Text":        {Type: "string"},
+	"pgtype.Varchar":     {Type: "string"},
+	"pgtype.BPChar":      {Type: "string"},
+	"pgtype.Int2":        {Type: "integer", Format: "int32"},
+	"pgtype.Int4":        {Type: "integer", Format: "int32"},
+	"pgtype.Int8":        {Type: "integer", Format: "int64"},
+	"pgtype.Float4":      {Type: "number", Format: "float"},
+	"pgtype.Float8":      {Type: "number", Format: "double"},
+	"pgtype.Numeric":     {Type: "number"},
+	"pgtype.Bool":        {Type: "boolean"},
+	"pgtype.Date":        {Type: "string", Format: "date"},
+	"pgtype.Timestamp":   {Type: "string", Format: "date-time"},
+	"pgtype.Timestamptz": {Type: "string", Format: "date-time"},
+	"pgtype.UUID":        {Type: "string", Format: "uuid"},
+}
+
+// nullTypeSchema returns the nullable schema of a database/sql or pgtype
+// null type, like sql.NullString or *pgtype.Int8
+func nullTypeSchema(typeName string) (Schema, bool) {
+	schema, ok := nullTypes[strings.ReplaceAll(typeName, "*", "")]
+	if !ok {
+		return Schema{}, false
+	}
+	schema.Nullable = true
+	return schema, true
+}
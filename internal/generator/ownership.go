@@ -0,0 +1,118 @@
+package generator
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// OwnershipRule maps source paths (CODEOWNERS-style patterns) to an owner
+// and team stamped on operations as x-owner/x-team
+type OwnershipRule struct {
+	Pattern string `json:"path"`
+	Owner   string `json:"owner"`
+	Team    string `json:"team"`
+}
+
+// LoadCodeOwners reads the project's CODEOWNERS file, if any, as ownership rules
+func LoadCodeOwners(projectPath string) ([]OwnershipRule, error) {
+	for _, candidate := range []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"} {
+		file, err := os.Open(filepath.Join(projectPath, candidate))
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+
+		var rules []OwnershipRule
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			// A pattern without owners leaves its files unowned
+			fields := append(strings.Fields(line), "")
+			rules = append(rules, OwnershipRule{
+				Pattern: fields[0],
+				Owner:   strings.TrimSpace(strings.Join(fields[1:], " ")),
+				Team:    teamFromOwner(fields[1]),
+			})
+		}
+		return rules, scanner.Err()
+	}
+	return nil, nil
+}
+
+// teamFromOwner extracts "team" from a GitHub "@org/team" owner
+func teamFromOwner(owner string) string {
+	if idx := strings.Index(owner, "/"); strings.HasPrefix(owner, "@") && idx != -1 {
+		return owner[idx+1:]
+	}
+	return ""
+}
+
+// applyOwnership stamps x-owner/x-team on an operation. As in CODEOWNERS the
+// last matching rule wins, and configured rules override CODEOWNERS.
+func (g *Generator) applyOwnership(operation *Operation, sourceFile string) {
+	if sourceFile == "" {
+		return
+	}
+
+	var last *OwnershipRule
+	for _, rules := range [][]OwnershipRule{g.config.CodeOwners, g.config.Ownership} {
+		for i := range rules {
+			if matchOwnershipPattern(rules[i].Pattern, sourceFile) {
+				last = &rules[i]
+			}
+		}
+	}
+	if last != nil {
+		// Owner and team come from the same rule, even when it leaves one out
+		operation.Owner = last.Owner
+		operation.Team = last.Team
+	}
+}
+
+// matchOwnershipPattern implements the gitignore-style matching used by CODEOWNERS
+func matchOwnershipPattern(pattern, path string) bool {
+	anchored := strings.HasPrefix(pattern, "/")
+	pattern = strings.TrimPrefix(pattern, "/")
+	if strings.HasSuffix(pattern, "/") {
+		pattern += "**"
+	}
+	if pattern == "" {
+		return false
+	}
+
+	var expr strings.Builder
+	if !anchored && !strings.Contains(strings.TrimSuffix(pattern, "/**"), "/") {
+		// Patterns without a slash match at any depth
+		expr.WriteString("^(.*/)?")
+	} else {
+		expr.WriteString("^")
+	}
+
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(pattern[i])))
+		}
+	}
+	// A pattern naming a directory owns everything below it
+	expr.WriteString("(/.*)?$")
+
+	matched, err := regexp.MatchString(expr.String(), path)
+	return err == nil && matched
+}
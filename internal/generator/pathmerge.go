@@ -0,0 +1,91 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+)
+
+// pathParamPattern matches the parameters of an OpenAPI path template
+var pathParamPattern = regexp.MustCompile(`\{[^}]*\}`)
+
+// pathMerger assembles the operations of all route files into path items,
+// so GET /users/{id} in one package and DELETE /users/{userId} in another
+// end up under one path
+type pathMerger struct {
+	canonical map[string]string         // first path registered per template, see pathTemplate
+	owners    map[string]analyzer.Route // route documented per method and template
+	conflicts int
+}
+
+func newPathMerger() *pathMerger {
+	return &pathMerger{
+		canonical: make(map[string]string),
+		owners:    make(map[string]analyzer.Route),
+	}
+}
+
+// pathTemplate is a path with its parameter names and trailing slash
+// dropped: paths with the same template are the same to a client, and
+// OpenAPI doesn't allow both
+func pathTemplate(path string) string {
+	template := pathParamPattern.ReplaceAllString(path, "{}")
+	if len(template) > 1 {
+		template = strings.TrimSuffix(template, "/")
+	}
+	return template
+}
+
+// add claims a method of a path for a route, returning the path the
+// operation goes under: the first path registered with the same template.
+// It reports false for a method already claimed, warning when a different
+// handler claimed it; the first route in route file order wins.
+func (m *pathMerger) add(route analyzer.Route, path string) (string, bool) {
+	template := pathTemplate(path)
+	key := strings.ToUpper(route.Method) + " " + template
+	if owner, claimed := m.owners[key]; claimed {
+		if owner.Handler != route.Handler || owner.SourceFile != route.SourceFile {
+			m.conflicts++
+			fmt.Printf("Warning: %s %s is registered by %s (%s) and %s (%s); documenting %s\n",
+				strings.ToUpper(route.Method), path, owner.Handler, owner.SourceFile, route.Handler, route.SourceFile, owner.Handler)
+		}
+		return "", false
+	}
+	m.owners[key] = route
+
+	canonical, exists := m.canonical[template]
+	if !exists {
+		m.canonical[template] = path
+		return path, true
+	}
+	if canonical != path {
+		fmt.Printf("[DEBUG] Documenting %s %s under %s\n", strings.ToUpper(route.Method), path, canonical)
+	}
+	return canonical, true
+}
+
+// renamePathParams renames an operation's path parameters after those of
+// the path it is merged under, by position
+func renamePathParams(operation *Operation, from, to string) {
+	if from == to {
+		return
+	}
+	fromNames := pathParamPattern.FindAllString(from, -1)
+	toNames := pathParamPattern.FindAllString(to, -1)
+	renames := make(map[string]string)
+	for i := range fromNames {
+		if i < len(toNames) {
+			renames[strings.Trim(fromNames[i], "{}")] = strings.Trim(toNames[i], "{}")
+		}
+	}
+	for i, param := range operation.Parameters {
+		if param.In != "path" {
+			continue
+		}
+		if name, renamed := renames[param.Name]; renamed {
+			operation.Parameters[i].Name = name
+		}
+	}
+}
@@ -0,0 +1,50 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+)
+
+// Pointer field policies, set with Config.Pointers
+const (
+	PointersNullable = "nullable"
+	PointersPlain    = "plain"
+)
+
+// isNullablePointer reports whether a field is a pointer documented as
+// nullable: nil pointers marshal to null, or are left out with omitempty
+func (g *Generator) isNullablePointer(field analyzer.Field) bool {
+	if g.config.Pointers == PointersPlain {
+		return false
+	}
+	typeName := field.OriginalType
+	if typeName == "" {
+		typeName = field.Type
+	}
+	return strings.HasPrefix(typeName, "*")
+}
+
+// nullableSchema marks the schema of a pointer field as nullable. OpenAPI
+// 3.0 ignores the siblings of $ref, so references are wrapped in an allOf.
+func nullableSchema(schema Schema) Schema {
+	if schema.Ref != "" {
+		return Schema{AllOf: []Schema{{Ref: schema.Ref}}, Nullable: true}
+	}
+	schema.Nullable = true
+	return schema
+}
+
+// validatesRequired reports whether the rules of a validate tag require the
+// field itself, which keeps a pointer field required
+func validatesRequired(rules string) bool {
+	for _, rule := range strings.Split(rules, ",") {
+		switch strings.TrimSpace(rule) {
+		case "dive":
+			return false
+		case "required":
+			return true
+		}
+	}
+	return false
+}
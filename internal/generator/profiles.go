@@ -0,0 +1,249 @@
+package generator
+
+import (
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ProfileOapiCodegen tunes the spec for generating Go code with oapi-codegen
+const ProfileOapiCodegen = "oapi-codegen"
+
+// GoTypeImport is oapi-codegen's x-go-type-import extension
+type GoTypeImport struct {
+	Path string `json:"path" yaml:"path"`
+}
+
+// ApplyProfile adjusts the spec, fragments included, for the configured
+// output profile. The default profile leaves it as generated.
+func (g *Generator) ApplyProfile(spec *OpenAPISpec) error {
+	switch g.config.Profile {
+	case "":
+		return nil
+	case ProfileOapiCodegen:
+		g.applyOapiCodegenProfile(spec)
+		return nil
+	default:
+		return fmt.Errorf("unknown output profile: %s (supported: %s)", g.config.Profile, ProfileOapiCodegen)
+	}
+}
+
+// applyOapiCodegenProfile makes the spec regenerate cleanly with
+// oapi-codegen: operation IDs become unique Go identifiers, models point at
+// their original Go types, inline objects become named schemas, and enum
+// values get valid constant names through x-enum-varnames.
+func (g *Generator) applyOapiCodegenProfile(spec *OpenAPISpec) {
+	g.goIdentifierOperationIDs(spec)
+
+	// Hoisting adds schemas, so it works from a snapshot of the names
+	names := make([]string, 0, len(spec.Components.Schemas))
+	for name := range spec.Components.Schemas {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		schema := spec.Components.Schemas[name]
+		// oapi-codegen imports the package and refers to the type by its
+		// package name instead of generating it
+		if schema.GoPackage != "" {
+			schema.GoType = path.Base(schema.GoPackage) + "." + name
+			schema.GoTypeImport = &GoTypeImport{Path: schema.GoPackage}
+			schema.GoPackage = ""
+		}
+		schema.Properties = hoistProperties(spec, name, schema.Properties)
+		schema.Items = hoistSchema(spec, name+"Item", schema.Items)
+		spec.Components.Schemas[name] = schema
+	}
+
+	for _, p := range sortedPaths(spec) {
+		pathItem := spec.Paths[p]
+		for _, operation := range []*Operation{pathItem.Get, pathItem.Post, pathItem.Put, pathItem.Delete, pathItem.Patch} {
+			if operation != nil {
+				hoistOperation(spec, operation)
+			}
+		}
+	}
+
+	forEachSchema(spec, func(schema *Schema) {
+		if len(schema.Enum) > 0 {
+			schema.EnumVarNames = enumVarNames(schema.Enum)
+		}
+	})
+}
+
+// goIdentifierOperationIDs turns operation IDs like get_api_users_id into Go
+// identifiers like getApiUsersId, numbering duplicates in path order
+func (g *Generator) goIdentifierOperationIDs(spec *OpenAPISpec) {
+	taken := make(map[string]bool)
+	for _, p := range sortedPaths(spec) {
+		pathItem := spec.Paths[p]
+		for _, operation := range []*Operation{pathItem.Get, pathItem.Post, pathItem.Put, pathItem.Delete, pathItem.Patch} {
+			if operation == nil {
+				continue
+			}
+			id := goIdentifier(operation.OperationID, false)
+			candidate := id
+			for i := 2; taken[candidate]; i++ {
+				candidate = fmt.Sprintf("%s%d", id, i)
+			}
+			taken[candidate] = true
+			operation.OperationID = candidate
+		}
+	}
+}
+
+// hoistOperation moves inline objects of an operation's request and
+// responses to schemas named after the operation
+func hoistOperation(spec *OpenAPISpec, operation *Operation) {
+	base := goIdentifier(operation.OperationID, true)
+	if operation.RequestBody != nil {
+		for mediaType, content := range operation.RequestBody.Content {
+			content.Schema = *hoistSchema(spec, base+"Request", &content.Schema)
+			operation.RequestBody.Content[mediaType] = content
+		}
+	}
+	for status, response := range operation.Responses {
+		for mediaType, content := range response.Content {
+			content.Schema = *hoistSchema(spec, base+status+"Response", &content.Schema)
+			response.Content[mediaType] = content
+		}
+	}
+}
+
+// hoistProperties hoists the inline objects of a schema's properties
+func hoistProperties(spec *OpenAPISpec, parent string, properties map[string]Schema) map[string]Schema {
+	for name, property := range properties {
+		properties[name] = *hoistSchema(spec, parent+goIdentifier(name, true), &property)
+	}
+	return properties
+}
+
+// hoistSchema returns schema with inline objects, its own or those of its
+// items and properties, replaced by references to new schemas named after
+// where they appear
+func hoistSchema(spec *OpenAPISpec, name string, schema *Schema) *Schema {
+	if schema == nil {
+		return nil
+	}
+	hoisted := *schema
+	hoisted.Items = hoistSchema(spec, name+"Item", schema.Items)
+	if len(hoisted.Properties) == 0 {
+		return &hoisted
+	}
+
+	hoisted.Properties = hoistProperties(spec, name, hoisted.Properties)
+	schemaName := name
+	for i := 2; ; i++ {
+		if _, taken := spec.Components.Schemas[schemaName]; !taken {
+			break
+		}
+		schemaName = fmt.Sprintf("%s%d", name, i)
+	}
+	spec.Components.Schemas[schemaName] = hoisted
+	return &Schema{Ref: "#/components/schemas/" + schemaName}
+}
+
+// forEachSchema calls fn for every schema of the spec, nested ones included
+func forEachSchema(spec *OpenAPISpec, fn func(*Schema)) {
+	var visit func(schema *Schema)
+	visit = func(schema *Schema) {
+		if schema == nil {
+			return
+		}
+		fn(schema)
+		for name, property := range schema.Properties {
+			visit(&property)
+			schema.Properties[name] = property
+		}
+		visit(schema.Items)
+		if additional, ok := schema.AdditionalProperties.(*Schema); ok {
+			visit(additional)
+		}
+		for _, list := range [][]Schema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+			for i := range list {
+				visit(&list[i])
+			}
+		}
+	}
+
+	for name, schema := range spec.Components.Schemas {
+		visit(&schema)
+		spec.Components.Schemas[name] = schema
+	}
+	for _, pathItem := range spec.Paths {
+		for _, operation := range []*Operation{pathItem.Get, pathItem.Post, pathItem.Put, pathItem.Delete, pathItem.Patch} {
+			if operation == nil {
+				continue
+			}
+			for i := range operation.Parameters {
+				visit(&operation.Parameters[i].Schema)
+			}
+			if operation.RequestBody != nil {
+				for mediaType, content := range operation.RequestBody.Content {
+					visit(&content.Schema)
+					operation.RequestBody.Content[mediaType] = content
+				}
+			}
+			for _, response := range operation.Responses {
+				for mediaType, content := range response.Content {
+					visit(&content.Schema)
+					response.Content[mediaType] = content
+				}
+			}
+		}
+	}
+}
+
+// enumVarNames names the constants oapi-codegen generates for enum values,
+// making them valid and unique Go identifiers
+func enumVarNames(values []interface{}) []string {
+	names := make([]string, len(values))
+	taken := make(map[string]bool)
+	for i, value := range values {
+		name := goIdentifier(fmt.Sprint(value), true)
+		switch {
+		case name == "":
+			name = "Empty"
+		case unicode.IsDigit(rune(name[0])):
+			name = "Value" + name
+		}
+		candidate := name
+		for n := 2; taken[candidate]; n++ {
+			candidate = fmt.Sprintf("%s%d", name, n)
+		}
+		taken[candidate] = true
+		names[i] = candidate
+	}
+	return names
+}
+
+// goIdentifier joins the letters and digits of s into a camel case
+// identifier, exported or not
+func goIdentifier(s string, exported bool) string {
+	var b strings.Builder
+	for i, word := range strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		first, size := utf8.DecodeRuneInString(word)
+		if i == 0 && !exported {
+			first = unicode.ToLower(first)
+		} else {
+			first = unicode.ToUpper(first)
+		}
+		b.WriteRune(first)
+		b.WriteString(word[size:])
+	}
+	return b.String()
+}
+
+func sortedPaths(spec *OpenAPISpec) []string {
+	paths := make([]string, 0, len(spec.Paths))
+	for p := range spec.Paths {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	return paths
+}
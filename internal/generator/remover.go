@@ -57,6 +57,14 @@ func (g *Generator) removeInvalidRefsFromSchema(schema Schema, validSchemas map[
 			schema.AdditionalProperties = &cleanAdditional
 		}
 	}
+
+	// Clean allOf parts and oneOf alternatives
+	for i, part := range schema.AllOf {
+		schema.AllOf[i] = g.removeInvalidRefsFromSchema(part, validSchemas)
+	}
+	for i, option := range schema.OneOf {
+		schema.OneOf[i] = g.removeInvalidRefsFromSchema(option, validSchemas)
+	}
 	
 	return schema
 }
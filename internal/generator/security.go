@@ -0,0 +1,139 @@
+package generator
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+)
+
+// defaultSecurityScheme is the scheme of routes behind a middleware whose
+// name mentions auth, unless the config says otherwise
+const defaultSecurityScheme = "bearerAuth"
+
+// SecurityConfig declares security schemes beyond the default bearerAuth,
+// like an apiKey scheme read from a custom header, and the security
+// requirements of routes. Requirements are lists of alternatives, each
+// alternative a list of schemes that must all be satisfied:
+// [["bearerAuth", "apiToken"], ["mtls"]] means bearerAuth and apiToken, or mtls.
+type SecurityConfig struct {
+	Schemes    map[string]SecurityScheme `json:"schemes"`
+	Middleware map[string][][]string     `json:"middleware"` // by middleware name, e.g. APIKeyAuth
+	Routes     map[string][][]string     `json:"routes"`     // by "METHOD /path", overriding middleware
+	Default    [][]string                `json:"default"`    // routes behind another auth middleware (default: bearerAuth)
+}
+
+// securitySchemes returns the default bearerAuth scheme and the configured
+// schemes, which may redefine it
+func (g *Generator) securitySchemes() map[string]SecurityScheme {
+	schemes := map[string]SecurityScheme{
+		defaultSecurityScheme: {
+			Type:         "http",
+			Scheme:       "bearer",
+			BearerFormat: "JWT",
+			Description:  "Authorization header using Bearer token",
+		},
+	}
+	for name, scheme := range g.config.Security.Schemes {
+		schemes[name] = scheme
+	}
+	return schemes
+}
+
+// checkSecurityConfig warns about requirements naming undeclared schemes
+func (g *Generator) checkSecurityConfig(schemes map[string]SecurityScheme) {
+	requirements := [][][]string{g.config.Security.Default}
+	for _, names := range []map[string][][]string{g.config.Security.Middleware, g.config.Security.Routes} {
+		for _, requirement := range names {
+			requirements = append(requirements, requirement)
+		}
+	}
+
+	unknown := make(map[string]bool)
+	for _, requirement := range requirements {
+		for _, alternative := range requirement {
+			for _, name := range alternative {
+				if _, exists := schemes[name]; !exists {
+					unknown[name] = true
+				}
+			}
+		}
+	}
+	names := make([]string, 0, len(unknown))
+	for name := range unknown {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Printf("Warning: security requirement references undeclared scheme '%s'\n", name)
+	}
+}
+
+// routeSecurity returns the security requirements of a route: the route's
+// own entry, or those of its middleware combined, every middleware having
+// to be satisfied
+func (g *Generator) routeSecurity(route analyzer.Route) []map[string][]string {
+	method := strings.ToUpper(route.Method)
+	for _, key := range []string{method + " " + route.Path, method + " " + g.convertPathFormat(route.Path)} {
+		if requirement, exists := g.config.Security.Routes[key]; exists {
+			return securityRequirements(requirement)
+		}
+	}
+
+	var combined [][]string
+	matched := false
+	for _, mw := range route.Middleware {
+		requirement, exists := g.config.Security.Middleware[mw]
+		if !exists {
+			// middleware.APIKeyAuth may be configured as APIKeyAuth
+			requirement, exists = g.config.Security.Middleware[mw[strings.LastIndex(mw, ".")+1:]]
+		}
+		if !exists {
+			continue
+		}
+		combined = andRequirements(combined, requirement, matched)
+		matched = true
+	}
+	if matched {
+		return securityRequirements(combined)
+	}
+
+	if g.hasAuthMiddleware(route.Middleware) {
+		if len(g.config.Security.Default) > 0 {
+			return securityRequirements(g.config.Security.Default)
+		}
+		return securityRequirements([][]string{{defaultSecurityScheme}})
+	}
+	return nil
+}
+
+// andRequirements requires both a and b: every alternative of a joined
+// with every alternative of b
+func andRequirements(a, b [][]string, haveA bool) [][]string {
+	if !haveA {
+		return b
+	}
+	var combined [][]string
+	for _, x := range a {
+		for _, y := range b {
+			alternative := append(append([]string{}, x...), y...)
+			combined = append(combined, alternative)
+		}
+	}
+	return combined
+}
+
+// securityRequirements converts alternatives of scheme names to OpenAPI
+// security requirement objects
+func securityRequirements(alternatives [][]string) []map[string][]string {
+	requirements := make([]map[string][]string, 0, len(alternatives))
+	for _, alternative := range alternatives {
+		requirement := make(map[string][]string)
+		for _, name := range alternative {
+			requirement[name] = []string{}
+		}
+		requirements = append(requirements, requirement)
+	}
+	return requirements
+}
@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+)
+
+// SLA documents service level objectives, emitted as x-sla on operations
+type SLA struct {
+	P95LatencyMs int    `json:"p95_latency_ms,omitempty" yaml:"p95_latency_ms,omitempty"`
+	P99LatencyMs int    `json:"p99_latency_ms,omitempty" yaml:"p99_latency_ms,omitempty"`
+	Availability string `json:"availability,omitempty" yaml:"availability,omitempty"`
+}
+
+// SLAConfig holds SLOs per tag and per route ("GET /users/:id"). Route
+// entries override the tag entry field by field.
+type SLAConfig struct {
+	Tags   map[string]SLA `json:"tags"`
+	Routes map[string]SLA `json:"routes"`
+}
+
+func (g *Generator) applySLA(operation *Operation, route analyzer.Route) {
+	var sla SLA
+	found := false
+
+	for _, tag := range route.Tags {
+		if tagSLA, exists := g.config.SLA.Tags[tag]; exists {
+			sla = mergeSLA(sla, tagSLA)
+			found = true
+		}
+	}
+
+	// Routes may be keyed with either the Fiber or the OpenAPI path format
+	method := strings.ToUpper(route.Method)
+	for _, key := range []string{method + " " + route.Path, method + " " + g.convertPathFormat(route.Path)} {
+		if routeSLA, exists := g.config.SLA.Routes[key]; exists {
+			sla = mergeSLA(sla, routeSLA)
+			found = true
+			break
+		}
+	}
+
+	if found {
+		operation.SLA = &sla
+	}
+}
+
+func mergeSLA(base, override SLA) SLA {
+	if override.P95LatencyMs != 0 {
+		base.P95LatencyMs = override.P95LatencyMs
+	}
+	if override.P99LatencyMs != 0 {
+		base.P99LatencyMs = override.P99LatencyMs
+	}
+	if override.Availability != "" {
+		base.Availability = override.Availability
+	}
+	return base
+}
@@ -0,0 +1,172 @@
+package generator
+
+import (
+	"strings"
+)
+
+// pathMethods are the methods of a path item's operations
+var pathMethods = []string{"get", "post", "put", "delete", "patch"}
+
+// untaggedSpec names the spec of operations without a tag in SplitByTag
+const untaggedSpec = "default"
+
+// SplitByTag returns a spec per tag holding the operations with that tag,
+// and only the schemas and security schemes they use. Operations with
+// several tags are in each of their tags' specs; operations without a tag
+// are in the default spec.
+func SplitByTag(spec *OpenAPISpec) map[string]*OpenAPISpec {
+	specs := make(map[string]*OpenAPISpec)
+	tagSpec := func(tag string) *OpenAPISpec {
+		if tagged, exists := specs[tag]; exists {
+			return tagged
+		}
+		tagged := &OpenAPISpec{
+			OpenAPI: spec.OpenAPI,
+			Info:    spec.Info,
+			Servers: spec.Servers,
+			Paths:   make(map[string]PathItem),
+			Components: Components{
+				Schemas:         make(map[string]Schema),
+				SecuritySchemes: make(map[string]SecurityScheme),
+			},
+		}
+		for _, t := range spec.Tags {
+			if t.Name == tag {
+				tagged.Tags = []Tag{t}
+			}
+		}
+		specs[tag] = tagged
+		return tagged
+	}
+
+	for path, pathItem := range spec.Paths {
+		for _, method := range pathMethods {
+			operation := pathItem.operation(method)
+			if operation == nil {
+				continue
+			}
+			tags := operation.Tags
+			if len(tags) == 0 {
+				tags = []string{untaggedSpec}
+			}
+			for _, tag := range tags {
+				tagged := tagSpec(tag)
+				taggedItem := tagged.Paths[path]
+				taggedItem.setOperation(method, operation)
+				tagged.Paths[path] = taggedItem
+			}
+		}
+	}
+
+	for _, tagged := range specs {
+		copyUsedComponents(spec, tagged)
+	}
+	return specs
+}
+
+// copyUsedComponents copies the schemas the operations of tagged reference,
+// directly or through other schemas, and the security schemes they require
+func copyUsedComponents(spec, tagged *OpenAPISpec) {
+	var pending []string
+	addRef := func(ref string) {
+		name := strings.TrimPrefix(ref, "#/components/schemas/")
+		if _, done := tagged.Components.Schemas[name]; done {
+			return
+		}
+		if schema, exists := spec.Components.Schemas[name]; exists {
+			tagged.Components.Schemas[name] = schema
+			pending = append(pending, name)
+		}
+	}
+
+	for _, pathItem := range tagged.Paths {
+		for _, method := range pathMethods {
+			operation := pathItem.operation(method)
+			if operation == nil {
+				continue
+			}
+			for _, param := range operation.Parameters {
+				schemaRefs(param.Schema, addRef)
+			}
+			if operation.RequestBody != nil {
+				for _, content := range operation.RequestBody.Content {
+					schemaRefs(content.Schema, addRef)
+				}
+			}
+			for _, response := range operation.Responses {
+				for _, content := range response.Content {
+					schemaRefs(content.Schema, addRef)
+				}
+			}
+			for _, requirement := range operation.Security {
+				for name := range requirement {
+					if scheme, exists := spec.Components.SecuritySchemes[name]; exists {
+						tagged.Components.SecuritySchemes[name] = scheme
+					}
+				}
+			}
+		}
+	}
+
+	for len(pending) > 0 {
+		name := pending[len(pending)-1]
+		pending = pending[:len(pending)-1]
+		schemaRefs(tagged.Components.Schemas[name], addRef)
+	}
+}
+
+// schemaRefs calls fn with every reference in schema and its subschemas
+func schemaRefs(schema Schema, fn func(ref string)) {
+	if schema.Ref != "" {
+		fn(schema.Ref)
+	}
+	for _, property := range schema.Properties {
+		schemaRefs(property, fn)
+	}
+	if schema.Items != nil {
+		schemaRefs(*schema.Items, fn)
+	}
+	switch additional := schema.AdditionalProperties.(type) {
+	case *Schema:
+		schemaRefs(*additional, fn)
+	case Schema:
+		schemaRefs(additional, fn)
+	}
+	for _, list := range [][]Schema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, sub := range list {
+			schemaRefs(sub, fn)
+		}
+	}
+}
+
+// operation returns the path item's operation for a lowercase method
+func (p PathItem) operation(method string) *Operation {
+	switch method {
+	case "get":
+		return p.Get
+	case "post":
+		return p.Post
+	case "put":
+		return p.Put
+	case "delete":
+		return p.Delete
+	case "patch":
+		return p.Patch
+	}
+	return nil
+}
+
+func (p *PathItem) setOperation(method string, operation *Operation) {
+	switch method {
+	case "get":
+		p.Get = operation
+	case "post":
+		p.Post = operation
+	case "put":
+		p.Put = operation
+	case "delete":
+		p.Delete = operation
+	case "patch":
+		p.Patch = operation
+	}
+}
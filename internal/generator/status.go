@@ -0,0 +1,71 @@
+package generator
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+)
+
+// applyStatuses documents the statuses a handler sets, c.Status(201) or
+// c.Status(fiber.StatusNotFound): the successful response moves to the 2xx
+// codes it answers with, 200 included when a response sets no status, and
+// error codes get error responses. 202 Accepted is left to asyncJob; a
+// handler answering it alone has no 200 response.
+func (g *Generator) applyStatuses(operation *Operation, route analyzer.Route) {
+	if len(route.Statuses) == 0 || route.WebSocket || route.StaticRoot != "" {
+		return
+	}
+
+	success, documented := operation.Responses["200"]
+	var successes []int
+	for _, status := range route.Statuses {
+		switch {
+		case status >= 200 && status < 300 && status != http.StatusAccepted:
+			successes = append(successes, status)
+		case status >= 300 && status < 400:
+			if _, exists := operation.Responses[strconv.Itoa(status)]; !exists {
+				operation.Responses[strconv.Itoa(status)] = Response{Description: statusDescription(status)}
+			}
+		case status >= 400:
+			if _, exists := operation.Responses[strconv.Itoa(status)]; !exists {
+				operation.Responses[strconv.Itoa(status)] = g.generateErrorResponse(statusDescription(status))
+			}
+		}
+	}
+	if !documented {
+		return
+	}
+	if len(successes) == 0 {
+		if route.Accepted {
+			delete(operation.Responses, "200")
+		}
+		return
+	}
+
+	delete(operation.Responses, "200")
+	for _, status := range successes {
+		response := success
+		switch status {
+		case http.StatusOK:
+		case http.StatusNoContent:
+			response = Response{Description: statusDescription(status), Headers: success.Headers}
+		default:
+			response.Description = statusDescription(status)
+		}
+		operation.Responses[strconv.Itoa(status)] = response
+	}
+}
+
+// statusDescription is the status text of a code in sentence case, like
+// "Not found", keeping acronyms like HTTP
+func statusDescription(status int) string {
+	words := strings.Fields(http.StatusText(status))
+	for i, word := range words {
+		if i > 0 && word != strings.ToUpper(word) {
+			words[i] = strings.ToLower(word)
+		}
+	}
+	return strings.Join(words, " ")
+}
@@ -0,0 +1,44 @@
+package generator
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+)
+
+// SunsetLayout is the layout of @sunset dates and of x-sunset
+const SunsetLayout = "2006-01-02"
+
+// applySunset deprecates an operation with a "// @sunset 2025-12-31"
+// annotation, records the date as x-sunset and documents the Sunset header
+// (RFC 8594) its successful responses carry until then
+func (g *Generator) applySunset(operation *Operation, route analyzer.Route) {
+	annotation, annotated := route.Annotations["sunset"]
+	if !annotated {
+		return
+	}
+	date, err := time.Parse(SunsetLayout, strings.TrimSpace(annotation))
+	if err != nil {
+		fmt.Printf("Warning: invalid @sunset date '%s' on handler '%s' (expected YYYY-MM-DD)\n", annotation, route.Handler)
+		return
+	}
+
+	operation.Deprecated = true
+	operation.Sunset = date.Format(SunsetLayout)
+	for code, response := range operation.Responses {
+		if !strings.HasPrefix(code, "2") {
+			continue
+		}
+		if response.Headers == nil {
+			response.Headers = make(map[string]Header)
+		}
+		response.Headers["Sunset"] = Header{
+			Description: "The operation is retired after this date",
+			Schema:      Schema{Type: "string", Example: date.UTC().Format(http.TimeFormat)},
+		}
+		operation.Responses[code] = response
+	}
+}
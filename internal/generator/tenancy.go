@@ -0,0 +1,82 @@
+package generator
+
+import (
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+)
+
+// TenancyConfig declares the headers identifying the tenant of a request
+type TenancyConfig struct {
+	Headers []TenancyHeader `json:"headers"`
+}
+
+// TenancyHeader is a header required by every operation under its path
+// prefixes, like X-Tenant-ID under /api
+type TenancyHeader struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Format      string   `json:"format"`   // string format of the value, e.g. uuid
+	Prefixes    []string `json:"prefixes"` // OpenAPI paths the header applies to; every path if empty
+}
+
+// applyTenancy adds the tenancy headers matching the route's path as
+// required header parameters, and documents the 400 returned when one is
+// missing
+func (g *Generator) applyTenancy(operation *Operation, route analyzer.Route) {
+	path := g.convertPathFormat(route.Path)
+	var missing []string
+	for _, header := range g.config.Tenancy.Headers {
+		if header.Name == "" || !matchesPrefix(path, header.Prefixes) {
+			continue
+		}
+		missing = append(missing, header.Name)
+
+		param := Parameter{
+			Name:        header.Name,
+			In:          "header",
+			Required:    true,
+			Description: header.Description,
+			Schema:      Schema{Type: "string", Format: header.Format},
+		}
+		if param.Description == "" {
+			param.Description = "Tenant the request is made for"
+		}
+
+		// A header the handler reads itself is completed, not repeated
+		replaced := false
+		for i, existing := range operation.Parameters {
+			if existing.In == "header" && strings.EqualFold(existing.Name, header.Name) {
+				operation.Parameters[i] = param
+				replaced = true
+			}
+		}
+		if !replaced {
+			operation.Parameters = append(operation.Parameters, param)
+		}
+	}
+	if len(missing) == 0 {
+		return
+	}
+
+	response, exists := operation.Responses["400"]
+	if !exists {
+		response = g.generateErrorResponse("Bad request")
+	}
+	response.Description += "; also returned when the " + strings.Join(missing, " or ") + " header is missing"
+	operation.Responses["400"] = response
+}
+
+// matchesPrefix reports whether path is one of the prefixes or under one
+func matchesPrefix(path string, prefixes []string) bool {
+	if len(prefixes) == 0 {
+		return true
+	}
+	for _, prefix := range prefixes {
+		prefix = strings.TrimSuffix(prefix, "/")
+		if prefix == "" || path == prefix || strings.HasPrefix(path, prefix+"/") {
+			return true
+		}
+	}
+	return false
+}
@@ -76,6 +76,14 @@ func (g *Generator) updateSchemaReferences(schema Schema, oldToNewNames map[stri
 			schema.AdditionalProperties = updated
 		}
 	}
+
+	// Update allOf parts and oneOf alternatives
+	for i, part := range schema.AllOf {
+		schema.AllOf[i] = g.updateSchemaReferences(part, oldToNewNames)
+	}
+	for i, option := range schema.OneOf {
+		schema.OneOf[i] = g.updateSchemaReferences(option, oldToNewNames)
+	}
 	
 	return schema
 }
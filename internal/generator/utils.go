@@ -1,11 +1,17 @@
 package generator
 import (
+	"math"
 	"regexp"
 	"strings"
 
 )
 
 func (g *Generator) isCustomType(typeName string) bool {
+	// Types of common packages are inlined, see wellKnownSchema
+	if _, ok := g.wellKnownSchema(typeName); ok {
+		return false
+	}
+
 	// Clean the type name first
 	cleanType := g.cleanTypeName(typeName)
 
@@ -14,6 +20,16 @@ func (g *Generator) isCustomType(typeName string) bool {
 		return false
 	}
 
+	// Empty structs left out of the spec can't be referenced
+	if g.isSkippedModel(cleanType) {
+		return false
+	}
+
+	// Named types are documented as their underlying type
+	if _, named := g.namedUnderlying(cleanType); named {
+		return false
+	}
+
 	// Consider it a custom type if it doesn't match Go built-in types
 	builtinTypes := map[string]bool{
 		"string":      true,
@@ -45,6 +61,11 @@ func (g *Generator) isCustomType(typeName string) bool {
 		return false
 	}
 
+	// Named string types with a format are inlined, not referenced
+	if g.isFormattedType(typeName) || g.isFormattedType(cleanType) {
+		return false
+	}
+
 	// Check if it starts with uppercase (exported type)
 	if len(cleanType) > 0 && cleanType[0] >= 'A' && cleanType[0] <= 'Z' {
 		return true
@@ -136,6 +157,41 @@ func (g *Generator) cleanSchema(schema Schema, allSchemas map[string]Schema) Sch
 			cleaned.AdditionalProperties = &cleanAdditional
 		}
 	}
+
+	// Clean up allOf parts, falling back to an object if a reference could not be resolved
+	if schema.AllOf != nil {
+		cleanAllOf := []Schema{}
+		for _, part := range schema.AllOf {
+			cleanPart := g.cleanSchema(part, allSchemas)
+			if part.Ref != "" && cleanPart.Ref == "" {
+				cleanAllOf = nil
+				break
+			}
+			cleanAllOf = append(cleanAllOf, cleanPart)
+		}
+		cleaned.AllOf = cleanAllOf
+		if len(cleanAllOf) == 0 {
+			cleaned.AllOf = nil
+			cleaned.Type = "object"
+		}
+	}
+
+	// Clean up oneOf alternatives, dropping references that could not be resolved
+	if schema.OneOf != nil {
+		cleanOneOf := []Schema{}
+		for _, option := range schema.OneOf {
+			cleanOption := g.cleanSchema(option, allSchemas)
+			if option.Ref != "" && cleanOption.Ref == "" {
+				continue
+			}
+			cleanOneOf = append(cleanOneOf, cleanOption)
+		}
+		cleaned.OneOf = cleanOneOf
+		if len(cleanOneOf) == 0 {
+			cleaned.OneOf = nil
+			cleaned.Type = "object"
+		}
+	}
 	
 	return cleaned
 }
@@ -233,4 +289,33 @@ func (g *Generator) extractMapValueType(mapType string) string {
 	// The cleanTypeName function will handle it later if needed
 	
 	return valueType
-}
\ No newline at end of file
+}
+// applyIntegerBounds sets minimum/maximum for unsigned and sized integer
+// types. Unsigned types wider than int32 get format int64, which their
+// range fits in, rather than int32.
+func (g *Generator) applyIntegerBounds(schema *Schema, typeName string) {
+	bounds := map[string][2]int64{
+		"int8":   {math.MinInt8, math.MaxInt8},
+		"int16":  {math.MinInt16, math.MaxInt16},
+		"uint8":  {0, math.MaxUint8},
+		"uint16": {0, math.MaxUint16},
+		"uint32": {0, math.MaxUint32},
+	}
+
+	if bound, exists := bounds[typeName]; exists {
+		minimum, maximum := bound[0], bound[1]
+		schema.Minimum = &minimum
+		schema.Maximum = &maximum
+		if maximum > math.MaxInt32 {
+			schema.Format = "int64"
+		}
+		return
+	}
+
+	// Platform-sized and 64-bit unsigned types only get a lower bound
+	if typeName == "uint" || typeName == "uint64" || typeName == "uintptr" {
+		minimum := int64(0)
+		schema.Minimum = &minimum
+		schema.Format = "int64"
+	}
+}
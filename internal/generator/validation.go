@@ -0,0 +1,162 @@
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// validateFormats are the validate rules that document a string format
+var validateFormats = map[string]string{
+	"email":            "email",
+	"url":              "uri",
+	"uri":              "uri",
+	"http_url":         "uri",
+	"uuid":             "uuid",
+	"uuid3":            "uuid",
+	"uuid4":            "uuid",
+	"uuid5":            "uuid",
+	"ipv4":             "ipv4",
+	"ipv6":             "ipv6",
+	"hostname":         "hostname",
+	"hostname_rfc1123": "hostname",
+}
+
+// oneOfValuePattern matches the values of a oneof rule, which are separated
+// by spaces and may be single-quoted to contain spaces
+var oneOfValuePattern = regexp.MustCompile(`'[^']*'|\S+`)
+
+// applyValidation documents the rules of a field's validate tag, like
+// validate:"min=1,max=50,email,oneof=a b", as constraints of its schema:
+// lengths for strings, sizes for arrays, bounds for numbers, formats and
+// enums. Rules after dive apply to the elements of arrays and the values of
+// maps. Rules with no schema equivalent, and required, which is handled by
+// the analyzer, are ignored.
+func (g *Generator) applyValidation(schema Schema, rules, fieldName string) Schema {
+	own := strings.Split(rules, ",")
+	var elements string
+	for i, rule := range own {
+		if strings.TrimSpace(rule) == "dive" {
+			own, elements = own[:i], strings.Join(own[i+1:], ",")
+			break
+		}
+	}
+
+	var oneOf []string
+	inKeys := false
+	for _, rule := range own {
+		name, param, _ := strings.Cut(strings.TrimSpace(rule), "=")
+		switch {
+		case name == "keys":
+			inKeys = true
+			continue
+		case name == "endkeys":
+			inKeys = false
+			continue
+		case inKeys || strings.Contains(rule, "|"):
+			continue
+		}
+
+		switch name {
+		case "min", "max", "len", "gt", "gte", "lt", "lte":
+			bound, err := strconv.ParseInt(param, 10, 64)
+			if err != nil {
+				fmt.Printf("[DEBUG] Ignoring validate rule %s of field %s: only integer bounds are documented\n", rule, fieldName)
+				continue
+			}
+			applyBound(&schema, name, bound)
+		case "oneof":
+			for _, value := range oneOfValuePattern.FindAllString(param, -1) {
+				oneOf = append(oneOf, strings.Trim(value, "'"))
+			}
+		default:
+			if format, ok := validateFormats[name]; ok && schema.Type == "string" && schema.Format == "" {
+				schema.Format = format
+			}
+		}
+	}
+
+	if elements != "" {
+		switch {
+		case schema.Type == "array" && schema.Items != nil:
+			items := g.applyValidation(*schema.Items, elements, fieldName)
+			schema.Items = &items
+		case schema.Type == "object":
+			if values, ok := schema.AdditionalProperties.(*Schema); ok {
+				validated := g.applyValidation(*values, elements, fieldName)
+				schema.AdditionalProperties = &validated
+			}
+		}
+	}
+
+	if len(oneOf) > 0 {
+		schema = g.oneOfEnum(schema, oneOf, fieldName)
+	}
+	return schema
+}
+
+// applyBound documents a min, max, len, gt, gte, lt or lte rule: the length
+// of a string, the size of an array or the value of a number
+func applyBound(schema *Schema, rule string, bound int64) {
+	var lower, upper **int64
+	switch schema.Type {
+	case "string":
+		lower, upper = &schema.MinLength, &schema.MaxLength
+	case "array":
+		lower, upper = &schema.MinItems, &schema.MaxItems
+	case "integer", "number":
+		lower, upper = &schema.Minimum, &schema.Maximum
+		switch rule {
+		case "gt":
+			schema.ExclusiveMinimum = true
+			*lower = &bound
+			return
+		case "lt":
+			schema.ExclusiveMaximum = true
+			*upper = &bound
+			return
+		}
+	default:
+		return
+	}
+
+	// Lengths and sizes are whole, so gt=3 is a minimum of 4
+	switch rule {
+	case "min", "gte":
+		*lower = &bound
+	case "gt":
+		bound++
+		*lower = &bound
+	case "max", "lte":
+		*upper = &bound
+	case "lt":
+		bound--
+		*upper = &bound
+	case "len":
+		*lower, *upper = &bound, &bound
+	}
+}
+
+// oneOfEnum documents the values of a oneof rule as the schema's enum,
+// typed after the schema
+func (g *Generator) oneOfEnum(schema Schema, values []string, fieldName string) Schema {
+	if schema.Type != "integer" && schema.Type != "number" {
+		return g.enumSchema(schema, fieldName, values)
+	}
+	enum := make([]interface{}, 0, len(values))
+	for _, value := range values {
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			fmt.Printf("[DEBUG] Ignoring oneof value '%s' of numeric field %s\n", value, fieldName)
+			continue
+		}
+		if schema.Type == "integer" {
+			enum = append(enum, int64(number))
+		} else {
+			enum = append(enum, number)
+		}
+	}
+	schema.Enum = enum
+	return schema
+}
@@ -0,0 +1,107 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+)
+
+// Policies for decimal and big number types, set with Config.Decimals
+const (
+	DecimalString = "string"
+	DecimalNumber = "number"
+)
+
+// decimalTypes are the arbitrary-precision number types by package and
+// name, with the JSON type they are documented as under the number policy
+var decimalTypes = map[string]string{
+	"decimal.Decimal":     "number",
+	"decimal.NullDecimal": "number",
+	"apd.Decimal":         "number",
+	"big.Int":             "integer",
+	"big.Float":           "number",
+}
+
+// Representations of time.Duration, set with Config.Durations
+const (
+	DurationInteger = "integer"
+	DurationString  = "string"
+)
+
+// wellKnownSchema returns the schema of a type of the standard library or a
+// common package the generator documents itself, rather than as a reference
+// to a model that doesn't exist: null types, see nullTypeSchema, decimal
+// types, see decimalSchema, time.Duration, see durationSchema, and
+// json.RawMessage, which holds any JSON value and has a schema without a type,
+// and the 64-bit integers of proto messages, strings in their JSON
+func (g *Generator) wellKnownSchema(typeName string) (Schema, bool) {
+	switch strings.ReplaceAll(typeName, "*", "") {
+	case "json.RawMessage":
+		return Schema{Description: "Any JSON value"}, true
+	case analyzer.ProtoInt64:
+		return Schema{Type: "string", Format: "int64"}, true
+	}
+	if schema, ok := nullTypeSchema(typeName); ok {
+		return schema, true
+	}
+	if schema, ok := g.durationSchema(typeName); ok {
+		return schema, true
+	}
+	return g.decimalSchema(typeName)
+}
+
+// durationSchema returns the schema of time.Duration: the int64 count of
+// nanoseconds it marshals to, or with the string representation a Go
+// duration string like 1h30m, for projects marshaling durations with
+// String
+func (g *Generator) durationSchema(typeName string) (Schema, bool) {
+	if strings.ReplaceAll(typeName, "*", "") != "time.Duration" {
+		return Schema{}, false
+	}
+	if g.config.Durations == DurationString {
+		return Schema{
+			Type:        "string",
+			Description: "Duration in Go's format, like 1h30m or 250ms",
+			Example:     "1h30m",
+		}, true
+	}
+	return Schema{Type: "integer", Format: "int64", Description: "Duration in nanoseconds"}, true
+}
+
+// decimalSchema returns the schema of a decimal or big number type, like
+// decimal.Decimal or *big.Int: a string with the decimal format, which keeps
+// its precision in clients parsing JSON numbers as floats, or a number with
+// the number policy
+func (g *Generator) decimalSchema(typeName string) (Schema, bool) {
+	typeName = strings.ReplaceAll(typeName, "*", "")
+	numberType, ok := decimalTypes[typeName]
+	if !ok {
+		return Schema{}, false
+	}
+	schema := Schema{Type: "string", Format: "decimal"}
+	if g.config.Decimals == DecimalNumber {
+		schema = Schema{Type: numberType}
+	}
+	schema.Nullable = typeName == "decimal.NullDecimal"
+	return schema, true
+}
+
+// checkWellKnown warns about unknown decimal, duration and pointer policies
+func (g *Generator) checkWellKnown() {
+	switch g.config.Decimals {
+	case "", DecimalString, DecimalNumber:
+	default:
+		fmt.Printf("Warning: unknown decimal policy '%s'; use string or number\n", g.config.Decimals)
+	}
+	switch g.config.Durations {
+	case "", DurationInteger, DurationString:
+	default:
+		fmt.Printf("Warning: unknown duration representation '%s'; use integer or string\n", g.config.Durations)
+	}
+	switch g.config.Pointers {
+	case "", PointersNullable, PointersPlain:
+	default:
+		fmt.Printf("Warning: unknown pointer policy '%s'; use nullable or plain\n", g.config.Pointers)
+	}
+}
@@ -0,0 +1,155 @@
+package linter
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+)
+
+// Severities
+const (
+	SeverityError   = "error"
+	SeverityWarning = "warning"
+	SeverityInfo    = "info"
+	SeverityOff     = "off"
+)
+
+// defaultSeverities lists every rule with the severity used unless the
+// config overrides it
+var defaultSeverities = map[string]string{
+	RuleParamNaming:       SeverityWarning,
+	RuleParamConflict:     SeverityWarning,
+	RulePathKebabCase:     SeverityWarning,
+	RulePathPlural:        SeverityInfo,
+	RulePathTrailingSlash: SeverityWarning,
+	RulePathNoVerbs:       SeverityWarning,
+	RulePropertyCase:      SeverityWarning,
+	RulePagination:        SeverityWarning,
+	RuleErrorFormat:       SeverityWarning,
+	RuleVersioning:        SeverityWarning,
+	RuleSunsetPassed:      SeverityWarning,
+}
+
+// Config controls the lint rules
+type Config struct {
+	Enabled         bool              `json:"enabled"`
+	NormalizeParams bool              `json:"normalize_params"` // rename parameters to their canonical name
+	ParamNames      map[string]string `json:"param_names"`      // explicit name -> canonical name
+	ParamCase       string            `json:"param_case"`       // case of canonical names: snake_case or camelCase; renames like NormalizeParams
+	Rules           map[string]string `json:"rules"`            // rule -> severity (error|warning|info|off)
+	Pack            string            `json:"pack"`             // built-in policy: zalando, google or custom, see packs
+	Policy          Policy            `json:"policy"`           // conventions overriding the pack's
+}
+
+// severity returns the configured severity of a rule, falling back to the
+// pack's and then its default for unknown values
+func (c Config) severity(rule string) string {
+	for _, severity := range []string{c.Rules[rule], c.policy().Rules[rule]} {
+		switch severity {
+		case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+			return severity
+		}
+	}
+	return defaultSeverities[rule]
+}
+
+// Normalizes reports whether parameters are renamed to their canonical
+// name, see NormalizeParams
+func (c Config) Normalizes() bool {
+	return c.NormalizeParams || c.ParamCase != ""
+}
+
+// validate warns about unknown rules and severities in the config
+func (c Config) validate() {
+	if _, ok := casePatterns[c.ParamCase]; !ok && c.ParamCase != "" {
+		fmt.Printf("Warning: unknown parameter case %q (use %s or %s)\n", c.ParamCase, CaseSnake, CaseCamel)
+	}
+	for rule, severity := range c.Rules {
+		if _, ok := defaultSeverities[rule]; !ok {
+			fmt.Printf("Warning: unknown lint rule %q\n", rule)
+		}
+		switch severity {
+		case SeverityError, SeverityWarning, SeverityInfo, SeverityOff:
+		default:
+			fmt.Printf("Warning: unknown severity %q for lint rule %q (use error, warning, info or off)\n", severity, rule)
+		}
+	}
+}
+
+// Finding is a single lint result
+type Finding struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Location string `json:"location"`
+	Message  string `json:"message"`
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s [%s] %s: %s", f.Severity, f.Rule, f.Location, f.Message)
+}
+
+// Lint runs all enabled rules against a generated spec
+func Lint(spec *generator.OpenAPISpec, config Config) []Finding {
+	config.validate()
+
+	var findings []Finding
+	findings = append(findings, lintParamNames(spec, config)...)
+	findings = append(findings, lintPaths(spec, config)...)
+	findings = append(findings, lintPolicy(spec, config)...)
+	findings = append(findings, lintSunset(spec, config, time.Now())...)
+
+	// Drop rules turned off in the config
+	enabled := findings[:0]
+	for _, finding := range findings {
+		if finding.Severity != SeverityOff {
+			enabled = append(enabled, finding)
+		}
+	}
+	return enabled
+}
+
+// Errors counts the findings with error severity
+func Errors(findings []Finding) int {
+	count := 0
+	for _, finding := range findings {
+		if finding.Severity == SeverityError {
+			count++
+		}
+	}
+	return count
+}
+
+type operationEntry struct {
+	method    string
+	path      string
+	operation *generator.Operation
+}
+
+func (e operationEntry) location() string {
+	return e.method + " " + e.path
+}
+
+// operations lists the spec's operations in a stable order
+func operations(spec *generator.OpenAPISpec) []operationEntry {
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var entries []operationEntry
+	for _, path := range paths {
+		item := spec.Paths[path]
+		for _, entry := range []operationEntry{
+			{"GET", path, item.Get}, {"POST", path, item.Post}, {"PUT", path, item.Put},
+			{"DELETE", path, item.Delete}, {"PATCH", path, item.Patch},
+		} {
+			if entry.operation != nil {
+				entries = append(entries, entry)
+			}
+		}
+	}
+	return entries
+}
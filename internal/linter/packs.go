@@ -0,0 +1,307 @@
+package linter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+)
+
+// Governance rules, checked when the policy of the selected pack sets their
+// convention
+const (
+	RulePropertyCase = "property-case"
+	RulePagination   = "pagination"
+	RuleErrorFormat  = "error-format"
+	RuleVersioning   = "versioning"
+)
+
+// Property name cases
+const (
+	CaseSnake = "snake_case"
+	CaseCamel = "camelCase"
+)
+
+// Versioning conventions
+const (
+	VersioningPath = "path" // every path starts with a version segment, like /v1
+	VersioningNone = "none" // no path has a version segment; versions go in media types
+)
+
+var casePatterns = map[string]*regexp.Regexp{
+	CaseSnake: regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`),
+	CaseCamel: regexp.MustCompile(`^[a-z][a-zA-Z0-9]*$`),
+}
+
+// Policy holds the API conventions the governance rules check; empty
+// fields leave their rule out
+type Policy struct {
+	PropertyCase     string            `json:"property_case"`     // snake_case or camelCase
+	PaginationParams []string          `json:"pagination_params"` // query parameters every GET of a collection takes
+	ErrorMediaType   string            `json:"error_media_type"`  // media type of 4xx and 5xx responses
+	ErrorProperties  []string          `json:"error_properties"`  // properties of the schema of 4xx and 5xx responses
+	Versioning       string            `json:"versioning"`        // path or none
+	Rules            map[string]string `json:"rules"`             // severities of the pack's rules
+}
+
+// packs are the built-in policies selectable with Config.Pack
+var packs = map[string]Policy{
+	// Zalando RESTful API guidelines: snake_case properties, cursor-based
+	// pagination, problem+json errors and no versions in paths
+	"zalando": {
+		PropertyCase:     CaseSnake,
+		PaginationParams: []string{"cursor", "limit"},
+		ErrorMediaType:   "application/problem+json",
+		ErrorProperties:  []string{"type", "title", "status"},
+		Versioning:       VersioningNone,
+		Rules: map[string]string{
+			RulePathKebabCase: SeverityError,
+			RulePathPlural:    SeverityWarning,
+			RulePathNoVerbs:   SeverityError,
+		},
+	},
+	// Google API improvement proposals, roughly: lowerCamelCase properties,
+	// page_size and page_token pagination, errors wrapped in an error object
+	// and the major version in the path
+	"google": {
+		PropertyCase:     CaseCamel,
+		PaginationParams: []string{"page_size", "page_token"},
+		ErrorProperties:  []string{"error"},
+		Versioning:       VersioningPath,
+		Rules: map[string]string{
+			RulePathPlural: SeverityWarning,
+		},
+	},
+}
+
+// policy returns the conventions of the selected pack with the config's
+// policy overriding them; the custom pack is the config's policy alone
+func (c Config) policy() Policy {
+	policy := packs[c.Pack]
+	custom := c.Policy
+	if custom.PropertyCase != "" {
+		policy.PropertyCase = custom.PropertyCase
+	}
+	if len(custom.PaginationParams) > 0 {
+		policy.PaginationParams = custom.PaginationParams
+	}
+	if custom.ErrorMediaType != "" {
+		policy.ErrorMediaType = custom.ErrorMediaType
+	}
+	if len(custom.ErrorProperties) > 0 {
+		policy.ErrorProperties = custom.ErrorProperties
+	}
+	if custom.Versioning != "" {
+		policy.Versioning = custom.Versioning
+	}
+	rules := make(map[string]string)
+	for _, overrides := range []map[string]string{policy.Rules, custom.Rules} {
+		for rule, severity := range overrides {
+			rules[rule] = severity
+		}
+	}
+	policy.Rules = rules
+	return policy
+}
+
+// validatePolicy warns about unknown packs and conventions
+func (c Config) validatePolicy(policy Policy) {
+	if _, ok := packs[c.Pack]; !ok && c.Pack != "" && c.Pack != "custom" {
+		fmt.Printf("Warning: unknown lint pack %q (use zalando, google or custom)\n", c.Pack)
+	}
+	for rule := range c.Policy.Rules {
+		if _, ok := defaultSeverities[rule]; !ok {
+			fmt.Printf("Warning: unknown lint rule %q in the policy\n", rule)
+		}
+	}
+	if _, ok := casePatterns[policy.PropertyCase]; !ok && policy.PropertyCase != "" {
+		fmt.Printf("Warning: unknown property case %q (use %s or %s)\n", policy.PropertyCase, CaseSnake, CaseCamel)
+	}
+	switch policy.Versioning {
+	case "", VersioningPath, VersioningNone:
+	default:
+		fmt.Printf("Warning: unknown versioning %q (use %s or %s)\n", policy.Versioning, VersioningPath, VersioningNone)
+	}
+}
+
+// lintPolicy applies the governance rules of the selected pack
+func lintPolicy(spec *generator.OpenAPISpec, config Config) []Finding {
+	policy := config.policy()
+	config.validatePolicy(policy)
+
+	var findings []Finding
+	add := func(rule, location, message string) {
+		findings = append(findings, Finding{
+			Rule:     rule,
+			Severity: config.severity(rule),
+			Location: location,
+			Message:  message,
+		})
+	}
+
+	if pattern, ok := casePatterns[policy.PropertyCase]; ok {
+		names := make([]string, 0, len(spec.Components.Schemas))
+		for name := range spec.Components.Schemas {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			properties := make([]string, 0, len(spec.Components.Schemas[name].Properties))
+			for property := range spec.Components.Schemas[name].Properties {
+				properties = append(properties, property)
+			}
+			sort.Strings(properties)
+			for _, property := range properties {
+				if !pattern.MatchString(property) {
+					add(RulePropertyCase, "#/components/schemas/"+name, fmt.Sprintf("property %q is not %s (suggested: %s)",
+						property, policy.PropertyCase, caseName(paramWords(property), policy.PropertyCase)))
+				}
+			}
+		}
+	}
+
+	for _, entry := range operations(spec) {
+		if len(policy.PaginationParams) > 0 && entry.method == "GET" && returnsCollection(spec, entry) {
+			var missing []string
+			for _, name := range policy.PaginationParams {
+				if !hasQueryParam(entry.operation.Parameters, name) {
+					missing = append(missing, name)
+				}
+			}
+			if len(missing) > 0 {
+				add(RulePagination, entry.location(), fmt.Sprintf("collection is not paginated with %s", strings.Join(missing, " and ")))
+			}
+		}
+
+		if policy.ErrorMediaType != "" || len(policy.ErrorProperties) > 0 {
+			if codes := nonconformingErrors(spec, entry.operation, policy); len(codes) > 0 {
+				add(RuleErrorFormat, entry.location(), fmt.Sprintf("error responses %s don't follow the error format (%s)",
+					strings.Join(codes, ", "), errorFormat(policy)))
+			}
+		}
+	}
+
+	for _, path := range sortedPaths(spec) {
+		versioned := false
+		for _, segment := range strings.Split(strings.Trim(path, "/"), "/") {
+			if versionSegment.MatchString(segment) {
+				versioned = true
+				break
+			}
+		}
+		switch {
+		case policy.Versioning == VersioningPath && !versioned:
+			add(RuleVersioning, path, "path has no version segment, like /v1")
+		case policy.Versioning == VersioningNone && versioned:
+			add(RuleVersioning, path, "path has a version segment; version the API with media types instead")
+		}
+	}
+
+	return findings
+}
+
+// caseName joins words in a property case
+func caseName(words []string, propertyCase string) string {
+	if propertyCase == CaseSnake {
+		return strings.Join(words, "_")
+	}
+	for i := 1; i < len(words); i++ {
+		words[i] = strings.ToUpper(words[i][:1]) + words[i][1:]
+	}
+	return strings.Join(words, "")
+}
+
+// hasQueryParam reports whether an operation takes a query parameter,
+// matching names by their words so page_size matches pageSize
+func hasQueryParam(params []generator.Parameter, name string) bool {
+	want := strings.Join(paramWords(name), " ")
+	for _, param := range params {
+		if param.In == "query" && strings.Join(paramWords(param.Name), " ") == want {
+			return true
+		}
+	}
+	return false
+}
+
+// returnsCollection reports whether an operation responds with an array,
+// or an object with an array property like a page envelope
+func returnsCollection(spec *generator.OpenAPISpec, entry operationEntry) bool {
+	response, exists := entry.operation.Responses["200"]
+	if !exists {
+		return false
+	}
+	for _, media := range response.Content {
+		schema := resolve(spec, media.Schema)
+		if schema.Type == "array" {
+			return true
+		}
+		for _, property := range schema.Properties {
+			if resolve(spec, property).Type == "array" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// nonconformingErrors returns the status codes of an operation's 4xx and
+// 5xx responses that don't follow the policy's error format
+func nonconformingErrors(spec *generator.OpenAPISpec, operation *generator.Operation, policy Policy) []string {
+	var codes []string
+	for code, response := range operation.Responses {
+		if !strings.HasPrefix(code, "4") && !strings.HasPrefix(code, "5") {
+			continue
+		}
+		conforming := false
+		for mediaType, media := range response.Content {
+			if policy.ErrorMediaType != "" && mediaType != policy.ErrorMediaType {
+				continue
+			}
+			schema := resolve(spec, media.Schema)
+			conforming = true
+			for _, property := range policy.ErrorProperties {
+				if _, exists := schema.Properties[property]; !exists {
+					conforming = false
+				}
+			}
+			if conforming {
+				break
+			}
+		}
+		if !conforming {
+			codes = append(codes, code)
+		}
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+func errorFormat(policy Policy) string {
+	var parts []string
+	if policy.ErrorMediaType != "" {
+		parts = append(parts, policy.ErrorMediaType)
+	}
+	if len(policy.ErrorProperties) > 0 {
+		parts = append(parts, "with "+strings.Join(policy.ErrorProperties, ", "))
+	}
+	return strings.Join(parts, " ")
+}
+
+// resolve follows a schema reference to the component schema
+func resolve(spec *generator.OpenAPISpec, schema generator.Schema) generator.Schema {
+	if schema.Ref == "" {
+		return schema
+	}
+	return spec.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+}
+
+func sortedPaths(spec *generator.OpenAPISpec) []string {
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
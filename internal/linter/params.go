@@ -0,0 +1,383 @@
+package linter
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+)
+
+// RuleParamNaming flags the same logical parameter being named differently
+// across endpoints (tenant_id vs tenantId vs tid)
+const RuleParamNaming = "param-naming"
+
+// RuleParamConflict flags operations whose path, with its parameters renamed
+// by NormalizeParams, is the path of another operation of the same method
+const RuleParamConflict = "param-conflict"
+
+// paramVariant is one spelling of a parameter and where it is used
+type paramVariant struct {
+	name      string
+	locations []string
+}
+
+// lintParamNames reports every non-canonical spelling of a parameter
+func lintParamNames(spec *generator.OpenAPISpec, config Config) []Finding {
+	var findings []Finding
+
+	for _, group := range groupParams(spec, config) {
+		canonical := canonicalParamName(group, config)
+		for _, variant := range group {
+			if variant.name == canonical {
+				continue
+			}
+			message := fmt.Sprintf("parameter %q is also named %s; suggested canonical name: %s",
+				variant.name, otherNames(group, variant.name), canonical)
+			if _, explicit := config.ParamNames[variant.name]; len(group) == 1 && !explicit {
+				message = fmt.Sprintf("parameter %q is not %s; suggested canonical name: %s", variant.name, config.ParamCase, canonical)
+			}
+			findings = append(findings, Finding{
+				Rule:     RuleParamNaming,
+				Severity: config.severity(RuleParamNaming),
+				Location: strings.Join(variant.locations, ", "),
+				Message:  message,
+			})
+		}
+	}
+
+	return findings
+}
+
+// NormalizeParams renames path and query parameters to their canonical name,
+// rewriting path templates as needed. It returns the number of renamed
+// parameters, and a param-conflict finding for every operation whose
+// rewritten path another operation of the same method already has: that
+// operation keeps its original path and path parameter names.
+func NormalizeParams(spec *generator.OpenAPISpec, config Config) (int, []Finding) {
+	renames := make(map[string]string)
+	for _, group := range groupParams(spec, config) {
+		canonical := canonicalParamName(group, config)
+		for _, variant := range group {
+			if variant.name != canonical {
+				renames[variant.name] = canonical
+			}
+		}
+	}
+	if len(renames) == 0 {
+		return 0, nil
+	}
+
+	entries := operations(spec)
+	kept := make(map[int]bool) // operations keeping their path, by index
+	var normalized []normalizedOperation
+	var findings []Finding
+	for {
+		normalized = normalized[:0]
+		for i, entry := range entries {
+			normalized = append(normalized, normalizeOperation(entry, renames, !kept[i]))
+		}
+
+		// Operations staying on their path claim it before the renamed
+		// ones; a kept path can be another's rewritten one, so repeat
+		// until no operation moves back
+		conflict := false
+		owners := make(map[string]int)
+		for _, moved := range []bool{false, true} {
+			for i, operation := range normalized {
+				if (operation.path != entries[i].path) != moved {
+					continue
+				}
+				key := entries[i].method + " " + operation.path
+				owner, claimed := owners[key]
+				if !claimed {
+					owners[key] = i
+					continue
+				}
+				kept[i] = true
+				conflict = true
+				if severity := config.severity(RuleParamConflict); severity != SeverityOff {
+					findings = append(findings, Finding{
+						Rule:     RuleParamConflict,
+						Severity: severity,
+						Location: entries[i].location(),
+						Message: fmt.Sprintf("normalized path %s is also the path of %s; keeping %s",
+							operation.path, entries[owner].location(), entries[i].path),
+					})
+				}
+			}
+		}
+		if !conflict {
+			break
+		}
+	}
+
+	renamed := 0
+	paths := make(map[string]generator.PathItem, len(spec.Paths))
+	for i, operation := range normalized {
+		entry := entries[i]
+		entry.operation.Parameters = operation.params
+		renameLinkParams(entry.operation, renames)
+		renamed += operation.renamed
+
+		item := paths[operation.path]
+		setOperation(&item, entry.method, entry.operation)
+		paths[operation.path] = item
+	}
+	spec.Paths = paths
+
+	return renamed, findings
+}
+
+// normalizedOperation is an operation's path and parameters after renaming
+type normalizedOperation struct {
+	path    string
+	params  []generator.Parameter
+	renamed int
+}
+
+// normalizeOperation renames an operation's parameters without changing it,
+// leaving path parameters as they are unless renamePath is set
+func normalizeOperation(entry operationEntry, renames map[string]string, renamePath bool) normalizedOperation {
+	result := normalizedOperation{path: entry.path}
+	for _, param := range entry.operation.Parameters {
+		if canonical, ok := renames[param.Name]; ok && isNamedParam(param) && (renamePath || param.In != "path") {
+			if hasParam(entry.operation.Parameters, canonical, param.In) {
+				// Already present under the canonical name
+				result.renamed++
+				continue
+			}
+			if param.In == "path" {
+				result.path = strings.ReplaceAll(result.path, "{"+param.Name+"}", "{"+canonical+"}")
+			}
+			param.Name = canonical
+			result.renamed++
+		}
+		result.params = append(result.params, param)
+	}
+	return result
+}
+
+// renameLinkParams renames the parameters response links pass, which name
+// the parameters of the operations they lead to
+func renameLinkParams(operation *generator.Operation, renames map[string]string) {
+	for _, response := range operation.Responses {
+		for _, link := range response.Links {
+			for name, value := range link.Parameters {
+				if canonical, ok := renames[name]; ok {
+					delete(link.Parameters, name)
+					link.Parameters[canonical] = value
+				}
+			}
+		}
+	}
+}
+
+// groupParams groups the spec's path and query parameters by the logical
+// value they name. Only groups with more than one spelling, or with an
+// explicitly renamed spelling or one not in the configured case, are
+// returned.
+func groupParams(spec *generator.OpenAPISpec, config Config) [][]*paramVariant {
+	variants := make(map[string]*paramVariant)
+	var names []string
+	for _, entry := range operations(spec) {
+		for _, param := range entry.operation.Parameters {
+			if !isNamedParam(param) {
+				continue
+			}
+			variant, exists := variants[param.Name]
+			if !exists {
+				variant = &paramVariant{name: param.Name}
+				variants[param.Name] = variant
+				names = append(names, param.Name)
+			}
+			variant.locations = append(variant.locations, entry.location())
+		}
+	}
+	sort.Strings(names)
+
+	groups := make(map[string][]*paramVariant)
+	var keys []string
+	for _, name := range names {
+		key := paramGroupKey(name, names, config)
+		if _, exists := groups[key]; !exists {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], variants[name])
+	}
+	sort.Strings(keys)
+
+	var result [][]*paramVariant
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) > 1 {
+			result = append(result, group)
+			continue
+		}
+		if canonical := canonicalParamName(group, config); canonical != group[0].name {
+			result = append(result, group)
+		}
+	}
+	return result
+}
+
+// paramGroupKey returns the key identifying the logical parameter a name
+// refers to: its lowercased words, the key of its configured canonical name,
+// or for a one-word abbreviation like tid the key of the only name it
+// abbreviates (tenant_id)
+func paramGroupKey(name string, names []string, config Config) string {
+	if canonical, ok := config.ParamNames[name]; ok {
+		return strings.Join(paramWords(canonical), "")
+	}
+
+	words := paramWords(name)
+	key := strings.Join(words, "")
+	if len(words) != 1 {
+		return key
+	}
+
+	candidate := ""
+	for _, other := range names {
+		otherWords := paramWords(other)
+		if !isParamAbbreviation(key, otherWords) {
+			continue
+		}
+		otherKey := strings.Join(otherWords, "")
+		if candidate != "" && candidate != otherKey {
+			// Ambiguous abbreviation
+			return key
+		}
+		candidate = otherKey
+	}
+	if candidate != "" {
+		return candidate
+	}
+	return key
+}
+
+// canonicalParamName picks the configured canonical name for a group, or
+// else its most used spelling, preferring the longest on ties, in the
+// configured case
+func canonicalParamName(group []*paramVariant, config Config) string {
+	for _, variant := range group {
+		if canonical, ok := config.ParamNames[variant.name]; ok {
+			return canonical
+		}
+	}
+
+	best := group[0]
+	for _, variant := range group[1:] {
+		switch {
+		case len(variant.locations) > len(best.locations):
+			best = variant
+		case len(variant.locations) == len(best.locations) && len(variant.name) > len(best.name):
+			best = variant
+		}
+	}
+	if _, ok := casePatterns[config.ParamCase]; ok {
+		return caseName(paramWords(best.name), config.ParamCase)
+	}
+	return best.name
+}
+
+// otherNames describes the other spellings in a group
+func otherNames(group []*paramVariant, name string) string {
+	var others []string
+	for _, variant := range group {
+		if variant.name == name {
+			continue
+		}
+		others = append(others, fmt.Sprintf("%q (%d)", variant.name, len(variant.locations)))
+	}
+	if len(others) == 0 {
+		return "nowhere else"
+	}
+	return strings.Join(others, ", ")
+}
+
+// paramWords splits snake_case, kebab-case and camelCase names into
+// lowercase words: tenantID -> [tenant id]
+func paramWords(name string) []string {
+	var words []string
+	var current []rune
+	runes := []rune(name)
+
+	flush := func() {
+		if len(current) > 0 {
+			words = append(words, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+
+	for i, r := range runes {
+		switch {
+		case r == '_' || r == '-' || r == '.':
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	return words
+}
+
+// isParamAbbreviation reports whether short abbreviates a multi-word name as
+// the initials of all but the last word followed by the last word:
+// tid -> tenant_id, uid -> user_id
+func isParamAbbreviation(short string, words []string) bool {
+	if len(words) < 2 {
+		return false
+	}
+	var b strings.Builder
+	for _, word := range words[:len(words)-1] {
+		b.WriteString(word[:1])
+	}
+	b.WriteString(words[len(words)-1])
+	return b.String() == short
+}
+
+func isNamedParam(param generator.Parameter) bool {
+	return param.In == "path" || param.In == "query"
+}
+
+func hasParam(params []generator.Parameter, name, in string) bool {
+	for _, param := range params {
+		if param.Name == name && param.In == in {
+			return true
+		}
+	}
+	return false
+}
+
+func setOperation(item *generator.PathItem, method string, operation *generator.Operation) {
+	switch method {
+	case "GET":
+		if item.Get == nil {
+			item.Get = operation
+		}
+	case "POST":
+		if item.Post == nil {
+			item.Post = operation
+		}
+	case "PUT":
+		if item.Put == nil {
+			item.Put = operation
+		}
+	case "DELETE":
+		if item.Delete == nil {
+			item.Delete = operation
+		}
+	case "PATCH":
+		if item.Patch == nil {
+			item.Patch = operation
+		}
+	}
+}
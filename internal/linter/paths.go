@@ -0,0 +1,97 @@
+package linter
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+)
+
+// Path style rules
+const (
+	RulePathKebabCase     = "path-kebab-case"
+	RulePathPlural        = "path-plural-resources"
+	RulePathTrailingSlash = "path-no-trailing-slash"
+	RulePathNoVerbs       = "path-no-verbs"
+)
+
+var (
+	kebabSegment   = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*(\.[a-z0-9]+)?$`)
+	versionSegment = regexp.MustCompile(`^v[0-9]+(\.[0-9]+)*$`)
+)
+
+// pathVerbs are words that name an action rather than a resource
+var pathVerbs = map[string]bool{
+	"get": true, "fetch": true, "retrieve": true, "list": true, "create": true,
+	"add": true, "make": true, "update": true, "edit": true, "modify": true,
+	"set": true, "save": true, "delete": true, "remove": true, "destroy": true,
+}
+
+// uncountableResources are nouns that are fine without a plural "s"
+var uncountableResources = map[string]bool{
+	"data": true, "metadata": true, "media": true, "people": true, "children": true,
+	"info": true, "news": true, "series": true, "feedback": true, "equipment": true,
+}
+
+// lintPaths applies the path style rules to every path in the spec
+func lintPaths(spec *generator.OpenAPISpec, config Config) []Finding {
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var findings []Finding
+	add := func(rule, path, message string) {
+		findings = append(findings, Finding{
+			Rule:     rule,
+			Severity: config.severity(rule),
+			Location: path,
+			Message:  message,
+		})
+	}
+
+	for _, path := range paths {
+		if len(path) > 1 && strings.HasSuffix(path, "/") {
+			add(RulePathTrailingSlash, path, "path ends with a trailing slash")
+		}
+
+		segments := strings.Split(strings.Trim(path, "/"), "/")
+		for i, segment := range segments {
+			if segment == "" || isPathParam(segment) || versionSegment.MatchString(segment) {
+				continue
+			}
+
+			if !kebabSegment.MatchString(segment) {
+				add(RulePathKebabCase, path, fmt.Sprintf("segment %q is not kebab-case (suggested: %s)",
+					segment, strings.Join(paramWords(segment), "-")))
+			}
+
+			words := paramWords(segment)
+			if len(words) > 0 && pathVerbs[words[0]] {
+				add(RulePathNoVerbs, path, fmt.Sprintf("segment %q names an action; let the HTTP method express %q", segment, words[0]))
+			}
+
+			// A segment followed by a path parameter is a collection
+			if i+1 < len(segments) && isPathParam(segments[i+1]) && len(words) > 0 && !isPlural(words[len(words)-1]) {
+				add(RulePathPlural, path, fmt.Sprintf("collection segment %q should be plural", segment))
+			}
+		}
+	}
+
+	return findings
+}
+
+func isPathParam(segment string) bool {
+	return strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}")
+}
+
+// isPlural is a heuristic English plural check
+func isPlural(word string) bool {
+	if uncountableResources[word] {
+		return true
+	}
+	return strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss")
+}
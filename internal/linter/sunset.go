@@ -0,0 +1,32 @@
+package linter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+)
+
+// RuleSunsetPassed flags operations still documented after their @sunset
+// date
+const RuleSunsetPassed = "sunset-passed"
+
+// lintSunset reports the operations whose sunset date is before today
+func lintSunset(spec *generator.OpenAPISpec, config Config, now time.Time) []Finding {
+	today := now.UTC().Format(generator.SunsetLayout)
+
+	var findings []Finding
+	for _, entry := range operations(spec) {
+		sunset := entry.operation.Sunset
+		if sunset == "" || sunset >= today {
+			continue
+		}
+		findings = append(findings, Finding{
+			Rule:     RuleSunsetPassed,
+			Severity: config.severity(RuleSunsetPassed),
+			Location: entry.location(),
+			Message:  fmt.Sprintf("sunset date %s has passed; remove the operation or move its date", sunset),
+		})
+	}
+	return findings
+}
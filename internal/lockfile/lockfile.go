@@ -0,0 +1,185 @@
+package lockfile
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// DefaultName is the lock file written next to the generated spec
+const DefaultName = "openapigen.lock"
+
+// Lock records what a spec was generated from, so a build can tell whether
+// the committed spec is still what the generator would produce
+type Lock struct {
+	ToolVersion string            `json:"tool_version"`
+	ConfigHash  string            `json:"config_hash"`
+	ProjectPath string            `json:"project_path"`
+	SpecPath    string            `json:"spec_path"`
+	SpecHash    string            `json:"spec_hash"`
+	Heuristics  map[string]string `json:"heuristics,omitempty"` // inference choices, like the detected framework
+	Inputs      map[string]string `json:"inputs"`               // hashes of the input files by path relative to the project
+	Extra       map[string]string `json:"extra,omitempty"`      // hashes of inputs outside the project, like spec fragments
+}
+
+// skippedDirs are never inputs of the generator
+var skippedDirs = map[string]bool{
+	"vendor":       true,
+	"node_modules": true,
+	"testdata":     true,
+}
+
+// Build hashes the generator's inputs: the project's Go, proto and go.mod
+// files, the extra files given (fragments, a base spec) and the spec written
+// from them. config is hashed as JSON.
+func Build(toolVersion string, config interface{}, projectPath, specPath string, extra []string) (*Lock, error) {
+	configJSON, err := json.Marshal(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode config: %w", err)
+	}
+	configHash := sha256.Sum256(configJSON)
+
+	lock := &Lock{
+		ToolVersion: toolVersion,
+		ConfigHash:  hex.EncodeToString(configHash[:]),
+		ProjectPath: filepath.ToSlash(projectPath),
+		SpecPath:    filepath.ToSlash(specPath),
+		Inputs:      make(map[string]string),
+	}
+
+	err = filepath.Walk(projectPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != projectPath && (skippedDirs[info.Name()] || strings.HasPrefix(info.Name(), ".")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !isInput(info.Name()) {
+			return nil
+		}
+		hash, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(projectPath, path)
+		if err != nil {
+			return err
+		}
+		lock.Inputs[filepath.ToSlash(rel)] = hash
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash project files: %w", err)
+	}
+
+	for _, path := range extra {
+		hash, err := hashFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash %s: %w", path, err)
+		}
+		if lock.Extra == nil {
+			lock.Extra = make(map[string]string)
+		}
+		lock.Extra[filepath.ToSlash(path)] = hash
+	}
+
+	if lock.SpecHash, err = hashFile(specPath); err != nil {
+		return nil, fmt.Errorf("failed to hash spec: %w", err)
+	}
+	return lock, nil
+}
+
+// isInput reports whether a project file can change the generated spec
+func isInput(name string) bool {
+	if strings.HasSuffix(name, "_test.go") {
+		return false
+	}
+	return strings.HasSuffix(name, ".go") || strings.HasSuffix(name, ".proto") || name == "go.mod" || name == "CODEOWNERS"
+}
+
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// Read loads a lock file
+func Read(path string) (*Lock, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read lock file: %w", err)
+	}
+	var lock Lock
+	if err := json.Unmarshal(data, &lock); err != nil {
+		return nil, fmt.Errorf("failed to parse lock file %s: %w", path, err)
+	}
+	return &lock, nil
+}
+
+// Write saves the lock as indented JSON, which diffs well in review
+func (l *Lock) Write(path string) error {
+	data, err := json.MarshalIndent(l, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode lock file: %w", err)
+	}
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write lock file: %w", err)
+	}
+	return nil
+}
+
+// Compare lists why current differs from the locked state: a different
+// tool version or config, changed, added or removed inputs, or a spec that
+// no longer matches the one generated. Heuristics aren't compared, since
+// they follow from the inputs.
+func Compare(locked, current *Lock) []string {
+	var changes []string
+	if locked.ToolVersion != current.ToolVersion {
+		changes = append(changes, fmt.Sprintf("tool version changed: %s -> %s", locked.ToolVersion, current.ToolVersion))
+	}
+	if current.ConfigHash != "" && locked.ConfigHash != current.ConfigHash {
+		changes = append(changes, "config changed")
+	}
+	changes = append(changes, compareHashes(locked.Inputs, current.Inputs)...)
+	changes = append(changes, compareHashes(locked.Extra, current.Extra)...)
+	if locked.SpecHash != current.SpecHash {
+		changes = append(changes, fmt.Sprintf("%s differs from the generated spec", locked.SpecPath))
+	}
+	return changes
+}
+
+func compareHashes(locked, current map[string]string) []string {
+	var changes []string
+	for path, hash := range current {
+		lockedHash, exists := locked[path]
+		switch {
+		case !exists:
+			changes = append(changes, "added "+path)
+		case lockedHash != hash:
+			changes = append(changes, "changed "+path)
+		}
+	}
+	for path := range locked {
+		if _, exists := current[path]; !exists {
+			changes = append(changes, "removed "+path)
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
@@ -0,0 +1,197 @@
+package publisher
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// publishBucket uploads the spec to <path>/<version>/<file> and refreshes
+// <path>/latest/<file> so consumers can pin or follow the newest spec
+func (p *Publisher) publishBucket(data []byte, contentType, fileName, version string) error {
+	if p.config.Bucket == "" {
+		return fmt.Errorf("%s target requires bucket", p.config.Target)
+	}
+
+	for _, dir := range []string{version, "latest"} {
+		key := strings.TrimPrefix(path.Join(p.config.Path, dir, fileName), "/")
+		if err := p.uploadObject(p.config.Target, key, data, contentType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// publishBackstage uploads the spec into TechDocs external storage using the
+// <namespace>/api/<name> layout Backstage expects
+func (p *Publisher) publishBackstage(data []byte, contentType, fileName, version string) error {
+	if p.config.Bucket == "" || p.config.API == "" {
+		return fmt.Errorf("backstage target requires bucket and api")
+	}
+
+	namespace := p.config.Owner
+	if namespace == "" {
+		namespace = "default"
+	}
+	storage := p.config.Storage
+	if storage == "" {
+		storage = TargetGCS
+	}
+
+	prefix := path.Join(p.config.Path, namespace, "api", p.config.API)
+	for _, dir := range []string{version, "latest"} {
+		key := strings.TrimPrefix(path.Join(prefix, dir, fileName), "/")
+		if err := p.uploadObject(storage, key, data, contentType); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (p *Publisher) uploadObject(storage, key string, data []byte, contentType string) error {
+	switch storage {
+	case TargetS3:
+		return p.uploadS3(key, data, contentType)
+	case TargetGCS:
+		return p.uploadGCS(key, data, contentType)
+	default:
+		return fmt.Errorf("unsupported storage: %s (supported: s3, gcs)", storage)
+	}
+}
+
+// uploadGCS uploads an object using the GCS JSON API and an OAuth access token
+// (e.g. from `gcloud auth print-access-token`)
+func (p *Publisher) uploadGCS(key string, data []byte, contentType string) error {
+	token, err := credential(p.config.TokenEnv, "GCS_ACCESS_TOKEN")
+	if err != nil {
+		return err
+	}
+
+	baseURL := p.config.URL
+	if baseURL == "" {
+		baseURL = "https://storage.googleapis.com"
+	}
+	endpoint := fmt.Sprintf("%s/upload/storage/v1/b/%s/o?uploadType=media&name=%s",
+		strings.TrimSuffix(baseURL, "/"), url.PathEscape(p.config.Bucket), url.QueryEscape(key))
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", contentType)
+	return p.do(req)
+}
+
+// uploadS3 uploads an object with a SigV4-signed PUT request. A custom URL
+// switches to path-style addressing for S3-compatible stores like MinIO.
+func (p *Publisher) uploadS3(key string, data []byte, contentType string) error {
+	accessKey, err := credential(p.config.AccessKeyEnv, "AWS_ACCESS_KEY_ID")
+	if err != nil {
+		return err
+	}
+	secretKey, err := credential(p.config.SecretKeyEnv, "AWS_SECRET_ACCESS_KEY")
+	if err != nil {
+		return err
+	}
+
+	region := p.config.Region
+	if region == "" {
+		region = "us-east-1"
+	}
+
+	escapedKey := escapeS3Key(key)
+	endpoint := fmt.Sprintf("https://%s.s3.%s.amazonaws.com/%s", p.config.Bucket, region, escapedKey)
+	if p.config.URL != "" {
+		endpoint = fmt.Sprintf("%s/%s/%s", strings.TrimSuffix(p.config.URL, "/"), p.config.Bucket, escapedKey)
+	}
+
+	req, err := http.NewRequest(http.MethodPut, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	if sessionToken := os.Getenv("AWS_SESSION_TOKEN"); sessionToken != "" {
+		req.Header.Set("X-Amz-Security-Token", sessionToken)
+	}
+
+	signS3Request(req, data, accessKey, secretKey, region, time.Now().UTC())
+	return p.do(req)
+}
+
+func escapeS3Key(key string) string {
+	segments := strings.Split(key, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// signS3Request adds AWS Signature Version 4 headers to the request
+func signS3Request(req *http.Request, payload []byte, accessKey, secretKey, region string, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	payloadHash := sha256Hex(payload)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	signedHeaders := []string{"content-type", "host", "x-amz-content-sha256", "x-amz-date"}
+	if req.Header.Get("X-Amz-Security-Token") != "" {
+		signedHeaders = append(signedHeaders, "x-amz-security-token")
+	}
+
+	var canonicalHeaders strings.Builder
+	for _, name := range signedHeaders {
+		value := req.Header.Get(name)
+		if name == "host" {
+			value = req.URL.Host
+		}
+		canonicalHeaders.WriteString(name + ":" + strings.TrimSpace(value) + "\n")
+	}
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		strings.Join(signedHeaders, ";"),
+		payloadHash,
+	}, "\n")
+
+	scope := date + "/" + region + "/s3/aws4_request"
+	stringToSign := strings.Join([]string{
+		"AWS4-HMAC-SHA256",
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+secretKey), date)
+	signingKey = hmacSHA256(signingKey, region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		accessKey, scope, strings.Join(signedHeaders, ";"), signature))
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,35 @@
+package publisher
+
+import "net/http"
+
+// Supported publish targets
+const (
+	TargetSwaggerHub = "swaggerhub"
+	TargetApicurio   = "apicurio"
+	TargetBackstage  = "backstage"
+	TargetS3         = "s3"
+	TargetGCS        = "gcs"
+)
+
+type Publisher struct {
+	config Config
+	client *http.Client
+}
+
+// Config describes where and how a spec is published. Credentials are never
+// stored in the config file itself, only the names of the env vars holding them.
+type Config struct {
+	Target  string `json:"target"`
+	URL     string `json:"url"`     // registry base URL (SwaggerHub, Apicurio, S3 endpoint)
+	Owner   string `json:"owner"`   // SwaggerHub owner / Apicurio group / Backstage namespace
+	API     string `json:"api"`     // API or artifact name
+	Bucket  string `json:"bucket"`  // S3/GCS bucket (also Backstage techdocs storage)
+	Path    string `json:"path"`    // object prefix inside the bucket
+	Region  string `json:"region"`  // S3 region
+	Storage string `json:"storage"` // techdocs storage backend for Backstage (s3|gcs)
+	Private bool   `json:"private"` // SwaggerHub visibility
+
+	TokenEnv     string `json:"token_env"`      // API key / bearer token env var
+	AccessKeyEnv string `json:"access_key_env"` // S3 access key env var
+	SecretKeyEnv string `json:"secret_key_env"` // S3 secret key env var
+}
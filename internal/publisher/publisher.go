@@ -0,0 +1,97 @@
+package publisher
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+func New(config Config) *Publisher {
+	return &Publisher{
+		config: config,
+		client: &http.Client{Timeout: 60 * time.Second},
+	}
+}
+
+// Publish pushes the spec file to the configured target under the given
+// version. When version is empty, info.version from the spec is used.
+func (p *Publisher) Publish(specPath, version string) error {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	if version == "" {
+		if version, err = specVersion(data); err != nil {
+			return err
+		}
+	}
+
+	contentType := "application/yaml"
+	if strings.HasSuffix(specPath, ".json") {
+		contentType = "application/json"
+	}
+
+	switch p.config.Target {
+	case TargetSwaggerHub:
+		return p.publishSwaggerHub(data, contentType, version)
+	case TargetApicurio:
+		return p.publishApicurio(data, contentType, version)
+	case TargetBackstage:
+		return p.publishBackstage(data, contentType, path.Base(specPath), version)
+	case TargetS3, TargetGCS:
+		return p.publishBucket(data, contentType, path.Base(specPath), version)
+	default:
+		return fmt.Errorf("unsupported publish target: %s (supported: swaggerhub, apicurio, backstage, s3, gcs)", p.config.Target)
+	}
+}
+
+// specVersion reads info.version from a JSON or YAML spec
+func specVersion(data []byte) (string, error) {
+	var spec struct {
+		Info struct {
+			Version string `yaml:"version"`
+		} `yaml:"info"`
+	}
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return "", fmt.Errorf("failed to parse spec: %w", err)
+	}
+	if spec.Info.Version == "" {
+		return "", fmt.Errorf("spec has no info.version and no version was given")
+	}
+	return spec.Info.Version, nil
+}
+
+// credential resolves a secret from the named env var
+func credential(envName, fallback string) (string, error) {
+	if envName == "" {
+		envName = fallback
+	}
+	value := os.Getenv(envName)
+	if value == "" {
+		return "", fmt.Errorf("credential env var %s is not set", envName)
+	}
+	return value, nil
+}
+
+func (p *Publisher) do(req *http.Request) error {
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to publish to %s: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return fmt.Errorf("publish to %s failed: %s: %s", req.URL.Host, resp.Status, strings.TrimSpace(string(body)))
+	}
+
+	fmt.Printf("Published spec to %s\n", req.URL.Redacted())
+	return nil
+}
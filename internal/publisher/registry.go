@@ -0,0 +1,71 @@
+package publisher
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// publishSwaggerHub creates or updates an API version in SwaggerHub
+func (p *Publisher) publishSwaggerHub(data []byte, contentType, version string) error {
+	apiKey, err := credential(p.config.TokenEnv, "SWAGGERHUB_API_KEY")
+	if err != nil {
+		return err
+	}
+
+	baseURL := p.config.URL
+	if baseURL == "" {
+		baseURL = "https://api.swaggerhub.com"
+	}
+	if p.config.Owner == "" || p.config.API == "" {
+		return fmt.Errorf("swaggerhub target requires owner and api")
+	}
+
+	query := url.Values{}
+	query.Set("version", version)
+	query.Set("isPrivate", fmt.Sprintf("%t", p.config.Private))
+	query.Set("force", "true")
+	endpoint := fmt.Sprintf("%s/apis/%s/%s?%s", strings.TrimSuffix(baseURL, "/"),
+		url.PathEscape(p.config.Owner), url.PathEscape(p.config.API), query.Encode())
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", apiKey)
+	req.Header.Set("Content-Type", contentType)
+	return p.do(req)
+}
+
+// publishApicurio registers the spec as a new artifact version in an
+// Apicurio registry, creating the artifact on first publish
+func (p *Publisher) publishApicurio(data []byte, contentType, version string) error {
+	if p.config.URL == "" || p.config.API == "" {
+		return fmt.Errorf("apicurio target requires url and api")
+	}
+
+	group := p.config.Owner
+	if group == "" {
+		group = "default"
+	}
+
+	endpoint := fmt.Sprintf("%s/apis/registry/v2/groups/%s/artifacts?ifExists=UPDATE",
+		strings.TrimSuffix(p.config.URL, "/"), url.PathEscape(group))
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Registry-ArtifactId", p.config.API)
+	req.Header.Set("X-Registry-ArtifactType", "OPENAPI")
+	req.Header.Set("X-Registry-Version", version)
+
+	// Apicurio is often deployed without auth internally
+	if token, err := credential(p.config.TokenEnv, "APICURIO_TOKEN"); err == nil {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	return p.do(req)
+}
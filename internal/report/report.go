@@ -0,0 +1,135 @@
+package report
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+)
+
+// LargePayloadBytes is the estimated response size above which list
+// operations are flagged for pagination
+const LargePayloadBytes = 64 * 1024
+
+var paginationParams = map[string]bool{
+	"limit": true, "offset": true, "skip": true, "page": true, "page_size": true,
+	"per_page": true, "cursor": true, "after": true, "before": true, "take": true,
+}
+
+// Report summarizes how much of the API the analyzer could document
+type Report struct {
+	Operations      []Operation `json:"operations"`
+	Total           int         `json:"total"`
+	WithResponse    int         `json:"with_response_schema"`
+	WithRequestBody int         `json:"with_request_body"`
+	WriteOperations int         `json:"write_operations"`
+
+	DocScores []generator.DocScore `json:"doc_scores"` // documentation completeness by tag
+}
+
+// Operation is the per-operation coverage entry
+type Operation struct {
+	Method            string `json:"method"`
+	Path              string `json:"path"`
+	HasResponseSchema bool   `json:"has_response_schema"`
+	HasRequestBody    bool   `json:"has_request_body"`
+	ResponseBytes     int    `json:"estimated_response_bytes,omitempty"`
+	RequestBytes      int    `json:"estimated_request_bytes,omitempty"`
+	ReturnsList       bool   `json:"returns_list"`
+	Paginated         bool   `json:"paginated"`
+	Recommendation    string `json:"recommendation,omitempty"`
+}
+
+// Build computes the coverage report for a generated spec
+func Build(spec *generator.OpenAPISpec) *Report {
+	report := &Report{}
+	estimator := newSizeEstimator(spec.Components.Schemas)
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := spec.Paths[path]
+		for _, entry := range []struct {
+			method    string
+			operation *generator.Operation
+		}{
+			{"GET", item.Get}, {"POST", item.Post}, {"PUT", item.Put}, {"DELETE", item.Delete}, {"PATCH", item.Patch},
+		} {
+			if entry.operation == nil {
+				continue
+			}
+			op := buildOperation(entry.method, path, entry.operation, estimator)
+			report.Operations = append(report.Operations, op)
+
+			report.Total++
+			if op.HasResponseSchema {
+				report.WithResponse++
+			}
+			if entry.method == "POST" || entry.method == "PUT" || entry.method == "PATCH" {
+				report.WriteOperations++
+				if op.HasRequestBody {
+					report.WithRequestBody++
+				}
+			}
+		}
+	}
+
+	report.DocScores = generator.DocScores(spec)
+	return report
+}
+
+// successResponse returns the operation's 2xx response with the lowest
+// status code, like the 202 of an operation starting an asynchronous job
+func successResponse(operation *generator.Operation) (generator.Response, bool) {
+	var codes []string
+	for code := range operation.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return generator.Response{}, false
+	}
+	sort.Strings(codes)
+	return operation.Responses[codes[0]], true
+}
+
+func buildOperation(method, path string, operation *generator.Operation, estimator *sizeEstimator) Operation {
+	op := Operation{Method: method, Path: path}
+
+	if response, exists := successResponse(operation); exists {
+		for _, media := range response.Content {
+			op.HasResponseSchema = true
+			op.ResponseBytes = estimator.estimate(media.Schema)
+			op.ReturnsList = estimator.containsArray(media.Schema)
+			break
+		}
+	}
+	if operation.RequestBody != nil {
+		for _, media := range operation.RequestBody.Content {
+			op.HasRequestBody = true
+			op.RequestBytes = estimator.estimate(media.Schema)
+			break
+		}
+	}
+
+	for _, param := range operation.Parameters {
+		if param.In == "query" && paginationParams[strings.ToLower(param.Name)] {
+			op.Paginated = true
+		}
+	}
+
+	if method == "GET" && op.ReturnsList && !op.Paginated {
+		if op.ResponseBytes >= LargePayloadBytes {
+			op.Recommendation = "large list response: add limit/offset or cursor pagination"
+		} else {
+			op.Recommendation = "list response without pagination parameters: consider limit/offset or cursor pagination"
+		}
+	}
+
+	return op
+}
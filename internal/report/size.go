@@ -0,0 +1,133 @@
+package report
+
+import (
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+)
+
+const (
+	// assumedArrayItems is the number of elements assumed for arrays, since
+	// schemas carry no cardinality
+	assumedArrayItems = 50
+	// assumedMapEntries is the number of entries assumed for free-form maps
+	assumedMapEntries = 5
+	// maxDepth bounds recursion through nested and self-referencing schemas
+	maxDepth = 8
+)
+
+// sizeEstimator approximates the JSON-encoded size of schema instances
+type sizeEstimator struct {
+	schemas map[string]generator.Schema
+}
+
+func newSizeEstimator(schemas map[string]generator.Schema) *sizeEstimator {
+	return &sizeEstimator{schemas: schemas}
+}
+
+func (e *sizeEstimator) estimate(schema generator.Schema) int {
+	return e.estimateDepth(schema, 0)
+}
+
+func (e *sizeEstimator) estimateDepth(schema generator.Schema, depth int) int {
+	if depth > maxDepth {
+		return 0
+	}
+
+	if schema.Ref != "" {
+		if resolved, exists := e.resolve(schema.Ref); exists {
+			return e.estimateDepth(resolved, depth+1)
+		}
+		return 2
+	}
+
+	// Compositions: allOf adds up, oneOf/anyOf take the largest option
+	if len(schema.AllOf) > 0 {
+		total := 0
+		for _, part := range schema.AllOf {
+			total += e.estimateDepth(part, depth+1)
+		}
+		return total
+	}
+	if options := append(append([]generator.Schema{}, schema.OneOf...), schema.AnyOf...); len(options) > 0 {
+		largest := 0
+		for _, option := range options {
+			if size := e.estimateDepth(option, depth+1); size > largest {
+				largest = size
+			}
+		}
+		return largest
+	}
+
+	switch schema.Type {
+	case "string":
+		switch schema.Format {
+		case "date-time":
+			return 27
+		case "date":
+			return 12
+		case "uuid":
+			return 38
+		case "binary", "byte":
+			return 1024
+		}
+		return 24
+	case "integer":
+		return 8
+	case "number":
+		return 12
+	case "boolean":
+		return 5
+	case "array":
+		if schema.Items == nil {
+			return 2
+		}
+		return 2 + assumedArrayItems*(e.estimateDepth(*schema.Items, depth+1)+1)
+	}
+
+	// Objects
+	size := 2
+	for name, property := range schema.Properties {
+		size += len(name) + 4 + e.estimateDepth(property, depth+1)
+	}
+	switch additional := schema.AdditionalProperties.(type) {
+	case bool:
+		if additional {
+			size += assumedMapEntries * 32
+		}
+	case *generator.Schema:
+		size += assumedMapEntries * (16 + e.estimateDepth(*additional, depth+1))
+	}
+	return size
+}
+
+// containsArray reports whether the schema is, or directly wraps, a list
+func (e *sizeEstimator) containsArray(schema generator.Schema) bool {
+	if schema.Ref != "" {
+		resolved, exists := e.resolve(schema.Ref)
+		if !exists {
+			return false
+		}
+		schema = resolved
+	}
+
+	if schema.Type == "array" {
+		return true
+	}
+	for _, property := range schema.Properties {
+		if property.Ref != "" {
+			if resolved, exists := e.resolve(property.Ref); exists {
+				property = resolved
+			}
+		}
+		if property.Type == "array" {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *sizeEstimator) resolve(ref string) (generator.Schema, bool) {
+	schema, exists := e.schemas[strings.TrimPrefix(ref, "#/components/schemas/")]
+	return schema, exists
+}
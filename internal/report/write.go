@@ -0,0 +1,81 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// WriteFile writes the report as JSON when path ends in .json, otherwise as text
+func (r *Report) WriteFile(path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create report directory: %w", err)
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create report file: %w", err)
+	}
+	defer file.Close()
+
+	if strings.HasSuffix(path, ".json") {
+		encoder := json.NewEncoder(file)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r)
+	}
+	return r.WriteText(file)
+}
+
+// WriteText renders the report in a human-readable form
+func (r *Report) WriteText(w io.Writer) error {
+	fmt.Fprintf(w, "Coverage report\n")
+	fmt.Fprintf(w, "  Operations:           %d\n", r.Total)
+	fmt.Fprintf(w, "  With response schema: %d (%s)\n", r.WithResponse, percent(r.WithResponse, r.Total))
+	fmt.Fprintf(w, "  Write ops with body:  %d/%d (%s)\n", r.WithRequestBody, r.WriteOperations, percent(r.WithRequestBody, r.WriteOperations))
+	fmt.Fprintln(w)
+
+	for _, op := range r.Operations {
+		fmt.Fprintf(w, "%-7s %s\n", op.Method, op.Path)
+		if op.HasResponseSchema {
+			fmt.Fprintf(w, "        response: ~%s\n", formatBytes(op.ResponseBytes))
+		} else {
+			fmt.Fprintf(w, "        response: no schema\n")
+		}
+		if op.HasRequestBody {
+			fmt.Fprintf(w, "        request:  ~%s\n", formatBytes(op.RequestBytes))
+		}
+		if op.Recommendation != "" {
+			fmt.Fprintf(w, "        note:     %s\n", op.Recommendation)
+		}
+	}
+
+	if len(r.DocScores) > 0 {
+		fmt.Fprintln(w)
+		fmt.Fprintf(w, "Documentation score by tag\n")
+		for _, score := range r.DocScores {
+			tag := score.Tag
+			if tag == "" {
+				tag = "(untagged)"
+			}
+			fmt.Fprintf(w, "  %-20s %3d  described operations %s, described fields %s, fields with examples %s\n", tag, score.Score,
+				percent(score.DescribedOps, score.Operations), percent(score.DescribedFields, score.Fields), percent(score.FieldsWithExample, score.Fields))
+		}
+	}
+	return nil
+}
+
+func percent(part, total int) string {
+	if total == 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.0f%%", float64(part)*100/float64(total))
+}
+
+func formatBytes(size int) string {
+	if size >= 1024 {
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	}
+	return fmt.Sprintf("%d B", size)
+}
@@ -0,0 +1,206 @@
+package roundtrip
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+)
+
+// Difference is one mismatch between the original and the generated spec
+type Difference struct {
+	Location string // slash-separated path into the spec, e.g. paths/~1users/get/parameters/page
+	Message  string
+}
+
+func (d Difference) String() string {
+	return fmt.Sprintf("%s: %s", d.Location, d.Message)
+}
+
+// ignoredKeys are free-text fields the round trip is not expected to preserve
+var ignoredKeys = map[string]bool{
+	"description": true,
+	"summary":     true,
+}
+
+// Compare diffs the paths and component schemas of two specs, ignoring
+// descriptions and summaries. Parameters are matched by name and location,
+// and string lists like required and enum are compared as sets.
+func Compare(original, generated *generator.OpenAPISpec) ([]Difference, error) {
+	want, err := normalize(original)
+	if err != nil {
+		return nil, err
+	}
+	got, err := normalize(generated)
+	if err != nil {
+		return nil, err
+	}
+
+	var differences []Difference
+	compareValues("paths", want["paths"], got["paths"], &differences)
+	compareValues("components/schemas", schemas(want), schemas(got), &differences)
+	return differences, nil
+}
+
+// normalize encodes a spec as a generic JSON document
+func normalize(spec *generator.OpenAPISpec) (map[string]interface{}, error) {
+	data, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode spec: %w", err)
+	}
+	var doc map[string]interface{}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to decode spec: %w", err)
+	}
+	return doc, nil
+}
+
+func schemas(doc map[string]interface{}) interface{} {
+	if components, ok := doc["components"].(map[string]interface{}); ok {
+		return components["schemas"]
+	}
+	return nil
+}
+
+func compareValues(location string, want, got interface{}, differences *[]Difference) {
+	add := func(format string, args ...interface{}) {
+		*differences = append(*differences, Difference{Location: location, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case want == nil && got == nil:
+		return
+	case got == nil:
+		add("missing from generated spec")
+		return
+	case want == nil:
+		add("not in original spec")
+		return
+	}
+
+	switch w := want.(type) {
+	case map[string]interface{}:
+		g, ok := got.(map[string]interface{})
+		if !ok {
+			add("expected an object, got %s", describe(got))
+			return
+		}
+		for _, key := range unionKeys(w, g) {
+			if ignoredKeys[key] {
+				continue
+			}
+			compareValues(location+"/"+escape(key), w[key], g[key], differences)
+		}
+	case []interface{}:
+		g, ok := got.([]interface{})
+		if !ok {
+			add("expected a list, got %s", describe(got))
+			return
+		}
+		compareLists(location, w, g, differences)
+	default:
+		if !reflect.DeepEqual(want, got) {
+			add("expected %s, got %s", describe(want), describe(got))
+		}
+	}
+}
+
+// compareLists matches named elements (parameters) by name and location,
+// compares string lists as sets and anything else by position
+func compareLists(location string, want, got []interface{}, differences *[]Difference) {
+	if wantKeys, ok := elementKeys(want); ok {
+		if gotKeys, ok := elementKeys(got); ok {
+			compareValues(location, wantKeys, gotKeys, differences)
+			return
+		}
+	}
+
+	if wantSet, ok := stringSet(want); ok {
+		if gotSet, ok := stringSet(got); ok {
+			compareValues(location, wantSet, gotSet, differences)
+			return
+		}
+	}
+
+	if len(want) != len(got) {
+		*differences = append(*differences, Difference{
+			Location: location,
+			Message:  fmt.Sprintf("expected %d items, got %d", len(want), len(got)),
+		})
+		return
+	}
+	for i := range want {
+		compareValues(fmt.Sprintf("%s/%d", location, i), want[i], got[i], differences)
+	}
+}
+
+// elementKeys keys a list of objects that all have a name, qualified by
+// their location (in) when present
+func elementKeys(list []interface{}) (map[string]interface{}, bool) {
+	if len(list) == 0 {
+		return nil, false
+	}
+	keyed := make(map[string]interface{}, len(list))
+	for _, element := range list {
+		object, ok := element.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		name, ok := object["name"].(string)
+		if !ok {
+			return nil, false
+		}
+		if in, ok := object["in"].(string); ok {
+			name = in + ":" + name
+		}
+		keyed[name] = element
+	}
+	return keyed, true
+}
+
+// stringSet turns a list of strings into a set, so ordering is ignored
+func stringSet(list []interface{}) (map[string]interface{}, bool) {
+	set := make(map[string]interface{}, len(list))
+	for _, element := range list {
+		s, ok := element.(string)
+		if !ok {
+			return nil, false
+		}
+		set[s] = true
+	}
+	return set, true
+}
+
+func unionKeys(a, b map[string]interface{}) []string {
+	seen := make(map[string]bool)
+	var keys []string
+	for _, m := range []map[string]interface{}{a, b} {
+		for key := range m {
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// escape encodes a key as a JSON pointer token
+func escape(key string) string {
+	return strings.ReplaceAll(strings.ReplaceAll(key, "~", "~0"), "/", "~1")
+}
+
+func describe(value interface{}) string {
+	switch value.(type) {
+	case map[string]interface{}:
+		return "an object"
+	case []interface{}:
+		return "a list"
+	}
+	data, _ := json.Marshal(value)
+	return string(data)
+}
@@ -0,0 +1,87 @@
+package roundtrip
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/scaffold"
+)
+
+// Options configures a round trip
+type Options struct {
+	Module string // Go module path for the scaffolded project's imports
+	Dir    string // directory to scaffold into; a temporary directory when empty
+	Keep   bool   // keep the scaffolded project instead of removing it
+}
+
+// Result is the outcome of a round trip
+type Result struct {
+	Dir         string // where the project was scaffolded (removed unless Keep is set)
+	Generated   *generator.OpenAPISpec
+	Differences []Difference
+}
+
+// Run scaffolds a Fiber project from spec, analyzes the scaffolded code and
+// generates a spec from it, then compares that spec with the original. Any
+// difference other than a description or summary points at an asymmetry
+// between the scaffolder and the analyzer/generator.
+func Run(spec *generator.OpenAPISpec, opts Options) (*Result, error) {
+	files, err := scaffold.Generate(spec, opts.Module)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scaffold: %w", err)
+	}
+
+	dir := opts.Dir
+	if dir == "" {
+		dir, err = os.MkdirTemp("", "roundtrip-")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+		}
+	}
+	if !opts.Keep {
+		defer os.RemoveAll(dir)
+	}
+
+	if err := scaffold.Write(dir, files, true); err != nil {
+		return nil, fmt.Errorf("failed to write scaffold: %w", err)
+	}
+
+	analysis, err := analyzer.New(analyzer.Config{
+		ProjectPath:   dir,
+		SDKPackage:    "sdk",
+		RoutesPattern: "routes/**/router.go",
+		Framework:     analyzer.FrameworkFiber,
+	}).Analyze(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze scaffolded project: %w", err)
+	}
+
+	generated, err := generator.New(generatorConfig(spec)).Generate(context.Background(), analysis)
+	if err != nil {
+		return nil, err
+	}
+
+	differences, err := Compare(spec, generated)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Result{Dir: dir, Generated: generated, Differences: differences}, nil
+}
+
+// generatorConfig carries the original spec's info and server over so they
+// don't show up as differences
+func generatorConfig(spec *generator.OpenAPISpec) generator.Config {
+	config := generator.Config{
+		Title:       spec.Info.Title,
+		Version:     spec.Info.Version,
+		Description: spec.Info.Description,
+	}
+	if len(spec.Servers) > 0 {
+		config.ServerURL = spec.Servers[0].URL
+	}
+	return config
+}
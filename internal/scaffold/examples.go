@@ -0,0 +1,141 @@
+package scaffold
+
+import (
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+)
+
+// formatExamples are the example values of string formats
+var formatExamples = map[string]string{
+	"date-time": "2024-01-15T09:30:00Z",
+	"date":      "2024-01-15",
+	"time":      "09:30:00",
+	"email":     "user@example.com",
+	"uuid":      "3fa85f64-5717-4562-b3fc-2c963f66afa6",
+	"uri":       "https://example.com",
+	"url":       "https://example.com",
+	"hostname":  "example.com",
+	"ipv4":      "192.0.2.1",
+	"ipv6":      "2001:db8::1",
+	"duration":  "1h30m",
+	"byte":      "ZXhhbXBsZQ==",
+	"password":  "s3cr3t-passw0rd",
+}
+
+// example builds a value valid against a schema: its example, default or
+// first enum value when it has one, or a value of its type within its
+// bounds. Objects get every property; arrays get as many items as they
+// need, at least one. A schema referring to itself, like a tree of
+// categories, stops at the first repetition with an empty array or no
+// property. visiting holds the schemas being built.
+func (s *scaffolder) example(schema generator.Schema, visiting map[string]bool) interface{} {
+	if schema.Ref != "" {
+		name := goTypeName(refName(schema.Ref))
+		target, exists := s.schemas[name]
+		if !exists || visiting[name] {
+			return nil
+		}
+		visiting[name] = true
+		defer delete(visiting, name)
+		return s.example(target, visiting)
+	}
+	switch {
+	case schema.Example != nil:
+		return schema.Example
+	case schema.Default != nil:
+		return schema.Default
+	case len(schema.Enum) > 0:
+		return schema.Enum[0]
+	case len(schema.AllOf) > 0:
+		merged := make(map[string]interface{})
+		for _, part := range schema.AllOf {
+			if object, ok := s.example(part, visiting).(map[string]interface{}); ok {
+				for key, value := range object {
+					merged[key] = value
+				}
+			}
+		}
+		return merged
+	case len(schema.OneOf) > 0:
+		return s.example(schema.OneOf[0], visiting)
+	case len(schema.AnyOf) > 0:
+		return s.example(schema.AnyOf[0], visiting)
+	}
+
+	switch schema.Type {
+	case "string":
+		return stringExample(schema)
+	case "integer":
+		return integerExample(schema)
+	case "number":
+		example := float64(integerExample(schema))
+		if schema.Maximum == nil {
+			example += 0.5
+		}
+		return example
+	case "boolean":
+		return true
+	case "array":
+		if schema.Items == nil {
+			return []interface{}{}
+		}
+		count := int64(1)
+		if schema.MinItems != nil && *schema.MinItems > count {
+			count = *schema.MinItems
+		}
+		items := make([]interface{}, 0, count)
+		for i := int64(0); i < count; i++ {
+			if item := s.example(*schema.Items, visiting); item != nil {
+				items = append(items, item)
+			}
+		}
+		return items
+	}
+
+	object := make(map[string]interface{})
+	for name, property := range schema.Properties {
+		if value := s.example(property, visiting); value != nil {
+			object[name] = value
+		}
+	}
+	if valueSchema, ok := additionalPropertiesSchema(schema.AdditionalProperties); ok && len(schema.Properties) == 0 {
+		if value := s.example(*valueSchema, visiting); value != nil {
+			object["key"] = value
+		}
+	}
+	return object
+}
+
+// stringExample is a string of the schema's format, or of its length bounds
+func stringExample(schema generator.Schema) string {
+	if example, ok := formatExamples[schema.Format]; ok {
+		return example
+	}
+	example := "string"
+	if schema.MinLength != nil && int64(len(example)) < *schema.MinLength {
+		example += strings.Repeat("x", int(*schema.MinLength)-len(example))
+	}
+	if schema.MaxLength != nil && int64(len(example)) > *schema.MaxLength {
+		example = example[:*schema.MaxLength]
+	}
+	return example
+}
+
+// integerExample is 1, moved within the schema's bounds
+func integerExample(schema generator.Schema) int64 {
+	example := int64(1)
+	if schema.Minimum != nil {
+		example = *schema.Minimum
+		if schema.ExclusiveMinimum {
+			example++
+		}
+	}
+	if schema.Maximum != nil && example > *schema.Maximum {
+		example = *schema.Maximum
+		if schema.ExclusiveMaximum {
+			example--
+		}
+	}
+	return example
+}
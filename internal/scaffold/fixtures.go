@@ -0,0 +1,182 @@
+package scaffold
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+)
+
+// FixturesFile is the file Fixtures renders, relative to the output directory
+const FixturesFile = "fixtures.go"
+
+// fixture is the example request body of an operation
+type fixture struct {
+	name      string // CreateUser, from the handler name like scaffolded handlers
+	method    string
+	path      string
+	goType    string
+	operation *generator.Operation
+}
+
+// Fixtures renders a Go file of package pkg with an example request body
+// for every operation taking JSON, for handler unit tests: the raw JSON as
+// a constant, CreateUserPayloadJSON, and a function decoding it into the
+// typed struct, CreateUserPayload(). The structs are rendered from the
+// spec's schemas like scaffolded sdk models, so the file stands alone.
+func Fixtures(spec *generator.OpenAPISpec, pkg string) (Files, error) {
+	s := &scaffolder{
+		schemas: make(map[string]generator.Schema),
+		files:   make(Files),
+	}
+	for name, schema := range spec.Components.Schemas {
+		s.schemas[goTypeName(name)] = schema
+	}
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var fixtures []fixture
+	for _, path := range paths {
+		item := spec.Paths[path]
+		for _, entry := range []struct {
+			method    string
+			operation *generator.Operation
+		}{
+			{"GET", item.Get}, {"POST", item.Post}, {"PUT", item.Put}, {"DELETE", item.Delete}, {"PATCH", item.Patch},
+		} {
+			if entry.operation == nil || entry.operation.RequestBody == nil {
+				continue
+			}
+			media, ok := entry.operation.RequestBody.Content["application/json"]
+			if !ok {
+				continue
+			}
+			name := handlerName(entry.method, path, entry.operation)
+			fixtures = append(fixtures, fixture{
+				name:      name,
+				method:    entry.method,
+				path:      path,
+				goType:    s.goType(media.Schema, name+"Request", ""),
+				operation: entry.operation,
+			})
+		}
+	}
+	if len(fixtures) == 0 {
+		return nil, fmt.Errorf("no operation takes a JSON request body")
+	}
+
+	// Only the types of the payloads, and the types they use, are rendered
+	used := make(map[string]bool)
+	for _, f := range fixtures {
+		s.collectTypes(f.operation.RequestBody.Content["application/json"].Schema, used)
+		s.collectTypes(s.schemas[strings.TrimLeft(f.goType, "[]")], used)
+		used[strings.TrimLeft(f.goType, "[]")] = true
+	}
+	for name := range s.schemas {
+		if !used[name] {
+			delete(s.schemas, name)
+		}
+	}
+
+	// Payload names must not clash with each other or with the types
+	names := &routePackage{handlers: make(map[string]bool)}
+	for name := range s.schemas {
+		names.handlers[name] = true
+	}
+
+	var payloads strings.Builder
+	for _, f := range fixtures {
+		media := f.operation.RequestBody.Content["application/json"]
+		data, err := json.MarshalIndent(s.example(media.Schema, make(map[string]bool)), "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode the example body of %s %s: %w", f.method, f.path, err)
+		}
+		name := names.uniqueHandler(f.name + "Payload")
+		fmt.Fprintf(&payloads, "// %sJSON is an example request body of %s %s\n", name, f.method, f.path)
+		fmt.Fprintf(&payloads, "const %sJSON = %s\n\n", name, rawString(string(data)))
+		fmt.Fprintf(&payloads, "// %s returns %sJSON decoded\n", name, name)
+		fmt.Fprintf(&payloads, "func %s() %s {\n", name, f.goType)
+		fmt.Fprintf(&payloads, "\tvar payload %s\n", f.goType)
+		fmt.Fprintf(&payloads, "\tmustDecode(%sJSON, &payload)\n", name)
+		payloads.WriteString("\treturn payload\n}\n\n")
+	}
+
+	types, usesTime := s.typesSource()
+
+	var file strings.Builder
+	file.WriteString("// Code generated by go-openapispec-generator gen-fixtures. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&file, "// Package %s holds example request bodies for handler tests\n", pkg)
+	fmt.Fprintf(&file, "package %s\n\n", pkg)
+	file.WriteString("import (\n\t\"encoding/json\"\n\t\"fmt\"\n")
+	if usesTime {
+		file.WriteString("\t\"time\"\n")
+	}
+	file.WriteString(")\n\n")
+	file.WriteString(payloads.String())
+	file.WriteString(mustDecodeSource)
+	file.WriteString(types)
+
+	if err := s.addGoFile(FixturesFile, file.String()); err != nil {
+		return nil, err
+	}
+	return s.files, nil
+}
+
+// collectTypes records the schemas a schema refers to, directly or through
+// its properties, items and compositions
+func (s *scaffolder) collectTypes(schema generator.Schema, used map[string]bool) {
+	if schema.Ref != "" {
+		name := goTypeName(refName(schema.Ref))
+		if used[name] {
+			return
+		}
+		used[name] = true
+		s.collectTypes(s.schemas[name], used)
+		return
+	}
+	for _, property := range schema.Properties {
+		s.collectTypes(property, used)
+	}
+	if schema.Items != nil {
+		s.collectTypes(*schema.Items, used)
+	}
+	if valueSchema, ok := additionalPropertiesSchema(schema.AdditionalProperties); ok {
+		s.collectTypes(*valueSchema, used)
+	}
+	for _, parts := range [][]generator.Schema{schema.AllOf, schema.OneOf, schema.AnyOf} {
+		for _, part := range parts {
+			s.collectTypes(part, used)
+		}
+	}
+}
+
+// PackageName returns the Go package name for a directory's base name
+func PackageName(dir string) string {
+	return packageName(dir)
+}
+
+// rawString quotes a string as a raw string literal, or an interpreted one
+// when it holds a backquote
+func rawString(s string) string {
+	if strings.Contains(s, "`") {
+		return strconv.Quote(s)
+	}
+	return "`" + s + "`"
+}
+
+const mustDecodeSource = `// mustDecode decodes an example body, which only fails when the fixtures
+// are out of date with their types
+func mustDecode(data string, v interface{}) {
+	if err := json.Unmarshal([]byte(data), v); err != nil {
+		panic(fmt.Sprintf("fixtures: %v", err))
+	}
+}
+
+`
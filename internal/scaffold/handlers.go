@@ -0,0 +1,317 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/token"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+)
+
+var (
+	pathParamPattern   = regexp.MustCompile(`\{([^}]+)\}`)
+	handlerDescPattern = regexp.MustCompile(`^(\w+) handler for `)
+)
+
+// statusConstants names the fiber constants for common success codes
+var statusConstants = map[string]string{
+	"200": "fiber.StatusOK",
+	"201": "fiber.StatusCreated",
+	"202": "fiber.StatusAccepted",
+	"204": "fiber.StatusNoContent",
+}
+
+// routePackage is a routes/<name> package: every path sharing a first segment
+type routePackage struct {
+	name     string
+	routes   []scaffoldRoute
+	handlers map[string]bool
+}
+
+type scaffoldRoute struct {
+	method    string // GET
+	specPath  string // /users/v1/users/{id}
+	fiberPath string // /v1/users/:id, relative to the package
+	handler   string
+	operation *generator.Operation
+}
+
+// buildPackages groups the spec's operations by the first path segment,
+// which the analyzer turns back into the path prefix
+func (s *scaffolder) buildPackages(spec *generator.OpenAPISpec) map[string]*routePackage {
+	packages := make(map[string]*routePackage)
+
+	paths := make([]string, 0, len(spec.Paths))
+	for path := range spec.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := spec.Paths[path]
+		segments := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 2)
+		name := packageName(segments[0])
+		if name != segments[0] {
+			fmt.Printf("Warning: %s is scaffolded in package %s; regenerated paths will start with /%s\n", path, name, name)
+		}
+		rest := ""
+		if len(segments) == 2 {
+			rest = "/" + segments[1]
+		}
+
+		pkg, exists := packages[name]
+		if !exists {
+			pkg = &routePackage{name: name, handlers: make(map[string]bool)}
+			packages[name] = pkg
+		}
+
+		for _, entry := range []struct {
+			method    string
+			operation *generator.Operation
+		}{
+			{"GET", item.Get}, {"POST", item.Post}, {"PUT", item.Put}, {"DELETE", item.Delete}, {"PATCH", item.Patch},
+		} {
+			if entry.operation == nil {
+				continue
+			}
+			pkg.routes = append(pkg.routes, scaffoldRoute{
+				method:    entry.method,
+				specPath:  path,
+				fiberPath: pathParamPattern.ReplaceAllString(rest, ":$1"),
+				handler:   pkg.uniqueHandler(handlerName(entry.method, path, entry.operation)),
+				operation: entry.operation,
+			})
+		}
+	}
+
+	return packages
+}
+
+func (p *routePackage) uniqueHandler(name string) string {
+	candidate := name
+	for i := 2; p.handlers[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	p.handlers[candidate] = true
+	return candidate
+}
+
+// handlerName recovers the handler name from descriptions written by the
+// generator ("GetUser handler for get /users/:id"), then falls back to the
+// operationId or the method and path
+func handlerName(method, path string, operation *generator.Operation) string {
+	if match := handlerDescPattern.FindStringSubmatch(operation.Description); match != nil {
+		if token.IsExported(match[1]) {
+			return match[1]
+		}
+	}
+	if operation.OperationID != "" {
+		return goFieldName(operation.OperationID)
+	}
+	return goFieldName(strings.ToLower(method) + " " + path)
+}
+
+// writePackage renders router.go and handlers.go for a route package
+func (s *scaffolder) writePackage(pkg *routePackage) error {
+	var handlers strings.Builder
+	usesSDK := false
+	usesAuth := false
+	for _, route := range pkg.routes {
+		source, sdkUsed := s.handlerSource(route)
+		handlers.WriteString(source)
+		usesSDK = usesSDK || sdkUsed
+		usesAuth = usesAuth || len(route.operation.Security) > 0
+	}
+
+	var router strings.Builder
+	fmt.Fprintf(&router, "package %s\n\n", pkg.name)
+	router.WriteString("import (\n\t\"github.com/gofiber/fiber/v2\"\n")
+	if usesAuth {
+		fmt.Fprintf(&router, "\t%q\n", s.module+"/middleware")
+		s.needsAuth = true
+	}
+	router.WriteString(")\n\n")
+	fmt.Fprintf(&router, "// RegisterRoutes registers the %s routes\n", pkg.name)
+	router.WriteString("func RegisterRoutes(router fiber.Router) {\n")
+	for _, route := range pkg.routes {
+		method := route.method[:1] + strings.ToLower(route.method[1:])
+		if len(route.operation.Security) > 0 {
+			fmt.Fprintf(&router, "\trouter.%s(%q, middleware.Auth(), %s)\n", method, route.fiberPath, route.handler)
+		} else {
+			fmt.Fprintf(&router, "\trouter.%s(%q, %s)\n", method, route.fiberPath, route.handler)
+		}
+	}
+	router.WriteString("}\n")
+
+	var file strings.Builder
+	fmt.Fprintf(&file, "package %s\n\n", pkg.name)
+	file.WriteString("import (\n\t\"github.com/gofiber/fiber/v2\"\n")
+	if usesSDK {
+		fmt.Fprintf(&file, "\t%q\n", s.module+"/sdk")
+	}
+	file.WriteString(")\n\n")
+	file.WriteString(handlers.String())
+
+	if err := s.addGoFile("routes/"+pkg.name+"/router.go", router.String()); err != nil {
+		return err
+	}
+	return s.addGoFile("routes/"+pkg.name+"/handlers.go", file.String())
+}
+
+// handlerSource renders a handler stub that reads the operation's parameters
+// and body and responds with its success schema. It reports whether the
+// stub references the sdk package.
+func (s *scaffolder) handlerSource(route scaffoldRoute) (string, bool) {
+	var b strings.Builder
+	usesSDK := false
+	operation := route.operation
+
+	fmt.Fprintf(&b, "// %s handles %s %s\n", route.handler, route.method, route.specPath)
+	if operation.Summary != "" {
+		fmt.Fprintf(&b, "// %s\n", operation.Summary)
+	}
+	if operation.Stability != "" {
+		fmt.Fprintf(&b, "//\n// @stability %s\n", operation.Stability)
+	}
+	fmt.Fprintf(&b, "func %s(c *fiber.Ctx) error {\n", route.handler)
+
+	declared := make(map[string]bool)
+	for _, param := range operation.Parameters {
+		varName := goVarName(param.Name)
+		if declared[varName] {
+			continue
+		}
+		declared[varName] = true
+		switch param.In {
+		case "path":
+			fmt.Fprintf(&b, "\t%s := c.Params(%q)\n", varName, param.Name)
+		case "query":
+			fmt.Fprintf(&b, "\t%s := c.%s(%q)\n", varName, queryMethod(param.Schema.Type), param.Name)
+		case "header":
+			fmt.Fprintf(&b, "\t%s := c.Get(%q)\n", varName, param.Name)
+		case "cookie":
+			fmt.Fprintf(&b, "\t%s := c.Cookies(%q)\n", varName, param.Name)
+		default:
+			continue
+		}
+		fmt.Fprintf(&b, "\t_ = %s\n", varName)
+	}
+
+	if operation.RequestBody != nil {
+		if media, ok := operation.RequestBody.Content["application/json"]; ok {
+			goType := s.qualify(s.goType(media.Schema, route.handler+"Request", ""))
+			usesSDK = usesSDK || strings.Contains(goType, "sdk.")
+			if len(declared) > 0 {
+				b.WriteString("\n")
+			}
+			fmt.Fprintf(&b, "\tvar req %s\n", goType)
+			b.WriteString("\tif err := c.BodyParser(&req); err != nil {\n")
+			b.WriteString("\t\treturn fiber.NewError(fiber.StatusBadRequest, err.Error())\n")
+			b.WriteString("\t}\n")
+		}
+	}
+
+	if len(declared) > 0 || operation.RequestBody != nil {
+		b.WriteString("\n")
+	}
+	b.WriteString("\t// TODO: implement\n")
+
+	code, response := successResponse(operation)
+	status := statusConstants[code]
+	if status == "" {
+		status = code
+	}
+	switch {
+	case response == nil:
+		fmt.Fprintf(&b, "\treturn c.SendStatus(%s)\n", status)
+	default:
+		goType := s.qualify(s.goType(*response, route.handler+"Response", ""))
+		usesSDK = usesSDK || strings.Contains(goType, "sdk.")
+		value := goType + "{}"
+		if !s.isCompositeType(goType) {
+			fmt.Fprintf(&b, "\tvar resp %s\n", goType)
+			value = "resp"
+		}
+		if code == "200" {
+			fmt.Fprintf(&b, "\treturn c.JSON(%s)\n", value)
+		} else {
+			fmt.Fprintf(&b, "\treturn c.Status(%s).JSON(%s)\n", status, value)
+		}
+	}
+	b.WriteString("}\n\n")
+
+	return b.String(), usesSDK
+}
+
+// successResponse returns the lowest 2xx status code and its JSON schema
+func successResponse(operation *generator.Operation) (string, *generator.Schema) {
+	var codes []string
+	for code := range operation.Responses {
+		if strings.HasPrefix(code, "2") {
+			codes = append(codes, code)
+		}
+	}
+	if len(codes) == 0 {
+		return "200", nil
+	}
+	sort.Strings(codes)
+
+	response := operation.Responses[codes[0]]
+	if media, ok := response.Content["application/json"]; ok {
+		return codes[0], &media.Schema
+	}
+	return codes[0], nil
+}
+
+func queryMethod(schemaType string) string {
+	switch schemaType {
+	case "integer":
+		return "QueryInt"
+	case "number":
+		return "QueryFloat"
+	case "boolean":
+		return "QueryBool"
+	}
+	return "Query"
+}
+
+// qualify prefixes the named sdk types in a Go type expression with "sdk."
+func (s *scaffolder) qualify(goType string) string {
+	prefix := ""
+	for {
+		switch {
+		case strings.HasPrefix(goType, "[]"):
+			prefix += "[]"
+			goType = goType[2:]
+			continue
+		case strings.HasPrefix(goType, "map[string]"):
+			prefix += "map[string]"
+			goType = goType[len("map[string]"):]
+			continue
+		}
+		break
+	}
+	if _, exists := s.schemas[goType]; exists {
+		goType = "sdk." + goType
+	}
+	return prefix + goType
+}
+
+// isCompositeType reports whether T{} is a valid literal for the type
+func (s *scaffolder) isCompositeType(goType string) bool {
+	if strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "map[") {
+		return true
+	}
+	name := strings.TrimPrefix(goType, "sdk.")
+	schema, exists := s.schemas[name]
+	if !exists {
+		return false
+	}
+	if len(schema.Properties) > 0 {
+		return true
+	}
+	underlying := s.goType(schema, name, "")
+	return strings.HasPrefix(underlying, "[]") || strings.HasPrefix(underlying, "map[")
+}
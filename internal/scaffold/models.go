@@ -0,0 +1,253 @@
+package scaffold
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+	"gopkg.in/yaml.v3"
+)
+
+// modelsSource renders every schema (including inline schemas registered
+// while rendering) as a Go type in package sdk
+func (s *scaffolder) modelsSource() string {
+	body, usesTime := s.typesSource()
+
+	var out strings.Builder
+	out.WriteString("package sdk\n\n")
+	if usesTime {
+		out.WriteString("import \"time\"\n\n")
+	}
+	out.WriteString(body)
+	return out.String()
+}
+
+// typesSource renders every schema, including inline schemas registered
+// while rendering, as Go types and reports whether they use time.Time
+func (s *scaffolder) typesSource() (string, bool) {
+	var body strings.Builder
+	usesTime := false
+
+	done := make(map[string]bool)
+	for {
+		var pending []string
+		for name := range s.schemas {
+			if !done[name] {
+				pending = append(pending, name)
+			}
+		}
+		if len(pending) == 0 {
+			break
+		}
+		sort.Strings(pending)
+
+		for _, name := range pending {
+			done[name] = true
+			source, timeUsed := s.typeSource(name, s.schemas[name])
+			body.WriteString(source)
+			usesTime = usesTime || timeUsed
+		}
+	}
+
+	return body.String(), usesTime
+}
+
+// typeSource renders a single named type and reports whether it uses time.Time
+func (s *scaffolder) typeSource(name string, schema generator.Schema) (string, bool) {
+	var b strings.Builder
+	writeComment(&b, schema.Description, "")
+
+	if len(schema.Properties) == 0 {
+		goType := s.goType(schema, name, "")
+		fmt.Fprintf(&b, "type %s %s\n\n", name, goType)
+		return b.String(), strings.Contains(goType, "time.Time")
+	}
+
+	required := make(map[string]bool)
+	for _, prop := range schema.Required {
+		required[prop] = true
+	}
+
+	props := make([]string, 0, len(schema.Properties))
+	for prop := range schema.Properties {
+		props = append(props, prop)
+	}
+	sort.Strings(props)
+
+	usesTime := false
+	fieldNames := make(map[string]int)
+	fmt.Fprintf(&b, "type %s struct {\n", name)
+	for _, prop := range props {
+		propSchema := schema.Properties[prop]
+
+		fieldName := goFieldName(prop)
+		if n := fieldNames[fieldName]; n > 0 {
+			fieldNames[fieldName] = n + 1
+			fieldName = fmt.Sprintf("%s%d", fieldName, n+1)
+		} else {
+			fieldNames[fieldName] = 1
+		}
+
+		goType := s.goType(propSchema, name, prop)
+		if strings.Contains(goType, "time.Time") {
+			usesTime = true
+		}
+
+		tag := prop
+		if !required[prop] {
+			tag += ",omitempty"
+		}
+
+		writeComment(&b, propSchema.Description, "\t")
+		if refs := oneOfRefs(propSchema); len(refs) > 0 {
+			fmt.Fprintf(&b, "\t//openapi:oneOf %s\n", strings.Join(refs, ","))
+		}
+		fmt.Fprintf(&b, "\t%s %s `json:\"%s\"`\n", fieldName, goType, tag)
+	}
+	b.WriteString("}\n\n")
+
+	return b.String(), usesTime
+}
+
+// goType maps a schema to a Go type. Inline object schemas are registered as
+// named types derived from their owner and field names.
+func (s *scaffolder) goType(schema generator.Schema, owner, field string) string {
+	switch {
+	case schema.Ref != "":
+		return goTypeName(refName(schema.Ref))
+	case len(schema.AllOf) == 1:
+		return s.goType(schema.AllOf[0], owner, field)
+	case len(schema.OneOf) > 0 || len(schema.AnyOf) > 0 || len(schema.AllOf) > 0:
+		return "interface{}"
+	}
+
+	switch schema.Type {
+	case "string":
+		if schema.Format == "date-time" {
+			return "time.Time"
+		}
+		return "string"
+	case "integer":
+		return integerType(schema)
+	case "number":
+		if schema.Format == "float" {
+			return "float32"
+		}
+		return "float64"
+	case "boolean":
+		return "bool"
+	case "array":
+		if schema.Items == nil {
+			return "[]interface{}"
+		}
+		return "[]" + s.goType(*schema.Items, owner, field)
+	case "object", "":
+		if len(schema.Properties) > 0 {
+			name := owner
+			if field != "" {
+				name += goFieldName(field)
+			}
+			return s.inlineType(name, schema)
+		}
+		if valueSchema, ok := additionalPropertiesSchema(schema.AdditionalProperties); ok {
+			return "map[string]" + s.goType(*valueSchema, owner, field+"_value")
+		}
+		if schema.Type == "object" || schema.AdditionalProperties != nil {
+			return "map[string]interface{}"
+		}
+	}
+	return "interface{}"
+}
+
+// inlineType registers an inline object schema under a unique Go type name
+func (s *scaffolder) inlineType(name string, schema generator.Schema) string {
+	candidate := name
+	for i := 2; ; i++ {
+		if _, exists := s.schemas[candidate]; !exists {
+			break
+		}
+		candidate = fmt.Sprintf("%s%d", name, i)
+	}
+	s.schemas[candidate] = schema
+	return candidate
+}
+
+// additionalPropertiesSchema returns the value schema of a map-like object,
+// which may come from a decoded spec (a generic map) or from the generator
+func additionalPropertiesSchema(value interface{}) (*generator.Schema, bool) {
+	switch v := value.(type) {
+	case *generator.Schema:
+		return v, v != nil
+	case generator.Schema:
+		return &v, true
+	case map[string]interface{}:
+		data, err := yaml.Marshal(v)
+		if err != nil {
+			return nil, false
+		}
+		var schema generator.Schema
+		if err := yaml.Unmarshal(data, &schema); err != nil {
+			return nil, false
+		}
+		return &schema, true
+	}
+	return nil, false
+}
+
+// integerType picks the Go integer type whose range matches the schema's
+// bounds, as emitted by the generator for sized integers
+func integerType(schema generator.Schema) string {
+	if schema.Minimum != nil && schema.Maximum != nil {
+		switch [2]int64{*schema.Minimum, *schema.Maximum} {
+		case [2]int64{math.MinInt8, math.MaxInt8}:
+			return "int8"
+		case [2]int64{math.MinInt16, math.MaxInt16}:
+			return "int16"
+		case [2]int64{0, math.MaxUint8}:
+			return "uint8"
+		case [2]int64{0, math.MaxUint16}:
+			return "uint16"
+		case [2]int64{0, math.MaxUint32}:
+			return "uint32"
+		}
+	}
+	unsigned := schema.Minimum != nil && *schema.Minimum == 0 && schema.Maximum == nil
+	switch {
+	case schema.Format == "int64" && unsigned:
+		return "uint64"
+	case schema.Format == "int64":
+		return "int64"
+	case unsigned:
+		return "uint"
+	}
+	return "int"
+}
+
+// oneOfRefs returns the schema names of a oneOf made only of references
+func oneOfRefs(schema generator.Schema) []string {
+	var refs []string
+	for _, option := range schema.OneOf {
+		if option.Ref == "" {
+			return nil
+		}
+		refs = append(refs, goTypeName(refName(option.Ref)))
+	}
+	return refs
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
+
+// writeComment writes text as // comment lines with the given indent
+func writeComment(b *strings.Builder, text, indent string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		fmt.Fprintf(b, "%s// %s\n", indent, strings.TrimSpace(line))
+	}
+}
@@ -0,0 +1,102 @@
+package scaffold
+
+import (
+	"go/token"
+	"strings"
+	"unicode"
+)
+
+// initialisms are written in upper case in Go identifiers
+var initialisms = map[string]bool{
+	"id": true, "url": true, "uri": true, "api": true, "http": true, "json": true,
+	"uuid": true, "ip": true, "sql": true, "html": true, "xml": true, "sla": true,
+}
+
+// words splits snake_case, kebab-case, camelCase and path-like names into
+// lowercase words
+func words(name string) []string {
+	var result []string
+	var current []rune
+	runes := []rune(name)
+
+	flush := func() {
+		if len(current) > 0 {
+			result = append(result, strings.ToLower(string(current)))
+			current = nil
+		}
+	}
+
+	for i, r := range runes {
+		switch {
+		case !unicode.IsLetter(r) && !unicode.IsDigit(r):
+			flush()
+			continue
+		case unicode.IsUpper(r) && i > 0:
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				flush()
+			}
+		}
+		current = append(current, r)
+	}
+	flush()
+
+	return result
+}
+
+// pascalCase joins words into an exported identifier: user_id -> UserID
+func pascalCase(parts []string) string {
+	var b strings.Builder
+	for _, word := range parts {
+		if initialisms[word] {
+			b.WriteString(strings.ToUpper(word))
+			continue
+		}
+		b.WriteString(strings.ToUpper(word[:1]) + word[1:])
+	}
+	return b.String()
+}
+
+// goFieldName returns the exported struct field name for a JSON property
+func goFieldName(prop string) string {
+	name := pascalCase(words(prop))
+	if name == "" || !unicode.IsLetter([]rune(name)[0]) {
+		name = "Field" + name
+	}
+	return name
+}
+
+// goTypeName returns a valid exported type name for a schema name, keeping
+// names that already are one
+func goTypeName(name string) string {
+	if token.IsIdentifier(name) && token.IsExported(name) {
+		return name
+	}
+	return goFieldName(name)
+}
+
+// goVarName returns an unexported variable name for a parameter
+func goVarName(name string) string {
+	exported := goFieldName(name)
+	parts := words(name)
+	if len(parts) > 0 && initialisms[parts[0]] {
+		// ID -> id, URLPath -> urlPath
+		exported = parts[0] + exported[len(parts[0]):]
+	} else {
+		exported = strings.ToLower(exported[:1]) + exported[1:]
+	}
+	if token.IsKeyword(exported) || exported == "c" || exported == "req" {
+		exported += "Param"
+	}
+	return exported
+}
+
+// packageName returns a Go package name for a path segment
+func packageName(segment string) string {
+	name := strings.Join(words(segment), "")
+	if name == "" || unicode.IsDigit([]rune(name)[0]) || token.IsKeyword(name) {
+		name = "api" + name
+	}
+	return name
+}
@@ -0,0 +1,148 @@
+package scaffold
+
+import (
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+	"gopkg.in/yaml.v3"
+)
+
+// Files maps slash-separated paths relative to the project root to their
+// contents
+type Files map[string][]byte
+
+// Load reads an OpenAPI spec in YAML or JSON format
+func Load(specPath string) (*generator.OpenAPISpec, error) {
+	data, err := os.ReadFile(specPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	var spec generator.OpenAPISpec
+	if err := yaml.Unmarshal(data, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+	return &spec, nil
+}
+
+// Generate produces a Fiber project skeleton for the spec: sdk structs for
+// its schemas, and a router.go and handlers.go per top-level path segment
+// under routes/. module is the Go module path used for imports.
+func Generate(spec *generator.OpenAPISpec, module string) (Files, error) {
+	s := &scaffolder{
+		module:  module,
+		schemas: make(map[string]generator.Schema),
+		files:   make(Files),
+	}
+	for name, schema := range spec.Components.Schemas {
+		s.schemas[goTypeName(name)] = schema
+	}
+
+	packages := s.buildPackages(spec)
+
+	names := make([]string, 0, len(packages))
+	for name := range packages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := s.writePackage(packages[name]); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.needsAuth {
+		if err := s.addGoFile("middleware/auth.go", authMiddlewareSource); err != nil {
+			return nil, err
+		}
+	}
+
+	// Models last: handlers may have added inline request/response schemas
+	if err := s.addGoFile("sdk/models.go", s.modelsSource()); err != nil {
+		return nil, err
+	}
+
+	return s.files, nil
+}
+
+// Write writes the generated files under dir. Existing files are only
+// overwritten when force is set.
+func Write(dir string, files Files, force bool) error {
+	paths := make([]string, 0, len(files))
+	for path := range files {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	if !force {
+		var existing []string
+		for _, path := range paths {
+			if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path))); err == nil {
+				existing = append(existing, path)
+			}
+		}
+		if len(existing) > 0 {
+			return fmt.Errorf("refusing to overwrite existing files (use -force): %s", strings.Join(existing, ", "))
+		}
+	}
+
+	for _, path := range paths {
+		target := filepath.Join(dir, filepath.FromSlash(path))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.WriteFile(target, files[path], 0644); err != nil {
+			return fmt.Errorf("failed to write %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// ModulePath reads the module path from dir/go.mod
+func ModulePath(dir string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+	if err != nil {
+		return "", err
+	}
+	match := regexp.MustCompile(`(?m)^module\s+(\S+)`).FindSubmatch(data)
+	if match == nil {
+		return "", fmt.Errorf("no module directive in go.mod")
+	}
+	return string(match[1]), nil
+}
+
+type scaffolder struct {
+	module    string
+	schemas   map[string]generator.Schema // Go type name -> schema
+	files     Files
+	needsAuth bool
+}
+
+// addGoFile gofmts and stores a generated Go source file
+func (s *scaffolder) addGoFile(path, source string) error {
+	formatted, err := format.Source([]byte(source))
+	if err != nil {
+		return fmt.Errorf("failed to format generated %s: %w", path, err)
+	}
+	s.files[path] = formatted
+	return nil
+}
+
+const authMiddlewareSource = `package middleware
+
+import "github.com/gofiber/fiber/v2"
+
+// Auth guards operations that require bearer authentication
+func Auth() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		// TODO: verify the bearer token
+		return c.Next()
+	}
+}
+`
@@ -0,0 +1,209 @@
+package selfupdate
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// DefaultReleasesURL is where release binaries are downloaded from
+const DefaultReleasesURL = "https://github.com/Aman-s12345/go-openapispec-generator/releases"
+
+// ChecksumsAsset is the file of each release listing the SHA-256 of its
+// binaries, in the format of sha256sum: "<hex digest>  <asset name>"
+const ChecksumsAsset = "checksums.txt"
+
+const (
+	// requestTimeout bounds a request until its response headers arrive, and
+	// small downloads like the checksums
+	requestTimeout = 30 * time.Second
+	// stallTimeout bounds how long the binary download may go without
+	// receiving data; the download as a whole has no deadline
+	stallTimeout = 60 * time.Second
+)
+
+// Updater replaces the running binary with a release binary
+type Updater struct {
+	ReleasesURL string // GitHub-style releases page: <url>/latest and <url>/download/<tag>/<asset>
+	client      *http.Client
+}
+
+func New(releasesURL string) *Updater {
+	if releasesURL == "" {
+		releasesURL = DefaultReleasesURL
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.ResponseHeaderTimeout = requestTimeout
+	return &Updater{
+		ReleasesURL: releasesURL,
+		client:      &http.Client{Transport: transport},
+	}
+}
+
+// AssetName is the release binary for this platform, e.g.
+// openapi-generator-linux-amd64
+func AssetName() string {
+	name := fmt.Sprintf("openapi-generator-%s-%s", runtime.GOOS, runtime.GOARCH)
+	if runtime.GOOS == "windows" {
+		name += ".exe"
+	}
+	return name
+}
+
+// Latest returns the tag of the latest release, read from the redirect of
+// <url>/latest to <url>/tag/<tag>
+func (u *Updater) Latest() (string, error) {
+	client := *u.client
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	resp, err := get(ctx, &client, u.ReleasesURL+"/latest")
+	if err != nil {
+		return "", fmt.Errorf("failed to look up the latest release: %w", err)
+	}
+	defer resp.Body.Close()
+	location := resp.Header.Get("Location")
+	if resp.StatusCode < 300 || resp.StatusCode >= 400 || location == "" {
+		return "", fmt.Errorf("failed to look up the latest release: unexpected status %s", resp.Status)
+	}
+	return path.Base(location), nil
+}
+
+// Update downloads the release binary of tag, verifies it against the
+// release's checksums and replaces the running executable with it,
+// returning the executable's path
+func (u *Updater) Update(tag string) (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", fmt.Errorf("failed to locate the running binary: %w", err)
+	}
+	if resolved, err := filepath.EvalSymlinks(executable); err == nil {
+		executable = resolved
+	}
+
+	want, err := u.checksum(tag, AssetName())
+	if err != nil {
+		return "", err
+	}
+
+	url := fmt.Sprintf("%s/download/%s/%s", u.ReleasesURL, tag, AssetName())
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	resp, err := get(ctx, u.client, url)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download %s: %s", url, resp.Status)
+	}
+
+	// Written next to the binary so the rename doesn't cross filesystems
+	tmp, err := os.CreateTemp(filepath.Dir(executable), ".openapi-generator-*")
+	if err != nil {
+		return "", fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	hash := sha256.New()
+	body := &stallReader{reader: resp.Body, timer: time.AfterFunc(stallTimeout, cancel)}
+	defer body.timer.Stop()
+	if _, err := io.Copy(io.MultiWriter(tmp, hash), body); err != nil {
+		tmp.Close()
+		return "", fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return "", fmt.Errorf("failed to write the new binary: %w", err)
+	}
+	if got := hex.EncodeToString(hash.Sum(nil)); got != want {
+		return "", fmt.Errorf("checksum mismatch for %s: got sha256 %s, %s lists %s", AssetName(), got, ChecksumsAsset, want)
+	}
+	if err := os.Chmod(tmp.Name(), 0755); err != nil {
+		return "", fmt.Errorf("failed to make the new binary executable: %w", err)
+	}
+
+	// Windows can't replace a running executable, but can rename it
+	old := ""
+	if runtime.GOOS == "windows" {
+		old = executable + ".old"
+		os.Remove(old)
+		if err := os.Rename(executable, old); err != nil {
+			return "", fmt.Errorf("failed to move the running binary aside: %w", err)
+		}
+	}
+	if err := os.Rename(tmp.Name(), executable); err != nil {
+		if old != "" {
+			if restoreErr := os.Rename(old, executable); restoreErr != nil {
+				return "", fmt.Errorf("failed to replace %s: %w (and failed to restore it from %s: %v)", executable, err, old, restoreErr)
+			}
+		}
+		return "", fmt.Errorf("failed to replace %s: %w", executable, err)
+	}
+	return executable, nil
+}
+
+// checksum returns the SHA-256 the release's checksums file lists for asset
+func (u *Updater) checksum(tag, asset string) (string, error) {
+	url := fmt.Sprintf("%s/download/%s/%s", u.ReleasesURL, tag, ChecksumsAsset)
+	ctx, cancel := context.WithTimeout(context.Background(), requestTimeout)
+	defer cancel()
+	resp, err := get(ctx, u.client, url)
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", url, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to download %s: %s; refusing to install an unverified binary", url, resp.Status)
+	}
+
+	scanner := bufio.NewScanner(resp.Body)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		// sha256sum marks binary mode with a * before the name
+		if len(fields) == 2 && strings.TrimPrefix(fields[1], "*") == asset {
+			digest := strings.ToLower(fields[0])
+			if _, err := hex.DecodeString(digest); err != nil || len(digest) != sha256.Size*2 {
+				return "", fmt.Errorf("invalid checksum for %s in %s", asset, url)
+			}
+			return digest, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", url, err)
+	}
+	return "", fmt.Errorf("%s lists no checksum for %s; refusing to install an unverified binary", url, asset)
+}
+
+func get(ctx context.Context, client *http.Client, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	return client.Do(req)
+}
+
+// stallReader pushes back its timer, which cancels the download, whenever
+// data arrives
+type stallReader struct {
+	reader io.Reader
+	timer  *time.Timer
+}
+
+func (r *stallReader) Read(p []byte) (int, error) {
+	n, err := r.reader.Read(p)
+	if n > 0 {
+		r.timer.Reset(stallTimeout)
+	}
+	return n, err
+}
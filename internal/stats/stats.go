@@ -0,0 +1,130 @@
+package stats
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultName is the stats file runs are appended to
+const DefaultName = "openapigen-stats.jsonl"
+
+// Record describes one generation run. Records stay on disk: nothing is
+// sent anywhere.
+type Record struct {
+	Time            time.Time      `json:"time"`
+	ToolVersion     string         `json:"tool_version"`
+	Project         string         `json:"project"`
+	Framework       string         `json:"framework"`
+	AnalyzeMillis   int64          `json:"analyze_ms"`
+	GenerateMillis  int64          `json:"generate_ms"`
+	Routes          int            `json:"routes"`
+	Models          int            `json:"models"`
+	Attempts        map[string]int `json:"attempts,omitempty"` // types each heuristic was tried on
+	Resolved        map[string]int `json:"resolved,omitempty"` // types inferred by each heuristic
+	Matched         int            `json:"matched"`            // request and response types that matched a model
+	Unresolved      int            `json:"unresolved"`         // request and response types that matched none
+	UnresolvedTypes []string       `json:"unresolved_types,omitempty"`
+	TypeChecked     int            `json:"type_checked"`
+	TypeCheckFailed int            `json:"type_check_failed"`
+}
+
+// ResolvedTotal is the number of types inferred by any heuristic
+func (r Record) ResolvedTotal() int {
+	total := 0
+	for _, count := range r.Resolved {
+		total += count
+	}
+	return total
+}
+
+// HitRate is the share of request and response types that matched a
+// model, from 0 to 1; runs without any have a rate of 1
+func (r Record) HitRate() float64 {
+	if r.Matched+r.Unresolved == 0 {
+		return 1
+	}
+	return float64(r.Matched) / float64(r.Matched+r.Unresolved)
+}
+
+// Append adds a record to the stats file as a JSON line, creating the file
+func Append(path string, record Record) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode stats: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("failed to open stats file: %w", err)
+	}
+	defer file.Close()
+	if _, err := file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("failed to write stats file: %w", err)
+	}
+	return nil
+}
+
+// Read loads the records of a stats file, oldest first. Lines that don't
+// parse, e.g. left by an interrupted run, are skipped.
+func Read(path string) ([]Record, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read stats file: %w", err)
+	}
+	defer file.Close()
+
+	var records []Record
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var record Record
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			continue
+		}
+		records = append(records, record)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read stats file: %w", err)
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		return records[i].Time.Before(records[j].Time)
+	})
+	return records, nil
+}
+
+// sparkTicks draw a series from its lowest to its highest value
+var sparkTicks = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline draws values as a line of block characters scaled between the
+// lowest and the highest
+func Sparkline(values []float64) string {
+	if len(values) == 0 {
+		return ""
+	}
+	low, high := values[0], values[0]
+	for _, value := range values {
+		if value < low {
+			low = value
+		}
+		if value > high {
+			high = value
+		}
+	}
+	var line strings.Builder
+	for _, value := range values {
+		tick := 0
+		if high > low {
+			tick = int((value - low) / (high - low) * float64(len(sparkTicks)-1))
+		}
+		line.WriteRune(sparkTicks[tick])
+	}
+	return line.String()
+}
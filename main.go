@@ -1,15 +1,23 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"log"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
 
 	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/catalog"
 	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/linter"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/publisher"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/report"
 	"gopkg.in/yaml.v3"
 )
 
@@ -23,19 +31,259 @@ type Config struct {
 	Description   string `json:"description"`
 	RoutesPattern string `json:"routes_pattern"`
 	SDKPackage    string `json:"sdk_package"`
+	Framework     string `json:"framework"`
+	ProblemJSON   bool   `json:"problem_json"`
+	NotifyWebhook string `json:"notify_webhook"`
+	PreviousSpec  string `json:"previous_spec"`
+
+	// Backstage catalog-info.yaml emission
+	CatalogInfo      string `json:"catalog_info"`
+	CatalogOwner     string `json:"catalog_owner"`
+	CatalogLifecycle string `json:"catalog_lifecycle"`
+	CatalogSystem    string `json:"catalog_system"`
+
+	// Ownership extensions from a path map and/or CODEOWNERS
+	Ownership  []generator.OwnershipRule `json:"ownership"`
+	CodeOwners bool                      `json:"codeowners"`
+
+	// Stability levels (alpha/beta/ga)
+	DefaultStability string `json:"default_stability"`
+	PublicOutput     string `json:"public_output"`
+
+	SLA generator.SLAConfig `json:"sla"`
+
+	// Security schemes and per-route requirements
+	Security generator.SecurityConfig `json:"security"`
+
+	// Tenancy headers required on the operations under given paths
+	Tenancy generator.TenancyConfig `json:"tenancy"`
+
+	ReportPath string `json:"report_path"`
+	DeadRoutes string `json:"dead_routes"`
+
+	// Enums with more values than this become named schemas (0: default of 20, -1: never)
+	MaxInlineEnum int `json:"max_inline_enum"`
+
+	// Schemas of models with embedded structs: flatten (default) promotes the
+	// embedded fields, allof composes the embedded structs' schemas
+	EmbeddedStructs string `json:"embedded_structs"`
+
+	// Wrap the singular response model of GET collection routes, like
+	// GET /users returning User, in a list: array or paginated
+	ListResponses string `json:"list_responses"`
+
+	// Leave out the request bodies GET, HEAD and DELETE handlers read,
+	// which gateways may reject
+	StripGetBodies bool `json:"strip_get_bodies"`
+
+	// Schemas of models without exported fields: object (default), free-form
+	// or skip, which leaves them and the bodies of their type out
+	EmptyStructs string `json:"empty_structs"`
+
+	// String formats by named Go type, e.g. {"Email": "email"}; others are
+	// inferred from the type name
+	Formats map[string]string `json:"formats"`
+
+	// JSON types of Go types whose JSON isn't their shape, like types with
+	// a MarshalJSON method, e.g. {"Money": "string", "Stamp": "integer:int64"}
+	JSONTypes map[string]string `json:"json_types"`
+
+	// Convert the descriptions taken from doc comments to CommonMark for
+	// doc renderers
+	Markdown bool `json:"markdown_descriptions"`
+
+	// Decimal and big number types, like decimal.Decimal and big.Int:
+	// string (default) or number
+	Decimals string `json:"decimals"`
+
+	// time.Duration: integer (default), its nanoseconds, or string, a Go
+	// duration string like 1h30m
+	Durations string `json:"durations"`
+
+	// Set the documentation score of each tag as x-doc-score
+	DocScore bool `json:"doc_score"`
+
+	// Pointer fields: nullable (default), nullable and optional unless
+	// validated as required, or plain, documented as their value
+	Pointers string `json:"pointers"`
+
+	// Fail on the first file with a syntax error instead of skipping it
+	Strict bool `json:"strict"`
+
+	// How long analysis and generation may take, like "5m"; no limit if empty
+	Timeout string `json:"timeout"`
+
+	// Spec fragments from spec-first frameworks merged into the output
+	Fragments []string `json:"fragments"`
+
+	// Methods documented for routes registered with All (default: GET, POST, PUT, DELETE, PATCH)
+	AllMethods []string `json:"all_methods"`
+
+	// Handler whose analysis state is dumped, for troubleshooting inference
+	DebugHandler string `json:"debug_handler"`
+
+	// Document routes serving static files as GET operations
+	IncludeStatic bool `json:"include_static"`
+
+	// Analyze with AST heuristics only, without loading the project with
+	// go/packages for type information
+	SkipTypeCheck bool `json:"skip_typecheck"`
+
+	// Prefixes route packages are mounted at, by directory relative to the
+	// project or package name, e.g. {"routes/users": "/api/v1/users"}
+	RoutePrefixes map[string]string `json:"route_prefixes"`
+
+	// Packages models are loaded from besides sdk, as directories relative
+	// to the project or import paths, e.g. ["internal/models", "pkg/dto"]
+	ModelPackages []string `json:"model_packages"`
+
+	// Leave WebSocket routes out of the spec instead of marking them
+	ExcludeWebSocket bool `json:"exclude_websocket"`
+
+	// Also write a spec per tag next to the output, e.g. openapi.users.yaml
+	SplitByTag bool `json:"split_by_tag"`
+
+	// Hand-maintained spec the generated paths, components and tags are
+	// injected into, keeping its info, servers, security and the rest
+	BaseSpec string `json:"base_spec"`
+
+	// Emit only the model schemas, without paths, for a shared schema package
+	ComponentsOnly bool `json:"components_only"`
+
+	// Generator versions the config may be used with, e.g. ">=1.4.0, <2";
+	// other versions fail before generating
+	RequiredVersion string `json:"required_version"`
+
+	// Lock file recording the tool version, config and input hashes the
+	// spec was generated from, for the check subcommand
+	LockFile string `json:"lock_file"`
+
+	// Local file each run appends its timings and type resolution counts
+	// to, for the stats subcommand; nothing is recorded when empty
+	StatsFile string `json:"stats_file"`
+
+	// Output profile tuning the spec for a consumer: oapi-codegen
+	Profile string `json:"profile"`
+
+	Lint linter.Config `json:"lint"`
+
+	Publish publisher.Config `json:"publish"`
+}
+
+// defaultConfig returns the configuration of a run without a config file,
+// which the flags default to
+func defaultConfig() Config {
+	return Config{
+		ProjectPath:     ".",
+		OutputPath:      "openapi.yaml",
+		OutputFormat:    "yaml",
+		ServerURL:       "http://localhost:3000",
+		Title:           "VSA API Server",
+		Version:         "1.0.0",
+		Description:     "Voice Service API Server",
+		DeadRoutes:      "mark",
+		MaxInlineEnum:   20,
+		EmbeddedStructs: "flatten",
+		EmptyStructs:    "object",
+		Decimals:        "string",
+		Durations:       "integer",
+		Pointers:        "nullable",
+		RoutesPattern:   "routes/**/router.go",
+		SDKPackage:      "sdk",
+		Framework:       "auto",
+	}
 }
 
 func main() {
+	// subcommands
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "publish":
+			runPublish(os.Args[2:])
+			return
+		case "scaffold":
+			runScaffold(os.Args[2:])
+			return
+		case "roundtrip-test":
+			runRoundtripTest(os.Args[2:])
+			return
+		case "gen-fixtures":
+			runGenFixtures(os.Args[2:])
+			return
+		case "serve":
+			runServe(os.Args[2:])
+			return
+		case "check":
+			runCheck(os.Args[2:])
+			return
+		case "stats":
+			runStats(os.Args[2:])
+			return
+		case "bench":
+			runBench(os.Args[2:])
+			return
+		case "version":
+			runVersion(os.Args[2:])
+			return
+		case "self-update":
+			runSelfUpdate(os.Args[2:])
+			return
+		}
+	}
+
 	// cmd line flags
+	defaults := defaultConfig()
 	var (
 		configPath   = flag.String("config", "", "Path to configuration file")
-		projectPath  = flag.String("project", ".", "Path to Go project")
-		outputPath   = flag.String("output", "openapi.yaml", "Output file path")
-		outputFormat = flag.String("format", "yaml", "Output format (json|yaml)")
-		serverURL    = flag.String("server", "http://localhost:3000", "Server URL")
-		title        = flag.String("title", "VSA API Server", "API title")
-		version      = flag.String("version", "1.0.0", "API version")
-		description  = flag.String("description", "Voice Service API Server", "API description")
+		projectPath  = flag.String("project", defaults.ProjectPath, "Path to Go project")
+		outputPath   = flag.String("output", defaults.OutputPath, "Output file path")
+		outputFormat = flag.String("format", defaults.OutputFormat, "Output format (json|yaml)")
+		serverURL    = flag.String("server", defaults.ServerURL, "Server URL")
+		title        = flag.String("title", defaults.Title, "API title")
+		version      = flag.String("version", defaults.Version, "API version")
+		description  = flag.String("description", defaults.Description, "API description")
+		framework    = flag.String("framework", defaults.Framework, "Router framework (auto|fiber|gin|echo|chi|mux|httprouter|hertz|iris|nethttp|grpc-gateway)")
+		problemJSON  = flag.Bool("problem-json", false, "Emit error responses as application/problem+json (RFC 7807)")
+		notifyHook   = flag.String("notify-webhook", "", "Slack/Teams webhook URL to notify of endpoint changes")
+		previousSpec = flag.String("previous", "", "Previous spec to diff against for notifications (default: existing output file)")
+		catalogInfo  = flag.String("catalog-info", "", "Write or update a Backstage catalog-info.yaml API entity at this path")
+		catalogOwner = flag.String("catalog-owner", "", "Owner of the Backstage API entity")
+		useCodeOwner = flag.Bool("codeowners", false, "Stamp x-owner/x-team on operations from the project's CODEOWNERS")
+		stability    = flag.String("default-stability", "", "x-stability for operations without a @stability annotation (alpha|beta|ga)")
+		publicOutput = flag.String("public-output", "", "Also write a public spec variant without alpha operations to this path")
+		reportPath   = flag.String("report", "", "Write a coverage report with payload size estimates (.json for JSON)")
+		deadRoutes   = flag.String("dead-routes", defaults.DeadRoutes, "Handling of stub handlers (mark|exclude|ignore)")
+		maxEnum      = flag.Int("max-inline-enum", defaults.MaxInlineEnum, "Enums with more values are emitted as named schemas (-1 keeps all inline)")
+		embedded     = flag.String("embedded-structs", defaults.EmbeddedStructs, "Schemas of models with embedded structs (flatten|allof)")
+		listResp     = flag.String("list-responses", "", "Wrap singular responses of GET collection routes in a list (array|paginated)")
+		stripBodies  = flag.Bool("strip-get-bodies", false, "Leave request bodies of GET, HEAD and DELETE routes out of the spec")
+		emptyStructs = flag.String("empty-structs", defaults.EmptyStructs, "Schemas of models without exported fields (object|free-form|skip)")
+		formats      = flag.String("formats", "", "String formats for named types, e.g. Email=email,ISODate=date")
+		jsonTypes    = flag.String("json-types", "", "JSON types of types with a custom MarshalJSON, e.g. Money=string,Stamp=integer:int64")
+		markdown     = flag.Bool("markdown-descriptions", false, "Convert descriptions from doc comments to CommonMark for doc renderers")
+		decimals     = flag.String("decimals", defaults.Decimals, "Schemas of decimal and big number types (string|number)")
+		durations    = flag.String("durations", defaults.Durations, "Schema of time.Duration: nanoseconds or a duration string like 1h30m (integer|string)")
+		docScore     = flag.Bool("doc-score", false, "Set the documentation completeness score of each tag as x-doc-score")
+		pointers     = flag.String("pointers", defaults.Pointers, "Pointer fields: nullable and optional, or plain as their value (nullable|plain)")
+		strict       = flag.Bool("strict", false, "Fail on the first file with a syntax error instead of skipping it")
+		timeout      = flag.String("timeout", "", "Fail when analysis and generation take longer, e.g. 5m (no limit if empty)")
+		fragments    = flag.String("fragments", "", "Comma-separated spec fragments (YAML or JSON) to merge into the output")
+		allMethods   = flag.String("all-methods", "", "Comma-separated methods to document routes registered with All under (default GET,POST,PUT,DELETE,PATCH)")
+		debugHandler = flag.String("debug-handler", "", "Print the analyzer's tracked variables and inferred types for this handler")
+		modelPkgs    = flag.String("model-packages", "", "Comma-separated packages to load models from besides sdk, e.g. internal/models,pkg/dto")
+		skipTypes    = flag.Bool("skip-typecheck", false, "Don't type-check the project; infer types with AST heuristics only")
+		static       = flag.Bool("include-static", false, "Document Static() file routes as GET operations returning binary content")
+		excludeWS    = flag.Bool("exclude-websocket", false, "Leave WebSocket routes out instead of marking them with x-websocket")
+		splitByTag   = flag.Bool("split-by-tag", false, "Also write a spec per tag, with only the components it uses, next to the output")
+		baseSpec     = flag.String("base", "", "Hand-maintained base spec to inject the generated paths and components into")
+		components   = flag.Bool("components-only", false, "Emit only components.schemas from the models, without paths")
+		lockFile     = flag.String("lock", "", "Write a lock file of the inputs the spec was generated from, e.g. openapigen.lock")
+		statsFile    = flag.String("stats", "", "Append run timings and type resolution counts to this local file, e.g. openapigen-stats.jsonl")
+		profile      = flag.String("profile", "", "Output profile tuning the spec for a code generator (oapi-codegen)")
+		lint         = flag.Bool("lint", false, "Report lint findings for the generated spec")
+		lintPack     = flag.String("lint-pack", "", "Governance policy pack to lint with (zalando|google|custom)")
+		normalize    = flag.Bool("normalize-params", false, "Rename inconsistently named parameters to their canonical name")
+		paramCase    = flag.String("param-case", "", "Rename path and query parameters to one case, in paths too (snake_case|camelCase)")
 		help         = flag.Bool("h", false, "Show help")
 	)
 	flag.Parse()
@@ -53,22 +301,71 @@ func main() {
 		}
 	} else {
 		config = Config{
-			ProjectPath:  *projectPath,
-			OutputPath:   *outputPath,
-			OutputFormat: *outputFormat,
-			ServerURL:    *serverURL,
-			Title:        *title,
-			Version:      *version,
-			Description:  *description,
+			ProjectPath:      *projectPath,
+			OutputPath:       *outputPath,
+			OutputFormat:     *outputFormat,
+			ServerURL:        *serverURL,
+			Title:            *title,
+			Version:          *version,
+			Description:      *description,
+			ProblemJSON:      *problemJSON,
+			NotifyWebhook:    *notifyHook,
+			PreviousSpec:     *previousSpec,
+			CatalogInfo:      *catalogInfo,
+			CatalogOwner:     *catalogOwner,
+			CodeOwners:       *useCodeOwner,
+			DefaultStability: *stability,
+			PublicOutput:     *publicOutput,
+			ReportPath:       *reportPath,
+			DeadRoutes:       *deadRoutes,
+			MaxInlineEnum:    *maxEnum,
+			EmbeddedStructs:  *embedded,
+			ListResponses:    *listResp,
+			StripGetBodies:   *stripBodies,
+			EmptyStructs:     *emptyStructs,
+			Formats:          parseFormats(*formats),
+			JSONTypes:        parseFormats(*jsonTypes),
+			Markdown:         *markdown,
+			Decimals:         *decimals,
+			Durations:        *durations,
+			DocScore:         *docScore,
+			Pointers:         *pointers,
+			Strict:           *strict,
+			Timeout:          *timeout,
+			Fragments:        splitList(*fragments),
+			AllMethods:       splitList(*allMethods),
+			DebugHandler:     *debugHandler,
+			Profile:          *profile,
+			LockFile:         *lockFile,
+			StatsFile:        *statsFile,
+			IncludeStatic:    *static,
+			SkipTypeCheck:    *skipTypes,
+			ModelPackages:    splitList(*modelPkgs),
+			ExcludeWebSocket: *excludeWS,
+			SplitByTag:       *splitByTag,
+			ComponentsOnly:   *components,
+			BaseSpec:         *baseSpec,
+			Lint: linter.Config{
+				Enabled:         *lint,
+				NormalizeParams: *normalize,
+				ParamCase:       *paramCase,
+				Pack:            *lintPack,
+			},
 			// Default pattern for routes and SDK
-			RoutesPattern: "routes/**/router.go",
-			SDKPackage:    "sdk",
+			RoutesPattern: defaults.RoutesPattern,
+			SDKPackage:    defaults.SDKPackage,
+			Framework:     *framework,
 		}
 	}
 
 	if _, err := os.Stat(config.ProjectPath); os.IsNotExist(err) {
 		log.Fatalf("Project path does not exist: %s", config.ProjectPath)
 	}
+	switch config.ListResponses {
+	case "", generator.ListArray, generator.ListPaginated:
+	default:
+		log.Fatalf("Invalid list responses mode: %s (supported: array, paginated)", config.ListResponses)
+	}
 
 	// Check for SDK directory
 	sdkPath := filepath.Join(config.ProjectPath, "sdk")
@@ -85,22 +382,133 @@ func main() {
 	} else {
 		fmt.Printf("Routes directory found: %s\n", routesPath)
 	}
-	projectAnalyzer := analyzer.New(config.ProjectPath, config.SDKPackage, config.RoutesPattern)
-	analysis, err := projectAnalyzer.Analyze()
+	ctx, cancel, err := runContext(config)
+	if err != nil {
+		log.Fatalf("Invalid timeout: %v", err)
+	}
+	defer cancel()
+
+	analyzeStart := time.Now()
+	analysis, err := analyzeProject(ctx, config)
 	if err != nil {
 		log.Fatalf("Failed to analyze project: %v", err)
 	}
+	analyzeTime := time.Since(analyzeStart)
 
-	specGenerator := generator.New(generator.Config{
-		Title:       config.Title,
-		Version:     config.Version,
-		Description: config.Description,
-		ServerURL:   config.ServerURL,
-	})
-	spec := specGenerator.Generate(analysis)
-	if err := writeOutput(spec, config.OutputPath, config.OutputFormat); err != nil {
+	generatorConfig, err := newGeneratorConfig(config)
+	if err != nil {
+		log.Fatalf("Failed to load CODEOWNERS: %v", err)
+	}
+	generateStart := time.Now()
+	specGenerator := generator.New(generatorConfig)
+	spec, err := specGenerator.Generate(ctx, analysis)
+	if err != nil {
+		log.Fatalf("Failed to generate spec: %v", err)
+	}
+	if err := specGenerator.MergeFragments(spec, config.Fragments); err != nil {
+		log.Fatalf("Failed to merge spec fragments: %v", err)
+	}
+	if err := specGenerator.ApplyProfile(spec); err != nil {
+		log.Fatalf("Failed to apply output profile: %v", err)
+	}
+	generateTime := time.Since(generateStart)
+
+	lintErrors := 0
+	if config.Lint.Enabled {
+		findings := linter.Lint(spec, config.Lint)
+		for _, finding := range findings {
+			fmt.Printf("LINT %s\n", finding)
+		}
+		lintErrors = linter.Errors(findings)
+		fmt.Printf("Lint findings: %d (%d errors)\n", len(findings), lintErrors)
+	}
+	if config.Lint.Normalizes() {
+		renamed, conflicts := linter.NormalizeParams(spec, config.Lint)
+		for _, finding := range conflicts {
+			fmt.Printf("LINT %s\n", finding)
+		}
+		lintErrors += linter.Errors(conflicts)
+		if renamed > 0 {
+			fmt.Printf("Normalized %d parameter names\n", renamed)
+		}
+	}
+
+	// Load the previous spec before it is overwritten
+	var previous interface{}
+	if config.NotifyWebhook != "" {
+		previous = loadPreviousSpec(config)
+	}
+
+	if err := writeSpec(spec, config.BaseSpec, config.OutputPath, config.OutputFormat); err != nil {
 		log.Fatalf("Failed to write output: %v", err)
 	}
+
+	if config.LockFile != "" {
+		if err := writeLock(config, analysis); err != nil {
+			log.Fatalf("Failed to write lock file: %v", err)
+		}
+	}
+
+	if config.StatsFile != "" {
+		if err := recordStats(config, analysis, analyzeTime, generateTime); err != nil {
+			log.Fatalf("Failed to record usage statistics: %v", err)
+		}
+	}
+
+	if config.SplitByTag {
+		if err := writeTagSpecs(spec, config.OutputPath, config.OutputFormat); err != nil {
+			log.Fatalf("Failed to write per-tag specs: %v", err)
+		}
+	}
+
+	if config.ReportPath != "" {
+		if err := report.Build(spec).WriteFile(config.ReportPath); err != nil {
+			log.Fatalf("Failed to write report: %v", err)
+		}
+		fmt.Printf("Coverage report written: %s\n", config.ReportPath)
+	}
+
+	// Public variant leaves out alpha operations
+	if config.PublicOutput != "" {
+		publicConfig := generatorConfig
+		publicConfig.ExcludeStability = []string{"alpha"}
+		publicGenerator := generator.New(publicConfig)
+		publicSpec, err := publicGenerator.Generate(ctx, analysis)
+		if err != nil {
+			log.Fatalf("Failed to generate public spec: %v", err)
+		}
+		if err := publicGenerator.MergeFragments(publicSpec, config.Fragments); err != nil {
+			log.Fatalf("Failed to merge spec fragments: %v", err)
+		}
+		if err := publicGenerator.ApplyProfile(publicSpec); err != nil {
+			log.Fatalf("Failed to apply output profile: %v", err)
+		}
+		if config.Lint.Normalizes() {
+			linter.NormalizeParams(publicSpec, config.Lint)
+		}
+		if err := writeSpec(publicSpec, config.BaseSpec, config.PublicOutput, config.OutputFormat); err != nil {
+			log.Fatalf("Failed to write public output: %v", err)
+		}
+	}
+
+	if config.NotifyWebhook != "" {
+		notifyChanges(config, previous, spec)
+	}
+
+	if config.CatalogInfo != "" {
+		err := catalog.Write(config.CatalogInfo, catalog.Entity{
+			Name:        catalog.EntityName(config.Title),
+			Description: config.Description,
+			Owner:       config.CatalogOwner,
+			Lifecycle:   config.CatalogLifecycle,
+			System:      config.CatalogSystem,
+			SpecPath:    config.OutputPath,
+		})
+		if err != nil {
+			log.Fatalf("Failed to write catalog info: %v", err)
+		}
+		fmt.Printf("Catalog info written: %s\n", config.CatalogInfo)
+	}
 	// Verify the file was created
 	if _, err := os.Stat(config.OutputPath); err == nil {
 		info, _ := os.Stat(config.OutputPath)
@@ -108,6 +516,122 @@ func main() {
 	} else {
 		fmt.Printf("ERROR: Output file was not created: %v\n", err)
 	}
+
+	// Error-severity lint findings fail the run once the outputs are written
+	if lintErrors > 0 {
+		log.Fatalf("Lint failed with %d error(s)", lintErrors)
+	}
+}
+
+// runContext returns the context of a run, canceled once the configured
+// timeout elapses
+func runContext(config Config) (context.Context, context.CancelFunc, error) {
+	if config.Timeout == "" {
+		ctx, cancel := context.WithCancel(context.Background())
+		return ctx, cancel, nil
+	}
+	timeout, err := time.ParseDuration(config.Timeout)
+	if err != nil || timeout <= 0 {
+		return nil, nil, fmt.Errorf("%q is not a positive duration like 5m", config.Timeout)
+	}
+	ctx, cancel := context.WithTimeoutCause(context.Background(), timeout, fmt.Errorf("timed out after %s", timeout))
+	return ctx, cancel, nil
+}
+
+// analyzeProject runs the analyzer over the configured project
+func analyzeProject(ctx context.Context, config Config) (*analyzer.Analysis, error) {
+	projectAnalyzer := analyzer.New(analyzer.Config{
+		ProjectPath:   config.ProjectPath,
+		SDKPackage:    config.SDKPackage,
+		RoutesPattern: config.RoutesPattern,
+		Framework:     config.Framework,
+		AllMethods:    config.AllMethods,
+		DebugHandler:  config.DebugHandler,
+		IncludeStatic: config.IncludeStatic,
+		ModelsOnly:    config.ComponentsOnly,
+		RoutePrefixes: config.RoutePrefixes,
+		SkipTypeCheck: config.SkipTypeCheck,
+		ModelPackages: config.ModelPackages,
+		Strict:        config.Strict,
+	})
+	analysis, err := projectAnalyzer.Analyze(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if len(analysis.SkippedFiles) > 0 {
+		fmt.Printf("Warning: skipped %d file(s) with syntax errors; their models, handlers and routes are missing from the spec (use -strict to fail instead):\n", len(analysis.SkippedFiles))
+		for _, skipped := range analysis.SkippedFiles {
+			fmt.Printf("  %v\n", skipped)
+		}
+	}
+	return analysis, nil
+}
+
+// newGeneratorConfig builds the generator settings from the config, loading
+// CODEOWNERS when requested
+func newGeneratorConfig(config Config) (generator.Config, error) {
+	var codeOwners []generator.OwnershipRule
+	if config.CodeOwners {
+		var err error
+		codeOwners, err = generator.LoadCodeOwners(config.ProjectPath)
+		if err != nil {
+			return generator.Config{}, err
+		}
+	}
+
+	return generator.Config{
+		Title:            config.Title,
+		Version:          config.Version,
+		Description:      config.Description,
+		ServerURL:        config.ServerURL,
+		ProblemJSON:      config.ProblemJSON,
+		Ownership:        config.Ownership,
+		CodeOwners:       codeOwners,
+		DefaultStability: config.DefaultStability,
+		SLA:              config.SLA,
+		Security:         config.Security,
+		Tenancy:          config.Tenancy,
+		ExcludeWebSocket: config.ExcludeWebSocket,
+		ComponentsOnly:   config.ComponentsOnly,
+		DeadRoutes:       config.DeadRoutes,
+		MaxInlineEnum:    config.MaxInlineEnum,
+		EmbeddedStructs:  config.EmbeddedStructs,
+		ListResponses:    config.ListResponses,
+		StripGetBodies:   config.StripGetBodies,
+		EmptyStructs:     config.EmptyStructs,
+		Formats:          config.Formats,
+		JSONTypes:        config.JSONTypes,
+		Markdown:         config.Markdown,
+		Decimals:         config.Decimals,
+		Durations:        config.Durations,
+		DocScore:         config.DocScore,
+		Pointers:         config.Pointers,
+		Profile:          config.Profile,
+	}, nil
+}
+
+// splitList splits a comma-separated flag value, dropping empty entries
+func splitList(value string) []string {
+	var values []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			values = append(values, entry)
+		}
+	}
+	return values
+}
+
+// parseFormats parses "Email=email,ISODate=date" into a format registry
+func parseFormats(value string) map[string]string {
+	formats := make(map[string]string)
+	for _, entry := range strings.Split(value, ",") {
+		typeName, format, found := strings.Cut(strings.TrimSpace(entry), "=")
+		if !found || typeName == "" {
+			continue
+		}
+		formats[strings.TrimSpace(typeName)] = strings.TrimSpace(format)
+	}
+	return formats
 }
 
 func loadConfig(configPath string, config *Config) error {
@@ -119,9 +643,47 @@ func loadConfig(configPath string, config *Config) error {
 	if err := json.Unmarshal(data, config); err != nil {
 		return fmt.Errorf("failed to parse config file: %w", err)
 	}
+	return checkRequiredVersion(config.RequiredVersion)
+}
+
+// writeTagSpecs writes a spec per tag next to the output, named after the
+// output file and the tag: openapi.yaml gives openapi.users.yaml
+func writeTagSpecs(spec *generator.OpenAPISpec, outputPath, format string) error {
+	ext := filepath.Ext(outputPath)
+	base := strings.TrimSuffix(outputPath, ext)
+	unsafe := regexp.MustCompile(`[^a-z0-9]+`)
+
+	tagSpecs := generator.SplitByTag(spec)
+	for tag, tagSpec := range tagSpecs {
+		name := strings.Trim(unsafe.ReplaceAllString(strings.ToLower(tag), "-"), "-")
+		if err := writeOutput(tagSpec, base+"."+name+ext, format); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("Wrote %d per-tag specs next to %s\n", len(tagSpecs), outputPath)
 	return nil
 }
 
+// writeSpec writes the spec, injected into the base spec if one is given
+func writeSpec(spec *generator.OpenAPISpec, basePath, outputPath, format string) error {
+	if basePath == "" {
+		return writeOutput(spec, outputPath, format)
+	}
+	merged, err := generator.MergeIntoBase(spec, basePath)
+	if err != nil {
+		return err
+	}
+	if format == "json" {
+		// JSON has no use for the node's comments and order
+		var value interface{}
+		if err := merged.Decode(&value); err != nil {
+			return fmt.Errorf("failed to decode merged spec: %w", err)
+		}
+		return writeOutput(generator.StringKeys(value), outputPath, format)
+	}
+	return writeOutput(merged, outputPath, format)
+}
+
 func writeOutput(spec interface{}, outputPath, format string) error {
 	dir := filepath.Dir(outputPath)
 	if err := os.MkdirAll(dir, 0755); err != nil {
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/diff"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/notifier"
+)
+
+// loadPreviousSpec reads the spec to diff against, returning nil when there is
+// none yet (every endpoint is then reported as added)
+func loadPreviousSpec(config Config) interface{} {
+	path := config.PreviousSpec
+	if path == "" {
+		path = config.OutputPath
+	}
+
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return nil
+	}
+
+	previous, err := diff.Load(path)
+	if err != nil {
+		fmt.Printf("WARNING: Could not load previous spec: %v\n", err)
+		return nil
+	}
+	return previous
+}
+
+// notifyChanges posts an endpoint change summary to the configured webhook.
+// Notification failures never fail the generation run.
+func notifyChanges(config Config, previous, current interface{}) {
+	result, err := diff.Compare(previous, current)
+	if err != nil {
+		fmt.Printf("WARNING: Could not diff specs: %v\n", err)
+		return
+	}
+
+	if result.Empty() {
+		fmt.Println("No endpoint changes, skipping notification")
+		return
+	}
+
+	if err := notifier.Send(config.NotifyWebhook, result.Summary(config.Title)); err != nil {
+		fmt.Printf("WARNING: %v\n", err)
+		return
+	}
+	fmt.Printf("Notified webhook of %d added, %d changed, %d removed endpoints\n",
+		len(result.Added), len(result.Changed), len(result.Removed))
+}
@@ -0,0 +1,38 @@
+package main
+
+import (
+	"flag"
+	"log"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/publisher"
+)
+
+// runPublish pushes a previously generated spec to the registry configured
+// in the "publish" block of the config file
+func runPublish(args []string) {
+	fs := flag.NewFlagSet("publish", flag.ExitOnError)
+	configPath := fs.String("config", "", "Path to configuration file")
+	specPath := fs.String("spec", "", "Spec file to publish (default: output_path from config)")
+	version := fs.String("version", "", "Version to publish (default: info.version from the spec)")
+	fs.Parse(args)
+
+	if *configPath == "" {
+		log.Fatalf("publish requires -config")
+	}
+
+	var config Config
+	if err := loadConfig(*configPath, &config); err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	if *specPath == "" {
+		*specPath = config.OutputPath
+	}
+	if *specPath == "" {
+		log.Fatalf("No spec to publish: set output_path in the config or pass -spec")
+	}
+
+	if err := publisher.New(config.Publish).Publish(*specPath, *version); err != nil {
+		log.Fatalf("Failed to publish spec: %v", err)
+	}
+}
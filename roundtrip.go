@@ -0,0 +1,54 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/roundtrip"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/scaffold"
+)
+
+// runRoundtripTest scaffolds a project from a spec, generates a spec from the
+// scaffolded code and reports where the two differ
+func runRoundtripTest(args []string) {
+	fs := flag.NewFlagSet("roundtrip-test", flag.ExitOnError)
+	specPath := fs.String("spec", "", "OpenAPI spec to round-trip (yaml or json)")
+	dir := fs.String("dir", "", "Directory to scaffold into (default: a temporary directory)")
+	module := fs.String("module", "example.com/roundtrip", "Go module path for the scaffolded project's imports")
+	keep := fs.Bool("keep", false, "Keep the scaffolded project for inspection")
+	fs.Parse(args)
+
+	if *specPath == "" {
+		log.Fatalf("roundtrip-test requires -spec")
+	}
+
+	spec, err := scaffold.Load(*specPath)
+	if err != nil {
+		log.Fatalf("Failed to load spec: %v", err)
+	}
+
+	result, err := roundtrip.Run(spec, roundtrip.Options{
+		Module: *module,
+		Dir:    *dir,
+		Keep:   *keep || *dir != "",
+	})
+	if err != nil {
+		log.Fatalf("Round trip failed: %v", err)
+	}
+
+	if *keep || *dir != "" {
+		fmt.Printf("Scaffolded project kept in %s\n", result.Dir)
+	}
+
+	if len(result.Differences) == 0 {
+		fmt.Printf("Round trip OK: the generated spec matches %s (ignoring descriptions)\n", *specPath)
+		return
+	}
+	for _, difference := range result.Differences {
+		fmt.Printf("DIFF %s\n", difference)
+	}
+	fmt.Printf("Round trip found %d difference(s)\n", len(result.Differences))
+	os.Exit(1)
+}
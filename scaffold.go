@@ -0,0 +1,48 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/scaffold"
+)
+
+// runScaffold generates a Fiber project skeleton (routes, handler stubs and
+// sdk structs) from an existing OpenAPI spec
+func runScaffold(args []string) {
+	fs := flag.NewFlagSet("scaffold", flag.ExitOnError)
+	specPath := fs.String("spec", "", "OpenAPI spec to scaffold from (yaml or json)")
+	outputDir := fs.String("output", ".", "Project directory to write routes/, sdk/ and middleware/ into")
+	module := fs.String("module", "", "Go module path for imports (default: read from go.mod in the output directory)")
+	force := fs.Bool("force", false, "Overwrite existing files")
+	fs.Parse(args)
+
+	if *specPath == "" {
+		log.Fatalf("scaffold requires -spec")
+	}
+
+	if *module == "" {
+		modulePath, err := scaffold.ModulePath(*outputDir)
+		if err != nil {
+			log.Fatalf("scaffold requires -module or a go.mod in %s: %v", *outputDir, err)
+		}
+		*module = modulePath
+	}
+
+	spec, err := scaffold.Load(*specPath)
+	if err != nil {
+		log.Fatalf("Failed to load spec: %v", err)
+	}
+
+	files, err := scaffold.Generate(spec, *module)
+	if err != nil {
+		log.Fatalf("Failed to scaffold: %v", err)
+	}
+
+	if err := scaffold.Write(*outputDir, files, *force); err != nil {
+		log.Fatalf("Failed to write scaffold: %v", err)
+	}
+
+	fmt.Printf("Scaffolded %d files into %s\n", len(files), *outputDir)
+}
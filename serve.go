@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/docserver"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/generator"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/linter"
+)
+
+// runServe serves Swagger UI for the project's spec, regenerating it when a
+// .go file changes and telling open browsers to reload over a websocket
+func runServe(args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	configPath := fs.String("config", "", "Path to configuration file")
+	projectPath := fs.String("project", ".", "Path to Go project")
+	framework := fs.String("framework", "auto", "Router framework (auto|fiber|gin|echo|chi|mux|httprouter|hertz|iris|nethttp|grpc-gateway)")
+	title := fs.String("title", "VSA API Server", "API title")
+	addr := fs.String("addr", "localhost:8090", "Address to serve the docs on")
+	interval := fs.Duration("interval", time.Second, "How often to check the project for changes")
+	fs.Parse(args)
+
+	config := defaultConfig()
+	config.ProjectPath = *projectPath
+	config.Framework = *framework
+	config.Title = *title
+	if *configPath != "" {
+		config = Config{}
+		if err := loadConfig(*configPath, &config); err != nil {
+			log.Fatalf("Failed to load config: %v", err)
+		}
+	}
+
+	server := docserver.New(config.Title)
+	regenerate := func() error {
+		spec, err := generateSpec(config)
+		if err != nil {
+			return err
+		}
+		return server.Update(spec)
+	}
+	if err := regenerate(); err != nil {
+		log.Fatalf("Failed to generate spec: %v", err)
+	}
+
+	go docserver.Watch(config.ProjectPath, *interval, nil, func() {
+		// Keep serving the last good spec while the code doesn't parse
+		if err := regenerate(); err != nil {
+			fmt.Printf("Warning: failed to regenerate spec: %v\n", err)
+			return
+		}
+		fmt.Printf("Spec regenerated, reloading %d browser(s)\n", server.Clients())
+	})
+
+	fmt.Printf("Serving docs for %s at http://%s/ (watching %s)\n", config.Title, *addr, config.ProjectPath)
+	log.Fatal(http.ListenAndServe(*addr, server.Handler()))
+}
+
+// generateSpec analyzes the project and generates its spec in memory,
+// within the configured timeout
+func generateSpec(config Config) (*generator.OpenAPISpec, error) {
+	ctx, cancel, err := runContext(config)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timeout: %w", err)
+	}
+	defer cancel()
+
+	analysis, err := analyzeProject(ctx, config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze project: %w", err)
+	}
+	generatorConfig, err := newGeneratorConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load CODEOWNERS: %w", err)
+	}
+
+	specGenerator := generator.New(generatorConfig)
+	spec, err := specGenerator.Generate(ctx, analysis)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate spec: %w", err)
+	}
+	if err := specGenerator.MergeFragments(spec, config.Fragments); err != nil {
+		return nil, err
+	}
+	if config.Lint.Normalizes() {
+		linter.NormalizeParams(spec, config.Lint)
+	}
+	return spec, nil
+}
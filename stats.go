@@ -0,0 +1,147 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/analyzer"
+	"github.com/Aman-s12345/go-openapispec-generator/internal/stats"
+)
+
+// recordStats appends the run's timings and type resolution counts to the
+// stats file
+func recordStats(config Config, analysis *analyzer.Analysis, analyzeTime, generateTime time.Duration) error {
+	record := stats.Record{
+		Time:            time.Now().UTC(),
+		ToolVersion:     currentVersion(),
+		Project:         config.ProjectPath,
+		Framework:       analysis.Framework,
+		AnalyzeMillis:   analyzeTime.Milliseconds(),
+		GenerateMillis:  generateTime.Milliseconds(),
+		Routes:          len(analysis.Routes),
+		Models:          len(analysis.Models),
+		Attempts:        analysis.Stats.Attempts,
+		Resolved:        analysis.Stats.Resolved,
+		Matched:         analysis.Stats.Matched,
+		Unresolved:      len(analysis.Stats.Unresolved),
+		UnresolvedTypes: analysis.Stats.Unresolved,
+		TypeChecked:     analysis.Stats.TypeChecked,
+		TypeCheckFailed: analysis.Stats.TypeCheckFailed,
+	}
+	if err := stats.Append(config.StatsFile, record); err != nil {
+		return err
+	}
+	fmt.Printf("Usage statistics recorded: %s\n", config.StatsFile)
+	return nil
+}
+
+// runStats prints the runs recorded in a stats file with the trends of
+// their timings and type resolution
+func runStats(args []string) {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	file := fs.String("file", stats.DefaultName, "Stats file written with -stats")
+	last := fs.Int("last", 20, "Number of most recent runs to show (0: all)")
+	project := fs.String("project", "", "Only show runs of this project path")
+	fs.Parse(args)
+
+	records, err := stats.Read(*file)
+	if err != nil {
+		log.Fatalf("Failed to load stats: %v", err)
+	}
+	if *project != "" {
+		var matching []stats.Record
+		for _, record := range records {
+			if record.Project == *project {
+				matching = append(matching, record)
+			}
+		}
+		records = matching
+	}
+	if len(records) == 0 {
+		fmt.Printf("No runs recorded in %s\n", *file)
+		return
+	}
+	if *last > 0 && len(records) > *last {
+		records = records[len(records)-*last:]
+	}
+
+	writer := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(writer, "TIME\tVERSION\tROUTES\tMODELS\tANALYZE\tGENERATE\tHIT RATE\tUNRESOLVED")
+	for _, record := range records {
+		fmt.Fprintf(writer, "%s\t%s\t%d\t%d\t%dms\t%dms\t%.1f%%\t%d\n",
+			record.Time.Local().Format("2006-01-02 15:04"), record.ToolVersion, record.Routes, record.Models,
+			record.AnalyzeMillis, record.GenerateMillis, record.HitRate()*100, record.Unresolved)
+	}
+	writer.Flush()
+
+	if len(records) > 1 {
+		fmt.Printf("\nTrends over %d runs (oldest to newest):\n", len(records))
+		printTrend("analyze time", records, "%.0fms", func(r stats.Record) float64 { return float64(r.AnalyzeMillis) })
+		printTrend("generate time", records, "%.0fms", func(r stats.Record) float64 { return float64(r.GenerateMillis) })
+		printTrend("hit rate", records, "%.1f%%", func(r stats.Record) float64 { return r.HitRate() * 100 })
+		printTrend("unresolved", records, "%.0f", func(r stats.Record) float64 { return float64(r.Unresolved) })
+	}
+
+	latest := records[len(records)-1]
+	if total := latest.ResolvedTotal(); total > 0 || len(latest.Attempts) > 0 {
+		fmt.Println("\nTypes inferred by heuristic in the latest run (hits/attempts):")
+		var heuristics []string
+		for heuristic := range latest.Resolved {
+			heuristics = append(heuristics, heuristic)
+		}
+		for heuristic := range latest.Attempts {
+			if _, hit := latest.Resolved[heuristic]; !hit {
+				heuristics = append(heuristics, heuristic)
+			}
+		}
+		sort.Slice(heuristics, func(i, j int) bool {
+			if latest.Resolved[heuristics[i]] != latest.Resolved[heuristics[j]] {
+				return latest.Resolved[heuristics[i]] > latest.Resolved[heuristics[j]]
+			}
+			return heuristics[i] < heuristics[j]
+		})
+		for _, heuristic := range heuristics {
+			count, attempts := latest.Resolved[heuristic], latest.Attempts[heuristic]
+			if attempts == 0 {
+				// Runs recorded before attempts were counted
+				fmt.Printf("  %-18s %4d  %5.1f%% of inferred\n", heuristic, count, float64(count)/float64(total)*100)
+				continue
+			}
+			fmt.Printf("  %-18s %4d/%-5d  %5.1f%% hit rate\n", heuristic, count, attempts, float64(count)/float64(attempts)*100)
+		}
+	}
+	if latest.TypeChecked+latest.TypeCheckFailed > 0 {
+		fmt.Printf("\nPackages type-checked in the latest run: %d (%d failed)\n", latest.TypeChecked, latest.TypeCheckFailed)
+	}
+	if len(latest.UnresolvedTypes) > 0 {
+		fmt.Printf("\nUnresolved types in the latest run: %s\n", strings.Join(uniqueSorted(latest.UnresolvedTypes), ", "))
+	}
+}
+
+// printTrend prints a metric's sparkline with its first and latest value
+func printTrend(name string, records []stats.Record, format string, metric func(stats.Record) float64) {
+	values := make([]float64, len(records))
+	for i, record := range records {
+		values[i] = metric(record)
+	}
+	fmt.Printf("  %-14s %s  "+format+" -> "+format+"\n", name, stats.Sparkline(values), values[0], values[len(values)-1])
+}
+
+func uniqueSorted(values []string) []string {
+	seen := make(map[string]bool)
+	var unique []string
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	sort.Strings(unique)
+	return unique
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"regexp"
+	"runtime"
+	"runtime/debug"
+
+	"github.com/Aman-s12345/go-openapispec-generator/internal/selfupdate"
+)
+
+// toolVersion is the generator's version; release builds set it with
+// -ldflags "-X main.toolVersion=v1.2.3"
+var toolVersion = "dev"
+
+// pseudoVersion matches the versions Go stamps on builds of a checkout,
+// v0.0.0-20240102150405-abcdef123456, which aren't releases
+var pseudoVersion = regexp.MustCompile(`\d{14}-[0-9a-f]{12}|\+dirty`)
+
+// currentVersion returns the release version, or the module version for
+// binaries built with go install ...@v1.2.3, or dev
+func currentVersion() string {
+	if toolVersion != "dev" {
+		return toolVersion
+	}
+	if info, ok := debug.ReadBuildInfo(); ok && info.Main.Version != "" && info.Main.Version != "(devel)" && !pseudoVersion.MatchString(info.Main.Version) {
+		return info.Main.Version
+	}
+	return toolVersion
+}
+
+// runVersion prints the generator's version
+func runVersion(args []string) {
+	fs := flag.NewFlagSet("version", flag.ExitOnError)
+	fs.Parse(args)
+	fmt.Printf("go-openapi-generator %s (%s %s/%s)\n", currentVersion(), runtime.Version(), runtime.GOOS, runtime.GOARCH)
+}
+
+// runSelfUpdate replaces the running binary with a release, the latest one
+// unless -version pins it
+func runSelfUpdate(args []string) {
+	fs := flag.NewFlagSet("self-update", flag.ExitOnError)
+	version := fs.String("version", "", "Release to install, e.g. v1.4.0 (default: latest)")
+	releasesURL := fs.String("releases-url", selfupdate.DefaultReleasesURL, "Releases page to download binaries from")
+	checkOnly := fs.Bool("check", false, "Only report whether a newer release is available")
+	fs.Parse(args)
+
+	updater := selfupdate.New(*releasesURL)
+	tag := *version
+	if tag == "" {
+		latest, err := updater.Latest()
+		if err != nil {
+			log.Fatalf("Failed to check for updates: %v", err)
+		}
+		tag = latest
+	}
+
+	current := currentVersion()
+	if current == tag {
+		fmt.Printf("Already at %s\n", current)
+		return
+	}
+	if *checkOnly {
+		fmt.Printf("Running %s; %s is available\n", current, tag)
+		return
+	}
+
+	executable, err := updater.Update(tag)
+	if err != nil {
+		log.Fatalf("Failed to update: %v", err)
+	}
+	fmt.Printf("Updated %s from %s to %s\n", executable, current, tag)
+}
+
+// checkRequiredVersion fails when the running binary doesn't satisfy the
+// config's required_version. Development builds can't be checked and only
+// warn.
+func checkRequiredVersion(required string) error {
+	if required == "" {
+		return nil
+	}
+	current := currentVersion()
+	if current == "dev" {
+		// A malformed constraint is still an error
+		if _, err := selfupdate.Satisfies("v0.0.0", required); err != nil {
+			return err
+		}
+		fmt.Printf("Warning: development build; required_version %q not checked\n", required)
+		return nil
+	}
+	ok, err := selfupdate.Satisfies(current, required)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return fmt.Errorf("generator %s does not satisfy required_version %q; install a matching release with self-update -version", current, required)
+	}
+	return nil
+}